@@ -0,0 +1,147 @@
+package httpretry
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrConcurrencyLimited 호스트의 동시 요청 수가 적응형 제한에 도달하여 요청하지 않음
+var ErrConcurrencyLimited = errors.New("concurrency limited")
+
+const (
+	// adaptiveSmoothing 새 제한값을 반영하는 비율
+	adaptiveSmoothing = 0.2
+	// adaptiveLongWindow 기준 지연 시간(long RTT)의 지수 평균 표본 수
+	adaptiveLongWindow = 600
+	// adaptiveBackoffRatio 재시도를 포기한 요청마다 제한을 줄이는 비율
+	adaptiveBackoffRatio = 0.9
+)
+
+type (
+	// AdaptiveConcurrency 지연 시간 변화로 호스트별 동시 요청 수 제한을 조절하는 정책 (Gradient2)
+	//
+	// 최근 지연 시간이 기준 지연 시간(long RTT)보다 늘어나면 제한을 줄이고, 같거나 줄어들면 제한을 늘립니다.
+	// 재시도를 포기한 요청은 과부하로 보고 제한을 줄입니다.
+	AdaptiveConcurrency struct {
+		// InitialLimit 초기 동시 요청 수 제한. 0인 경우 20
+		InitialLimit int
+		// MinLimit 최소 동시 요청 수 제한. 0인 경우 1
+		MinLimit int
+		// MaxLimit 최대 동시 요청 수 제한. 0인 경우 200
+		MaxLimit int
+		// Tolerance 기준 지연 시간 대비 허용하는 지연 시간 비율. 0인 경우 1.5
+		Tolerance float64
+	}
+
+	// adaptiveLimit 호스트 하나의 적응형 동시 요청 수 제한
+	adaptiveLimit struct {
+		policy   AdaptiveConcurrency
+		mu       sync.Mutex
+		limit    float64
+		inFlight int
+		longRTT  float64
+		samples  int
+	}
+
+	// adaptiveLimits 호스트별 적응형 동시 요청 수 제한
+	adaptiveLimits struct {
+		policy AdaptiveConcurrency
+		mu     sync.Mutex
+		hosts  map[string]*adaptiveLimit
+	}
+)
+
+// newAdaptiveLimits 정책이 설정되지 않으면 nil을 반환
+func newAdaptiveLimits(policy *AdaptiveConcurrency) *adaptiveLimits {
+	if policy == nil {
+		return nil
+	}
+	p := *policy
+	if p.InitialLimit <= 0 {
+		p.InitialLimit = 20
+	}
+	if p.MinLimit <= 0 {
+		p.MinLimit = 1
+	}
+	if p.MaxLimit <= 0 {
+		p.MaxLimit = 200
+	}
+	if p.Tolerance <= 0 {
+		p.Tolerance = 1.5
+	}
+	return &adaptiveLimits{policy: p, hosts: make(map[string]*adaptiveLimit)}
+}
+
+// get 호스트의 제한을 반환
+func (a *adaptiveLimits) get(host string) *adaptiveLimit {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	limit, ok := a.hosts[host]
+	if !ok {
+		limit = &adaptiveLimit{policy: a.policy, limit: float64(a.policy.InitialLimit)}
+		a.hosts[host] = limit
+	}
+	return limit
+}
+
+// acquire 동시 요청 수가 제한보다 적으면 자리를 얻음
+func (l *adaptiveLimit) acquire(host string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.inFlight >= int(l.limit) {
+		return fmt.Errorf("host(%s) limit %d: %w", host, int(l.limit), ErrConcurrencyLimited)
+	}
+	l.inFlight++
+	return nil
+}
+
+// succeed 자리를 반환하고, 응답까지 걸린 시간으로 제한을 조절
+func (l *adaptiveLimit) succeed(rtt time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight--
+
+	short := float64(rtt)
+	if short <= 0 {
+		return
+	}
+	if l.samples < adaptiveLongWindow {
+		l.samples++
+	}
+	if l.longRTT == 0 {
+		l.longRTT = short
+	} else {
+		l.longRTT += (short - l.longRTT) / float64(l.samples)
+	}
+	// 지연 시간이 크게 늘어난 상태가 이어지면 기준 지연 시간이 따라 올라가지 않도록 낮춤
+	if l.longRTT/short > 2 {
+		l.longRTT *= 0.95
+	}
+
+	gradient := max(0.5, min(1.0, l.policy.Tolerance*l.longRTT/short))
+	next := l.limit*gradient + math.Sqrt(l.limit)
+	l.set(l.limit*(1-adaptiveSmoothing) + next*adaptiveSmoothing)
+}
+
+// fail 자리를 반환하고, 과부하로 보고 제한을 줄임
+func (l *adaptiveLimit) fail() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight--
+	l.set(l.limit * adaptiveBackoffRatio)
+}
+
+// release 제한을 조절하지 않고 자리를 반환. 호출자가 취소한 요청 등
+func (l *adaptiveLimit) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight--
+}
+
+// set MinLimit, MaxLimit 범위로 제한을 설정
+func (l *adaptiveLimit) set(limit float64) {
+	l.limit = min(max(limit, float64(l.policy.MinLimit)), float64(l.policy.MaxLimit))
+}
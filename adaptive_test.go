@@ -0,0 +1,96 @@
+package httpretry_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithAdaptiveConcurrency(t *testing.T) {
+	t.Run("제한에 도달하면 거절하고, 응답 바디를 닫으면 자리를 반환하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithAdaptiveConcurrency(httpretry.AdaptiveConcurrency{InitialLimit: 1}),
+		))
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+		// when
+		first, firstErr := client.Do(req)
+		_, limitedErr := client.Do(req)
+		first.Body.Close()
+		second, secondErr := client.Do(req)
+
+		// then
+		assert.NoError(t, firstErr)
+		assert.ErrorIs(t, limitedErr, httpretry.ErrConcurrencyLimited)
+		assert.NoError(t, secondErr)
+		second.Body.Close()
+	})
+
+	t.Run("지연 시간이 일정하면 제한을 늘리는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithAdaptiveConcurrency(httpretry.AdaptiveConcurrency{InitialLimit: 1, MaxLimit: 10}),
+		))
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		for range 20 {
+			resp, err := client.Do(req)
+			assert.NoError(t, err)
+			resp.Body.Close()
+		}
+
+		// when
+		first, firstErr := client.Do(req)
+		second, secondErr := client.Do(req)
+
+		// then
+		assert.NoError(t, firstErr)
+		assert.NoError(t, secondErr)
+		first.Body.Close()
+		second.Body.Close()
+	})
+
+	t.Run("재시도를 포기하면 제한을 줄이는 테스트", func(t *testing.T) {
+		// given
+		var failing atomic.Bool
+		failing.Store(true)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if failing.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(1),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithAdaptiveConcurrency(httpretry.AdaptiveConcurrency{InitialLimit: 2}),
+		))
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		_, err := client.Do(req)
+		assert.Error(t, err)
+		failing.Store(false)
+
+		// when
+		first, firstErr := client.Do(req)
+		_, limitedErr := client.Do(req)
+
+		// then
+		assert.NoError(t, firstErr)
+		first.Body.Close()
+		assert.ErrorIs(t, limitedErr, httpretry.ErrConcurrencyLimited)
+	})
+}
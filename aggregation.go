@@ -0,0 +1,111 @@
+package httpretry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// ErrorAggregation 재시도를 포기한 요청의 최종 에러를 구성하는 방식
+type ErrorAggregation string
+
+const (
+	// ErrorAggregationAll 모든 시도의 에러를 포함 (기본값)
+	ErrorAggregationAll ErrorAggregation = "all"
+	// ErrorAggregationLast 마지막 시도와 그 이후의 에러(최대 재시도 횟수 초과 등)만 포함
+	ErrorAggregationLast ErrorAggregation = "last"
+	// ErrorAggregationSummary 모든 시도의 에러를 포함하되, 메시지는 "4 attempts: 2x 503, 1x timeout, 1x conn refused"처럼 요약
+	ErrorAggregationSummary ErrorAggregation = "summary"
+)
+
+// lastAttemptErrors 마지막 시도의 에러부터 이후의 에러만 남김. 시도 에러가 없으면 그대로 반환
+func lastAttemptErrors(errs []error) []error {
+	for i := len(errs) - 1; i >= 0; i-- {
+		if _, ok := errs[i].(*AttemptError); ok {
+			return errs[i:]
+		}
+	}
+	return errs
+}
+
+// joinMessages 에러 메시지를 "; "로 이어 한 줄로 만듦
+func joinMessages(errs []error) string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// summarizeErrors 시도 수와 시도별 실패 원인을 횟수로 묶은 요약 메시지를 생성
+//
+// 실패 원인은 처음 나온 순서대로 나열하며, 시도와 관련 없는 에러는 뒤에 덧붙입니다.
+func summarizeErrors(attempts int, errs []error) string {
+	var (
+		causes []string
+		counts = make(map[string]int)
+		others []string
+	)
+	for _, err := range errs {
+		attemptErr, ok := err.(*AttemptError)
+		if !ok {
+			others = append(others, err.Error())
+			continue
+		}
+		cause := attemptCause(attemptErr)
+		if counts[cause] == 0 {
+			causes = append(causes, cause)
+		}
+		counts[cause]++
+	}
+
+	var b strings.Builder
+	if attempts == 1 {
+		b.WriteString("1 attempt")
+	} else {
+		fmt.Fprintf(&b, "%d attempts", attempts)
+	}
+	for i, cause := range causes {
+		if i == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%dx %s", counts[cause], cause)
+	}
+	for _, other := range others {
+		b.WriteString("; ")
+		b.WriteString(other)
+	}
+	return b.String()
+}
+
+// attemptCause 요약 메시지에 사용할 시도의 짧은 실패 원인
+func attemptCause(err *AttemptError) string {
+	if err.StatusCode > 0 {
+		return strconv.Itoa(err.StatusCode)
+	}
+	var netErr net.Error
+	switch {
+	case errors.Is(err.Err, context.DeadlineExceeded), errors.As(err.Err, &netErr) && netErr.Timeout():
+		return "timeout"
+	case errors.Is(err.Err, context.Canceled):
+		return "cancelled"
+	case errors.Is(err.Err, syscall.ECONNREFUSED):
+		return "conn refused"
+	case errors.Is(err.Err, syscall.ECONNRESET):
+		return "conn reset"
+	case errors.Is(err.Err, io.EOF), errors.Is(err.Err, io.ErrUnexpectedEOF):
+		return "eof"
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err.Err, &dnsErr) {
+		return "dns error"
+	}
+	return "error"
+}
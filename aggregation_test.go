@@ -0,0 +1,88 @@
+package httpretry_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithErrorAggregation(t *testing.T) {
+	// flakyServer 503을 두 번 응답한 뒤 502를 응답하는 서버
+	flakyServer := func(t *testing.T) *httptest.Server {
+		var received atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if received.Add(1) <= 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+	retryError := func(t *testing.T, err error) *httpretry.RetryError {
+		var retryErr *httpretry.RetryError
+		assert.True(t, errors.As(err, &retryErr))
+		return retryErr
+	}
+
+	t.Run("ErrorAggregationSummary는 실패 원인을 횟수로 묶어 요약하는 테스트", func(t *testing.T) {
+		// given
+		server := flakyServer(t)
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithErrorAggregation(httpretry.ErrorAggregationSummary),
+		))
+
+		// when
+		_, err := client.Get(server.URL)
+
+		// then
+		retryErr := retryError(t, err)
+		assert.Equal(t, "3 attempts: 2x 503, 1x 502; max retries reached", retryErr.Error())
+		assert.Len(t, retryErr.Report().Attempts, 3, "요약해도 모든 시도의 에러를 유지해야 합니다.")
+	})
+
+	t.Run("ErrorAggregationSummary는 전송 에러를 짧은 원인으로 요약하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithErrorAggregation(httpretry.ErrorAggregationSummary),
+		))
+
+		// when
+		_, err := client.Get(server.URL)
+
+		// then
+		assert.Equal(t, "2 attempts: 2x conn refused; max retries reached", retryError(t, err).Error())
+	})
+
+	t.Run("ErrorAggregationLast는 마지막 시도의 에러만 포함하는 테스트", func(t *testing.T) {
+		// given
+		server := flakyServer(t)
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithErrorAggregation(httpretry.ErrorAggregationLast),
+		))
+
+		// when
+		_, err := client.Get(server.URL)
+
+		// then
+		retryErr := retryError(t, err)
+		assert.Equal(t, 3, retryErr.Attempts)
+		assert.NotContains(t, retryErr.Error(), "503")
+		var attemptErr *httpretry.AttemptError
+		assert.True(t, errors.As(err, &attemptErr))
+		assert.Equal(t, 3, attemptErr.Attempt)
+		assert.Equal(t, http.StatusBadGateway, attemptErr.StatusCode)
+	})
+}
@@ -0,0 +1,97 @@
+package httpretry
+
+import (
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+const (
+	// HeaderB3 B3 single header
+	HeaderB3 = "B3"
+	// HeaderB3TraceID B3 multi header의 TraceId
+	HeaderB3TraceID = "X-B3-Traceid"
+	// HeaderB3SpanID B3 multi header의 SpanId
+	HeaderB3SpanID = "X-B3-Spanid"
+	// HeaderB3ParentSpanID B3 multi header의 ParentSpanId
+	HeaderB3ParentSpanID = "X-B3-Parentspanid"
+	// HeaderB3Sampled B3 multi header의 Sampled
+	HeaderB3Sampled = "X-B3-Sampled"
+	// HeaderB3Flags B3 multi header의 Flags (debug)
+	HeaderB3Flags = "X-B3-Flags"
+)
+
+// b3Propagator B3 (Zipkin) 전파 방식
+//
+// 추출은 single header와 multi header를 모두 지원하며, single header를 우선합니다.
+type b3Propagator struct {
+	single bool
+}
+
+var (
+	// B3 B3 multi header (X-B3-TraceId, X-B3-SpanId, X-B3-Sampled) 전파 방식
+	B3 Propagator = b3Propagator{}
+	// B3Single B3 single header (b3: {TraceId}-{SpanId}-{Sampled}) 전파 방식
+	B3Single Propagator = b3Propagator{single: true}
+)
+
+// Inject B3 헤더를 설정
+//
+// 기존 헤더와 SpanId가 달라지면 기존 ParentSpanId는 맞지 않으므로 삭제합니다.
+func (p b3Propagator) Inject(header http.Header, sc SpanContext) {
+	traceID, spanID := hex.EncodeToString(sc.TraceID[:]), hex.EncodeToString(sc.SpanID[:])
+	sampled := "0"
+	if sc.Sampled {
+		sampled = "1"
+	}
+	if p.single {
+		header.Set(HeaderB3, traceID+"-"+spanID+"-"+sampled)
+		return
+	}
+	if header.Get(HeaderB3SpanID) != spanID {
+		header.Del(HeaderB3ParentSpanID)
+	}
+	header.Set(HeaderB3TraceID, traceID)
+	header.Set(HeaderB3SpanID, spanID)
+	header.Set(HeaderB3Sampled, sampled)
+	header.Del(HeaderB3Flags)
+}
+
+// Extract B3 헤더를 읽음. 64bit TraceId는 앞을 0으로 채움
+func (b3Propagator) Extract(header http.Header) (SpanContext, bool) {
+	if single := header.Get(HeaderB3); single != "" {
+		parts := strings.Split(single, "-")
+		if len(parts) < 2 {
+			return SpanContext{}, false
+		}
+		sampled := ""
+		if len(parts) > 2 {
+			sampled = parts[2]
+		}
+		return extractB3(parts[0], parts[1], sampled)
+	}
+	sampled := header.Get(HeaderB3Sampled)
+	if header.Get(HeaderB3Flags) == "1" {
+		sampled = "d"
+	}
+	return extractB3(header.Get(HeaderB3TraceID), header.Get(HeaderB3SpanID), sampled)
+}
+
+// extractB3 B3 TraceId, SpanId, Sampled 값을 SpanContext로 변환
+func extractB3(traceID, spanID, sampled string) (SpanContext, bool) {
+	var sc SpanContext
+	if len(traceID) == 16 {
+		traceID = strings.Repeat("0", 16) + traceID
+	}
+	if !decodeHex(sc.TraceID[:], traceID) || !decodeHex(sc.SpanID[:], spanID) || !sc.IsValid() {
+		return SpanContext{}, false
+	}
+	switch sampled {
+	case "1", "true", "d":
+		sc.Sampled = true
+	case "", "0", "false":
+	default:
+		return SpanContext{}, false
+	}
+	return sc, true
+}
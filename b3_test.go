@@ -0,0 +1,85 @@
+package httpretry_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestB3(t *testing.T) {
+	t.Run("B3 multi header를 추출하고 다시 주입하는 테스트", func(t *testing.T) {
+		// given
+		header := http.Header{}
+		header.Set("X-B3-TraceId", "4bf92f3577b34da6a3ce929d0e0e4736")
+		header.Set("X-B3-SpanId", "00f067aa0ba902b7")
+		header.Set("X-B3-Sampled", "1")
+
+		// when
+		sc, ok := httpretry.B3.Extract(header)
+		injected := http.Header{}
+		httpretry.B3.Inject(injected, sc)
+
+		// then
+		assert.True(t, ok)
+		assert.True(t, sc.Sampled)
+		assert.Equal(t, header, injected)
+	})
+
+	t.Run("B3 single header와 64bit TraceId를 추출하는 테스트", func(t *testing.T) {
+		// given
+		header := http.Header{}
+		header.Set("b3", "a3ce929d0e0e4736-00f067aa0ba902b7-d")
+
+		// when
+		sc, ok := httpretry.B3.Extract(header)
+		injected := http.Header{}
+		httpretry.B3Single.Inject(injected, sc)
+
+		// then
+		assert.True(t, ok)
+		assert.True(t, sc.Sampled)
+		assert.Equal(t, "0000000000000000a3ce929d0e0e4736-00f067aa0ba902b7-1", injected.Get("b3"))
+	})
+
+	t.Run("SpanId가 바뀌면 ParentSpanId를 삭제하는 테스트", func(t *testing.T) {
+		// given
+		header := http.Header{}
+		header.Set("X-B3-SpanId", "00f067aa0ba902b7")
+		header.Set("X-B3-ParentSpanId", "0000000000000001")
+
+		// when
+		httpretry.B3.Inject(header, httpretry.SpanContext{TraceID: [16]byte{15: 1}, SpanID: [8]byte{7: 2}})
+
+		// then
+		assert.Empty(t, header.Get("X-B3-ParentSpanId"))
+		assert.Equal(t, "0", header.Get("X-B3-Sampled"))
+	})
+
+	t.Run("W3C traceparent를 B3 헤더로도 전파하는 테스트", func(t *testing.T) {
+		// given
+		var received http.Header
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r.Header.Clone()
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithPropagators(httpretry.TraceContext, httpretry.B3),
+		))
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		req.Header.Set(httpretry.HeaderTraceparent, traceparent)
+
+		// when
+		resp, err := client.Do(req)
+
+		// then
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, traceparent, received.Get(httpretry.HeaderTraceparent))
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", received.Get("X-B3-TraceId"))
+		assert.Equal(t, "00f067aa0ba902b7", received.Get("X-B3-SpanId"))
+		assert.Equal(t, "1", received.Get("X-B3-Sampled"))
+	})
+}
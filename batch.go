@@ -0,0 +1,64 @@
+package httpretry
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// BatchResult 배치 요청 1건의 결과
+//
+// Err가 nil인 경우, 호출자가 Response의 바디를 읽고 닫아야 합니다.
+type BatchResult struct {
+	Response *http.Response
+	Err      error
+}
+
+// DoBatch 요청들을 최대 concurrency개씩 병렬로 실행하고, 요청과 같은 순서로 결과를 반환
+//
+// 재시도 정책은 요청마다 개별로 적용됩니다. ctx가 취소되면 아직 시작하지 않은 요청은 실행하지 않고 ctx의 에러를 결과로 반환합니다.
+//
+// Parameters:
+//   - ctx: (context.Context) 배치 실행 context
+//   - client: (Doer) 요청에 사용할 클라이언트. 보통 NewClient로 생성한 재시도 클라이언트
+//   - requests: ([]*http.Request) 실행할 요청 목록
+//   - concurrency: (int) 최대 동시 실행 수. 0 이하인 경우 모든 요청을 동시에 실행
+func DoBatch(
+	ctx context.Context,
+	client Doer,
+	requests []*http.Request,
+	concurrency int,
+) []BatchResult {
+	if concurrency <= 0 || concurrency > len(requests) {
+		concurrency = len(requests)
+	}
+
+	results := make([]BatchResult, len(requests))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, req := range requests {
+		if err := ctx.Err(); err != nil {
+			results[i] = BatchResult{Err: err}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			results[i] = BatchResult{Err: ctx.Err()}
+			continue
+		case semaphore <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			resp, err := client.Do(req)
+			results[i] = BatchResult{Response: resp, Err: err}
+		}()
+	}
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,91 @@
+package httpretry_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoBatch(t *testing.T) {
+	t.Run("동시 실행 수를 제한하며 요청 순서대로 결과를 반환하는 테스트", func(t *testing.T) {
+		// given
+		var (
+			inFlight    atomic.Int32
+			maxInFlight atomic.Int32
+			mu          sync.Mutex
+			failed      = map[string]bool{}
+		)
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				current := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					observed := maxInFlight.Load()
+					if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+
+				// 각 요청은 처음 한 번 실패한 뒤 성공
+				mu.Lock()
+				first := !failed[r.URL.Path]
+				failed[r.URL.Path] = true
+				mu.Unlock()
+				if first {
+					w.WriteHeader(http.StatusBadGateway)
+					return
+				}
+				w.Write([]byte(r.URL.Path))
+			}),
+		)
+		defer testServer.Close()
+
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(3),
+				httpretry.WithBackoffPolicy(noBackoff),
+			),
+		)
+		var requests []*http.Request
+		for i := range 8 {
+			req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/item/%d", testServer.URL, i), nil)
+			requests = append(requests, req)
+		}
+
+		// when
+		results := httpretry.DoBatch(context.Background(), retryClient, requests, 3)
+
+		// then
+		assert.Len(t, results, len(requests))
+		for i, result := range results {
+			assert.NoError(t, result.Err)
+			body, _ := io.ReadAll(result.Response.Body)
+			result.Response.Body.Close()
+			assert.Equal(t, fmt.Sprintf("/item/%d", i), string(body))
+		}
+		assert.LessOrEqual(t, maxInFlight.Load(), int32(3))
+	})
+
+	t.Run("context 취소 시, 시작하지 않은 요청은 에러 반환 테스트", func(t *testing.T) {
+		// given
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+
+		// when
+		results := httpretry.DoBatch(ctx, httpretry.NewClient(nil), []*http.Request{req}, 1)
+
+		// then
+		assert.ErrorIs(t, results[0].Err, context.Canceled)
+	})
+}
@@ -0,0 +1,220 @@
+package httpretry
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// BodyInspector 응답 바디의 앞부분을 검사하여 재시도 여부를 판단하는 함수
+//
+// 200 응답에 {"error":"try_again"}을 담아 보내는 upstream처럼 상태 코드만으로 판단할 수 없는 경우에 사용합니다.
+// true를 반환하면 재시도합니다.
+type BodyInspector func(resp *http.Response, peek []byte) bool
+
+var (
+	// errSpillReleased 임시 파일에 저장한 요청 바디를 이미 삭제했음을 나타내는 에러
+	errSpillReleased = errors.New("spilled request body already released")
+	// errBodyNotReplayable 최대 크기를 넘어 재시도를 위해 보관하지 않은 요청 바디를 다시 읽으려는 경우의 에러
+	errBodyNotReplayable = errors.New("request body exceeds the max replayable size")
+)
+
+type (
+	// bodySpill 메모리에 버퍼링하기에 큰 요청 바디를 임시 파일에 저장하는 설정
+	bodySpill struct {
+		// threshold 임시 파일에 저장하는 바디 크기 (bytes). 0인 경우 항상 메모리에 버퍼링
+		threshold int64
+		// dir 임시 파일을 생성할 디렉터리. 비어 있으면 os.TempDir
+		dir string
+	}
+
+	// spooledBody 임시 파일에 저장한 요청 바디
+	//
+	// 요청과 열린 reader가 모두 끝나면 파일을 닫고 삭제합니다.
+	spooledBody struct {
+		mu   sync.Mutex
+		file *os.File
+		size int64
+		refs int
+	}
+
+	// spooledReader 임시 파일의 처음부터 읽는 reader. 닫으면 참조를 반환
+	spooledReader struct {
+		*io.SectionReader
+		close func()
+	}
+)
+
+// replayableBody 재시도 시 요청 바디를 다시 생성하는 함수와, 요청이 끝나면 호출할 정리 함수를 반환
+//
+// 요청에 GetBody가 있으면 그대로 사용하고, 없으면 바디를 메모리에 버퍼링합니다.
+// 바디가 spill.threshold를 넘으면 임시 파일에 저장하며, 정리 함수를 호출한 뒤 열린 reader가 모두 닫히면 삭제합니다.
+// 바디가 maxReplayable을 넘으면 버퍼링하지 않고 한 번만 전송하며, replayable이 false이므로 재시도하지 않아야 합니다.
+// 바디가 없는 요청은 nil을 반환합니다.
+func replayableBody(
+	req *http.Request,
+	spill bodySpill,
+	maxReplayable int64,
+) (getBody func() (io.ReadCloser, error), release func(), replayable bool, err error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, func() {}, true, nil
+	}
+	if req.GetBody != nil {
+		return req.GetBody, func() {}, true, nil
+	}
+	// 크기를 미리 알 수 있는 큰 바디는 읽지 않고 그대로 전송
+	if maxReplayable > 0 && req.ContentLength > maxReplayable {
+		getBody, release = streamOnce(req.Body)
+		return getBody, release, false, nil
+	}
+
+	// 임시 파일 저장 기준과 최대 크기 중 작은 크기까지만 메모리에 버퍼링
+	limit := spill.threshold
+	if maxReplayable > 0 && (limit <= 0 || maxReplayable < limit) {
+		limit = maxReplayable
+	}
+	reader := io.Reader(req.Body)
+	if limit > 0 {
+		reader = io.LimitReader(req.Body, limit+1)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		req.Body.Close()
+		return nil, nil, false, fmt.Errorf("buffering request body: %w", err)
+	}
+	if limit <= 0 || int64(len(data)) <= limit {
+		req.Body.Close()
+		return func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		}, func() {}, true, nil
+	}
+
+	rest := io.MultiReader(bytes.NewReader(data), req.Body)
+	if maxReplayable > 0 && int64(len(data)) > maxReplayable {
+		getBody, release = streamOnce(readCloser(rest, req.Body))
+		return getBody, release, false, nil
+	}
+	if maxReplayable > 0 {
+		rest = io.LimitReader(rest, maxReplayable+1)
+	}
+	spooled, err := spoolBody(spill.dir, rest)
+	if err != nil {
+		req.Body.Close()
+		return nil, nil, false, err
+	}
+	if maxReplayable > 0 && spooled.size > maxReplayable {
+		// 임시 파일에 저장한 앞부분에 나머지 바디를 이어 한 번만 전송
+		stored, _ := spooled.open()
+		spooled.release()
+		getBody, release = streamOnce(readCloser(io.MultiReader(stored, req.Body), closerFunc(func() error {
+			stored.Close()
+			return req.Body.Close()
+		})))
+		return getBody, release, false, nil
+	}
+	req.Body.Close()
+	return spooled.open, spooled.release, true, nil
+}
+
+// streamOnce 바디를 한 번만 반환하는 함수와, 반환하지 않은 바디를 닫는 정리 함수를 생성
+func streamOnce(body io.ReadCloser) (func() (io.ReadCloser, error), func()) {
+	var taken atomic.Bool
+	getBody := func() (io.ReadCloser, error) {
+		if taken.Swap(true) {
+			return nil, errBodyNotReplayable
+		}
+		return body, nil
+	}
+	release := func() {
+		if !taken.Swap(true) {
+			body.Close()
+		}
+	}
+	return getBody, release
+}
+
+// readCloser reader와 closer를 묶은 io.ReadCloser
+func readCloser(reader io.Reader, closer io.Closer) io.ReadCloser {
+	return struct {
+		io.Reader
+		io.Closer
+	}{Reader: reader, Closer: closer}
+}
+
+// closerFunc 함수를 io.Closer로 사용
+type closerFunc func() error
+
+// Close 함수를 호출
+func (f closerFunc) Close() error {
+	return f()
+}
+
+// spoolBody 바디를 임시 파일에 저장. 실패하면 파일을 삭제
+func spoolBody(dir string, body io.Reader) (*spooledBody, error) {
+	file, err := os.CreateTemp(dir, "httpretry-body-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating request body spill file: %w", err)
+	}
+	size, err := io.Copy(file, body)
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, fmt.Errorf("spilling request body: %w", err)
+	}
+	return &spooledBody{file: file, size: size, refs: 1}, nil
+}
+
+// open 임시 파일의 처음부터 읽는 reader를 반환
+func (s *spooledBody) open() (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.refs == 0 {
+		return nil, errSpillReleased
+	}
+	s.refs++
+	return &spooledReader{SectionReader: io.NewSectionReader(s.file, 0, s.size), close: sync.OnceFunc(s.release)}, nil
+}
+
+// release 참조를 반환하고, 남은 참조가 없으면 임시 파일을 닫고 삭제
+func (s *spooledBody) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.refs == 0 {
+		return
+	}
+	if s.refs--; s.refs == 0 {
+		s.file.Close()
+		os.Remove(s.file.Name())
+	}
+}
+
+// Close 참조를 반환. 여러 번 호출해도 한 번만 반환
+func (r *spooledReader) Close() error {
+	r.close()
+	return nil
+}
+
+// peekBody 응답 바디를 최대 limit 바이트까지 읽고, 읽은 내용이 다시 포함되도록 바디를 감쌈
+//
+// 읽기에 실패하면 바디를 닫고 에러를 반환합니다.
+func peekBody(response *http.Response, limit int) ([]byte, error) {
+	// limit보다 1바이트 더 읽어, 바디가 정확히 limit 바이트인 경우에도 EOF까지 읽고 trailer를 받도록 함
+	peeked, err := io.ReadAll(io.LimitReader(response.Body, int64(limit)+1))
+	if err != nil {
+		response.Body.Close()
+		return nil, fmt.Errorf("peeking response body: %w", err)
+	}
+	response.Body = struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: io.MultiReader(bytes.NewReader(peeked), response.Body),
+		Closer: response.Body,
+	}
+	return peeked[:min(len(peeked), limit)], nil
+}
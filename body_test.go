@@ -0,0 +1,291 @@
+package httpretry_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetriableTransport_BodyReplay(t *testing.T) {
+	t.Run("GetBody가 없는 요청도 재시도 시 바디를 다시 전송하는 테스트", func(t *testing.T) {
+		// given
+		var receivedBodies []string
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				receivedBodies = append(receivedBodies, string(body))
+				if len(receivedBodies) < 3 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+		defer testServer.Close()
+
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(3),
+				httpretry.WithBackoffPolicy(noBackoff),
+			),
+		)
+		req, _ := http.NewRequest(
+			http.MethodPost,
+			testServer.URL,
+			io.NopCloser(strings.NewReader("payload")), // GetBody가 설정되지 않는 바디
+		)
+
+		// when
+		resp, err := retryClient.Do(req)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []string{"payload", "payload", "payload"}, receivedBodies)
+	})
+
+	t.Run("임계값을 넘는 바디는 임시 파일로 재전송하고 요청이 끝나면 삭제하는 테스트", func(t *testing.T) {
+		// given
+		var receivedBodies []string
+		var spilledFiles int
+		spillDir := t.TempDir()
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				receivedBodies = append(receivedBodies, string(body))
+				entries, _ := os.ReadDir(spillDir)
+				spilledFiles = max(spilledFiles, len(entries))
+				if len(receivedBodies) < 3 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+		defer testServer.Close()
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(3),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithBodySpill(4, spillDir),
+			),
+		)
+		req, _ := http.NewRequest(http.MethodPost, testServer.URL, io.NopCloser(strings.NewReader("payload")))
+
+		// when
+		resp, err := retryClient.Do(req)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []string{"payload", "payload", "payload"}, receivedBodies)
+		assert.Equal(t, 1, spilledFiles)
+		assert.Eventually(t, func() bool {
+			entries, _ := os.ReadDir(spillDir)
+			return len(entries) == 0
+		}, time.Second, 10*time.Millisecond, "요청이 끝나면 임시 파일을 삭제해야 합니다.")
+	})
+}
+
+func TestWithMaxReplayableBodySize(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		opts     []httpretry.HTTPOption
+		expected []string
+	}{
+		{
+			name:     "최대 크기 이하의 바디는 재시도 시 다시 전송하는 테스트",
+			body:     "small",
+			expected: []string{"small", "small"},
+		},
+		{
+			name:     "최대 크기를 넘는 바디는 한 번만 전송하고 재시도하지 않는 테스트",
+			body:     "oversized payload",
+			expected: []string{"oversized payload"},
+		},
+		{
+			name:     "임시 파일 저장 기준을 넘어도 최대 크기를 넘으면 재시도하지 않는 테스트",
+			body:     "oversized payload",
+			opts:     []httpretry.HTTPOption{httpretry.WithBodySpill(4, t.TempDir())},
+			expected: []string{"oversized payload"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			var receivedBodies []string
+			testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				receivedBodies = append(receivedBodies, string(body))
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer testServer.Close()
+			opts := append([]httpretry.HTTPOption{
+				httpretry.WithMaxRetry(2),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithMaxReplayableBodySize(8),
+			}, tt.opts...)
+			retryClient := httpretry.NewClient(httpretry.NewHTTPSettings(opts...))
+			req, _ := http.NewRequest(http.MethodPost, testServer.URL, io.NopCloser(strings.NewReader(tt.body)))
+
+			// when
+			resp, err := retryClient.Do(req)
+
+			// then
+			assert.Equal(t, tt.expected, receivedBodies)
+			if len(tt.expected) == 1 {
+				// 재시도하지 않은 요청은 첫 응답을 그대로 반환
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+			}
+		})
+	}
+
+	t.Run("GetBody가 있는 요청은 크기와 관계없이 재시도하는 테스트", func(t *testing.T) {
+		// given
+		var receivedBodies []string
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			receivedBodies = append(receivedBodies, string(body))
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer testServer.Close()
+		retryClient := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithMaxReplayableBodySize(8),
+		))
+		req, _ := http.NewRequest(http.MethodPost, testServer.URL, strings.NewReader("oversized payload"))
+
+		// when
+		_, err := retryClient.Do(req)
+
+		// then
+		assert.Error(t, err)
+		assert.Equal(t, []string{"oversized payload", "oversized payload"}, receivedBodies)
+	})
+}
+
+func TestRetriableTransport_BodyPeek(t *testing.T) {
+	t.Run("바디 검사 결과에 따라 재시도하고, 최종 응답 바디는 온전히 읽히는 테스트", func(t *testing.T) {
+		// given
+		reqCount := 0
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reqCount++
+				w.WriteHeader(http.StatusOK)
+				if reqCount < 3 {
+					w.Write([]byte(`{"error":"try_again"}`))
+					return
+				}
+				w.Write([]byte(`{"result":"a response longer than the peek limit"}`))
+			}),
+		)
+		defer testServer.Close()
+
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(3),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithBodyPeek(32, func(resp *http.Response, peek []byte) bool {
+					return strings.Contains(string(peek), "try_again")
+				}),
+			),
+		)
+
+		// when
+		resp, err := retryClient.Get(testServer.URL)
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		body, readErr := io.ReadAll(resp.Body)
+		assert.NoError(t, readErr)
+		assert.Equal(t, `{"result":"a response longer than the peek limit"}`, string(body))
+		assert.Equal(t, 3, reqCount)
+	})
+
+	t.Run("재시도 대상 상태 코드 응답은 바디를 기다리지 않고 재시도하는 테스트", func(t *testing.T) {
+		// given
+		reqCount := 0
+		release := make(chan struct{})
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reqCount++
+				if reqCount == 1 {
+					// 헤더만 보내고 바디는 끝내지 않는 스트리밍 응답
+					w.WriteHeader(http.StatusServiceUnavailable)
+					w.(http.Flusher).Flush()
+					<-release
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"result":"ok"}`))
+			}),
+		)
+		defer testServer.Close()
+		defer close(release)
+
+		var inspected []int
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(2),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithBodyPeek(32, func(resp *http.Response, peek []byte) bool {
+					inspected = append(inspected, resp.StatusCode)
+					return false
+				}),
+			),
+		)
+
+		// when
+		start := time.Now()
+		resp, err := retryClient.Get(testServer.URL)
+		elapsed := time.Since(start)
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 2, reqCount)
+		assert.Equal(t, []int{http.StatusOK}, inspected)
+		assert.Less(t, elapsed, time.Second)
+	})
+
+	t.Run("바디 검사로 재시도 횟수 초과 시, 에러 반환 테스트", func(t *testing.T) {
+		// given
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"error":"try_again"}`))
+			}),
+		)
+		defer testServer.Close()
+
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(2),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithBodyPeek(32, func(resp *http.Response, peek []byte) bool {
+					return strings.Contains(string(peek), "try_again")
+				}),
+			),
+		)
+
+		// when
+		resp, err := retryClient.Get(testServer.URL)
+
+		// then
+		assert.Nil(t, resp)
+		assert.ErrorContains(t, err, "retry requested by body inspection")
+		assert.ErrorContains(t, err, "max retries reached")
+	})
+}
@@ -0,0 +1,265 @@
+package httpretry
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen 서킷 브레이커가 열려 있어 요청하지 않았음을 나타내는 에러
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState 서킷 브레이커 상태
+type CircuitState int
+
+const (
+	// CircuitClosed 요청을 허용하는 정상 상태
+	CircuitClosed CircuitState = iota
+	// CircuitOpen 연속 실패로 요청을 차단하는 상태
+	CircuitOpen
+	// CircuitHalfOpen 차단 시간이 지나 probe 요청으로 회복 여부를 확인하는 상태
+	CircuitHalfOpen
+)
+
+// String 상태 이름을 반환
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitStateListener 호스트의 서킷 브레이커 상태가 바뀌면 호출되는 함수
+//
+// 서킷 브레이커의 잠금을 해제한 뒤 요청 goroutine에서 호출되므로 오래 걸리는 작업은 피해야 합니다.
+//
+// Parameters:
+//   - host: (string) 서킷 브레이커가 적용된 호스트
+//   - from: (CircuitState) 변경 전 상태
+//   - to: (CircuitState) 변경 후 상태
+type CircuitStateListener func(host string, from, to CircuitState)
+
+// CircuitProbePolicy half-open 상태에서 회복 여부를 확인하는 probe 요청 정책
+type CircuitProbePolicy struct {
+	// MaxProbes 동시에 허용하는 probe 요청 수. 0 이하이면 1
+	MaxProbes int
+	// Methods probe로 보낼 수 있는 HTTP 메서드. 비어 있으면 모든 메서드. 다른 메서드는 half-open 동안 차단
+	Methods []string
+	// SuccessThreshold 서킷을 닫는 probe 성공 횟수. 0 이하이면 1
+	SuccessThreshold int
+}
+
+// CircuitBreaker 연속 실패 횟수 기반의 서킷 브레이커
+//
+// threshold 회 연속 실패하면 열리고, cooldown 이후 probe 요청을 허용합니다.
+// probe가 probe 정책의 SuccessThreshold만큼 성공하면 닫히고, 한 번이라도 실패하면 다시 cooldown 동안 열립니다.
+// 기본 probe 정책은 한 번에 하나의 probe를 허용하고, 한 번 성공하면 닫습니다.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	state     CircuitState
+	failures  int
+	threshold int
+	cooldown  time.Duration
+	openedAt  time.Time
+	// probes 진행 중인 probe 요청 수, successes half-open 상태에서 성공한 probe 수
+	probes    int
+	successes int
+	probe     CircuitProbePolicy
+	// host, onChange 호스트별 서킷 브레이커의 상태 변경 알림
+	host     string
+	onChange CircuitStateListener
+}
+
+// NewCircuitBreaker 서킷 브레이커를 생성
+//
+// Parameters:
+//   - threshold: (int) 서킷을 여는 연속 실패 횟수
+//   - cooldown: (time.Duration) 서킷이 열린 뒤 probe 요청을 허용하기까지의 시간
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// Allow 요청 가능 여부를 확인하며, 요청할 수 없으면 ErrCircuitOpen을 반환
+//
+// Allow가 nil을 반환한 경우, 요청 결과를 Record로 반드시 기록해야 합니다.
+func (b *CircuitBreaker) Allow() error {
+	return b.allowMethod("")
+}
+
+// allowMethod 메서드가 probe 정책에 맞는지 함께 확인하여 요청 가능 여부를 반환. 빈 메서드는 probe로 허용
+func (b *CircuitBreaker) allowMethod(method string) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	from := b.state
+	err := b.allow(method)
+	to := b.state
+	b.mu.Unlock()
+
+	b.notify(from, to)
+	return err
+}
+
+// allow 잠금을 획득한 상태에서 요청 가능 여부를 확인
+func (b *CircuitBreaker) allow(method string) error {
+	switch b.state {
+	case CircuitOpen:
+		if time.Since(b.openedAt) < b.cooldown || !b.probeMethod(method) {
+			return ErrCircuitOpen
+		}
+		b.state = CircuitHalfOpen
+		b.successes = 0
+		b.probes = 1
+	case CircuitHalfOpen:
+		if b.probes >= max(b.probe.MaxProbes, 1) || !b.probeMethod(method) {
+			return ErrCircuitOpen
+		}
+		b.probes++
+	}
+	return nil
+}
+
+// probeMethod probe로 보낼 수 있는 메서드인지 확인
+func (b *CircuitBreaker) probeMethod(method string) bool {
+	return method == "" || len(b.probe.Methods) == 0 || slices.Contains(b.probe.Methods, method)
+}
+
+// Record 요청 결과를 기록
+func (b *CircuitBreaker) Record(success bool) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	from := b.state
+	b.probes = max(b.probes-1, 0)
+	switch {
+	case success && b.state == CircuitHalfOpen:
+		b.successes++
+		if b.successes >= max(b.probe.SuccessThreshold, 1) {
+			b.state = CircuitClosed
+			b.failures = 0
+		}
+	case success:
+		b.state = CircuitClosed
+		b.failures = 0
+	case b.state == CircuitHalfOpen:
+		b.open()
+	default:
+		b.failures++
+		if b.failures >= b.threshold {
+			b.open()
+		}
+	}
+	to := b.state
+	b.mu.Unlock()
+
+	b.notify(from, to)
+}
+
+// State 현재 상태를 반환
+func (b *CircuitBreaker) State() CircuitState {
+	if b == nil {
+		return CircuitClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// CircuitState 호스트의 서킷 브레이커 상태를 반환
+//
+// 서킷 브레이커를 사용하지 않거나 요청한 적 없는 호스트는 CircuitClosed를 반환합니다.
+// 열린 서킷은 cooldown이 지나도 다음 요청 전까지 CircuitOpen으로 유지됩니다.
+//
+// Parameters:
+//   - host: (string) 요청 URL의 호스트 (포트 포함)
+func (c *Client) CircuitState(host string) CircuitState {
+	return c.transport.breakers.state(host)
+}
+
+// CircuitStates 요청한 적 있는 모든 호스트의 서킷 브레이커 상태를 반환
+//
+// readiness probe나 대시보드에 서킷 상태를 노출할 때 사용합니다.
+func (c *Client) CircuitStates() map[string]CircuitState {
+	return c.transport.breakers.states()
+}
+
+// release 결과를 판단할 수 없는 요청(취소 등)이 끝났을 때 probe 자리를 반환
+func (b *CircuitBreaker) release() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probes = max(b.probes-1, 0)
+}
+
+// notify 상태가 바뀌었으면 상태 변경 알림을 호출
+func (b *CircuitBreaker) notify(from, to CircuitState) {
+	if from != to && b.onChange != nil {
+		b.onChange(b.host, from, to)
+	}
+}
+
+// open 서킷을 열고 차단 시작 시간을 기록
+func (b *CircuitBreaker) open() {
+	b.state = CircuitOpen
+	b.openedAt = time.Now()
+	b.failures = 0
+}
+
+// breakerGroup 호스트별 서킷 브레이커
+type breakerGroup struct {
+	breakers  sync.Map
+	threshold int
+	cooldown  time.Duration
+	probe     CircuitProbePolicy
+	onChange  CircuitStateListener
+}
+
+// get 호스트의 서킷 브레이커를 반환. 서킷 브레이커를 사용하지 않는 경우 nil을 반환
+func (g *breakerGroup) get(host string) *CircuitBreaker {
+	if g == nil {
+		return nil
+	}
+	if breaker, ok := g.breakers.Load(host); ok {
+		return breaker.(*CircuitBreaker)
+	}
+	breaker := NewCircuitBreaker(g.threshold, g.cooldown)
+	breaker.probe, breaker.host, breaker.onChange = g.probe, host, g.onChange
+	actual, _ := g.breakers.LoadOrStore(host, breaker)
+	return actual.(*CircuitBreaker)
+}
+
+// state 호스트의 서킷 브레이커 상태를 반환. 요청한 적 없는 호스트는 CircuitClosed
+func (g *breakerGroup) state(host string) CircuitState {
+	if g == nil {
+		return CircuitClosed
+	}
+	breaker, ok := g.breakers.Load(host)
+	if !ok {
+		return CircuitClosed
+	}
+	return breaker.(*CircuitBreaker).State()
+}
+
+// states 요청한 적 있는 모든 호스트의 서킷 브레이커 상태를 반환
+func (g *breakerGroup) states() map[string]CircuitState {
+	states := make(map[string]CircuitState)
+	if g == nil {
+		return states
+	}
+	g.breakers.Range(func(host, breaker any) bool {
+		states[host.(string)] = breaker.(*CircuitBreaker).State()
+		return true
+	})
+	return states
+}
@@ -0,0 +1,171 @@
+package httpretry_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Run("연속 실패 시 열리고, cooldown 이후 probe 성공 시 닫히는 테스트", func(t *testing.T) {
+		// given
+		breaker := httpretry.NewCircuitBreaker(2, 50*time.Millisecond)
+
+		// when
+		breaker.Record(false)
+		breaker.Record(false)
+
+		// then
+		assert.Equal(t, httpretry.CircuitOpen, breaker.State())
+		assert.ErrorIs(t, breaker.Allow(), httpretry.ErrCircuitOpen)
+
+		time.Sleep(60 * time.Millisecond)
+		assert.NoError(t, breaker.Allow(), "cooldown 이후 probe를 허용해야 합니다.")
+		assert.Equal(t, httpretry.CircuitHalfOpen, breaker.State())
+		assert.ErrorIs(t, breaker.Allow(), httpretry.ErrCircuitOpen, "probe는 한 번만 허용해야 합니다.")
+
+		breaker.Record(true)
+		assert.Equal(t, httpretry.CircuitClosed, breaker.State())
+	})
+
+	t.Run("호스트의 서킷이 열린 경우, 요청하지 않고 에러 반환 테스트", func(t *testing.T) {
+		// given
+		reqCount := 0
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reqCount++
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}),
+		)
+		defer testServer.Close()
+
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(5),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithCircuitBreaker(2, time.Minute),
+			),
+		)
+
+		// when
+		_, err := retryClient.Get(testServer.URL)
+
+		// then
+		assert.ErrorIs(t, err, httpretry.ErrCircuitOpen)
+		assert.Equal(t, 2, reqCount)
+	})
+}
+
+func TestWithCircuitStateListener(t *testing.T) {
+	t.Run("서킷 상태 변경을 알리고 호스트별 상태를 조회하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		type change struct {
+			host     string
+			from, to httpretry.CircuitState
+		}
+		var changes []change
+		client := httpretry.New(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithCircuitBreaker(2, time.Minute),
+			httpretry.WithCircuitStateListener(func(host string, from, to httpretry.CircuitState) {
+				changes = append(changes, change{host, from, to})
+			}),
+		))
+		host := server.Listener.Addr().String()
+
+		// when
+		assert.Equal(t, httpretry.CircuitClosed, client.CircuitState(host))
+		client.Get(server.URL)
+
+		// then
+		assert.Equal(t, []change{{host, httpretry.CircuitClosed, httpretry.CircuitOpen}}, changes)
+		assert.Equal(t, httpretry.CircuitOpen, client.CircuitState(host))
+		assert.Equal(t, map[string]httpretry.CircuitState{host: httpretry.CircuitOpen}, client.CircuitStates())
+	})
+
+	t.Run("서킷 브레이커를 사용하지 않으면 상태를 조회해도 비어 있는 테스트", func(t *testing.T) {
+		// given
+		client := httpretry.New(httpretry.NewHTTPSettings())
+
+		// when
+		states := client.CircuitStates()
+
+		// then
+		assert.Empty(t, states)
+		assert.Equal(t, httpretry.CircuitClosed, client.CircuitState("example.com"))
+	})
+}
+
+func TestWithCircuitProbePolicy(t *testing.T) {
+	// recoveringServer 첫 요청만 실패하는 서버
+	recoveringServer := func(t *testing.T) *httptest.Server {
+		var received atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if received.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	t.Run("probe가 SuccessThreshold만큼 성공해야 서킷을 닫는 테스트", func(t *testing.T) {
+		// given
+		server := recoveringServer(t)
+		client := httpretry.New(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(1),
+			httpretry.WithCircuitBreaker(1, 20*time.Millisecond),
+			httpretry.WithCircuitProbePolicy(httpretry.CircuitProbePolicy{SuccessThreshold: 2}),
+		))
+		host := server.Listener.Addr().String()
+		client.Get(server.URL)
+		time.Sleep(30 * time.Millisecond)
+
+		// when
+		first, firstErr := client.Get(server.URL)
+		state := client.CircuitState(host)
+		second, secondErr := client.Get(server.URL)
+
+		// then
+		assert.NoError(t, firstErr)
+		assert.NoError(t, secondErr)
+		first.Body.Close()
+		second.Body.Close()
+		assert.Equal(t, httpretry.CircuitHalfOpen, state)
+		assert.Equal(t, httpretry.CircuitClosed, client.CircuitState(host))
+	})
+
+	t.Run("probe 메서드가 아닌 요청은 half-open 동안 차단하는 테스트", func(t *testing.T) {
+		// given
+		server := recoveringServer(t)
+		client := httpretry.New(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(1),
+			httpretry.WithCircuitBreaker(1, 20*time.Millisecond),
+			httpretry.WithCircuitProbePolicy(httpretry.CircuitProbePolicy{Methods: []string{http.MethodGet}}),
+		))
+		client.Get(server.URL)
+		time.Sleep(30 * time.Millisecond)
+
+		// when
+		_, postErr := client.Post(server.URL, "text/plain", nil)
+		resp, getErr := client.Get(server.URL)
+
+		// then
+		assert.ErrorIs(t, postErr, httpretry.ErrCircuitOpen)
+		assert.NoError(t, getErr)
+		resp.Body.Close()
+		assert.Equal(t, httpretry.CircuitClosed, client.CircuitState(server.Listener.Addr().String()))
+	})
+}
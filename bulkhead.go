@@ -0,0 +1,89 @@
+package httpretry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ErrBulkheadFull bulkhead의 동시 요청 수가 가득 차서 요청하지 않음
+var ErrBulkheadFull = errors.New("bulkhead is full")
+
+type (
+	// Bulkhead 의존 서비스별 동시 요청 제한
+	//
+	// 느려진 의존 서비스가 자기 bulkhead만 채우므로, 같은 클라이언트를 사용하는 다른 의존 서비스 요청은 영향을 받지 않습니다.
+	Bulkhead struct {
+		// MaxConcurrent 동시에 처리할 최대 요청 수. 재시도와 백오프 대기, 응답 바디 소비까지 포함
+		MaxConcurrent int
+		// MaxWait 자리가 날 때까지 기다리는 최대 시간. 0인 경우 기다리지 않고 ErrBulkheadFull을 반환
+		MaxWait time.Duration
+	}
+
+	// bulkhead 동시 요청 수를 제한하는 semaphore
+	bulkhead struct {
+		name    string
+		slots   chan struct{}
+		maxWait time.Duration
+	}
+
+	// bulkheads 이름(호스트 또는 Policy.Bulkhead)별 bulkhead
+	bulkheads map[string]*bulkhead
+)
+
+// newBulkheads 설정된 bulkhead가 없으면 nil을 반환
+func newBulkheads(settings map[string]Bulkhead) bulkheads {
+	if len(settings) == 0 {
+		return nil
+	}
+	b := make(bulkheads, len(settings))
+	for name, setting := range settings {
+		if setting.MaxConcurrent <= 0 {
+			continue
+		}
+		b[name] = &bulkhead{
+			name:    name,
+			slots:   make(chan struct{}, setting.MaxConcurrent),
+			maxWait: setting.MaxWait,
+		}
+	}
+	return b
+}
+
+// acquire 요청에 해당하는 bulkhead의 자리를 얻고, 자리를 반환하는 함수를 반환
+//
+// 정책에 지정된 bulkhead가 없으면 포트를 포함한 호스트, 호스트 이름 순으로 찾습니다.
+// 해당하는 bulkhead가 없으면 제한하지 않습니다.
+func (b bulkheads) acquire(ctx context.Context, name string, target *url.URL) (func(), error) {
+	h, ok := b[name]
+	if name == "" {
+		if h, ok = b[target.Host]; !ok {
+			h, ok = b[target.Hostname()]
+		}
+	}
+	if !ok {
+		return func() {}, nil
+	}
+	release := func() { <-h.slots }
+
+	select {
+	case h.slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+	if h.maxWait <= 0 {
+		return nil, fmt.Errorf("bulkhead(%s): %w", h.name, ErrBulkheadFull)
+	}
+	timer := time.NewTimer(h.maxWait)
+	defer timer.Stop()
+	select {
+	case h.slots <- struct{}{}:
+		return release, nil
+	case <-timer.C:
+		return nil, fmt.Errorf("bulkhead(%s): waited %s: %w", h.name, h.maxWait, ErrBulkheadFull)
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for bulkhead(%s): %w", h.name, context.Cause(ctx))
+	}
+}
@@ -0,0 +1,83 @@
+package httpretry_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithBulkhead(t *testing.T) {
+	t.Run("bulkhead가 가득 찬 호스트만 거절하는 테스트", func(t *testing.T) {
+		// given
+		block := make(chan struct{})
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-block
+		}))
+		defer slow.Close()
+		defer close(block)
+		healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer healthy.Close()
+		slowURL, _ := url.Parse(slow.URL)
+		healthyURL, _ := url.Parse(healthy.URL)
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithBulkhead(slowURL.Host, httpretry.Bulkhead{MaxConcurrent: 1}),
+			httpretry.WithBulkhead(healthyURL.Host, httpretry.Bulkhead{MaxConcurrent: 1}),
+		))
+		started := make(chan struct{})
+		go func() {
+			req, _ := http.NewRequest(http.MethodGet, slow.URL, nil)
+			close(started)
+			client.Do(req)
+		}()
+		<-started
+		assert.Eventually(t, func() bool {
+			req, _ := http.NewRequest(http.MethodGet, slow.URL, nil)
+			_, err := client.Do(req)
+			return errors.Is(err, httpretry.ErrBulkheadFull)
+		}, time.Second, 10*time.Millisecond)
+
+		// when
+		req, _ := http.NewRequest(http.MethodGet, healthy.URL, nil)
+		resp, err := client.Do(req)
+
+		// then
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("응답 바디를 닫으면 자리를 반환하고, 정책 그룹으로 bulkhead를 공유하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		serverURL, _ := url.Parse(server.URL)
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithHostPolicy(serverURL.Host, httpretry.Policy{MaxRetry: 1, Bulkhead: "payments"}),
+			httpretry.WithBulkhead("payments", httpretry.Bulkhead{MaxConcurrent: 1, MaxWait: 10 * time.Millisecond}),
+		))
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+		// when
+		first, firstErr := client.Do(req)
+		_, fullErr := client.Do(req)
+		first.Body.Close()
+		second, secondErr := client.Do(req)
+
+		// then
+		assert.NoError(t, firstErr)
+		assert.ErrorIs(t, fullErr, httpretry.ErrBulkheadFull)
+		assert.ErrorContains(t, fullErr, "bulkhead(payments)")
+		assert.NoError(t, secondErr)
+		second.Body.Close()
+	})
+}
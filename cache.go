@@ -0,0 +1,290 @@
+package httpretry
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HeaderCache 캐시된 응답임을 나타내는 응답 헤더. 캐시에서 반환한 응답은 "HIT" 값을 가짐
+const HeaderCache = "X-Httpretry-Cache"
+
+// varyMarker Vary 헤더가 있는 응답의 기본 키에 저장하는 값의 prefix. 뒤에 Vary 헤더 이름 목록이 붙음
+const varyMarker = "httpretry-vary:"
+
+// cacheableStatus 캐시할 수 있는 응답 상태 코드
+var cacheableStatus = map[int]struct{}{
+	http.StatusOK:                   {},
+	http.StatusNonAuthoritativeInfo: {},
+	http.StatusMovedPermanently:     {},
+	http.StatusNotFound:             {},
+	http.StatusGone:                 {},
+}
+
+type (
+	// CacheStore 캐시된 응답을 보관하는 저장소
+	//
+	// 여러 replica가 같은 저장소(Redis 등)를 공유하면 캐시도 공유됩니다.
+	CacheStore interface {
+		// Get 키에 해당하는 값을 반환. 값이 없거나 만료되었으면 false를 반환
+		Get(ctx context.Context, key string) ([]byte, bool, error)
+		// Set 값을 ttl 동안 보관
+		Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+		// Delete 키에 해당하는 값을 삭제
+		Delete(ctx context.Context, key string) error
+	}
+
+	// cacheLayer 재시도 루프 바깥에서 응답을 캐시
+	cacheLayer struct {
+		store        CacheStore
+		defaultTTL   time.Duration
+		maxEntrySize int64
+		logger       Logger
+	}
+)
+
+// newCacheLayer 캐시 설정을 생성. 캐시를 사용하지 않는 경우 nil을 반환
+func newCacheLayer(settings *Settings) *cacheLayer {
+	if settings.Cache == nil {
+		return nil
+	}
+	return &cacheLayer{
+		store:        settings.Cache,
+		logger:       newLogger(settings),
+		defaultTTL:   settings.CacheDefaultTTL,
+		maxEntrySize: settings.CacheMaxEntrySize,
+	}
+}
+
+// roundTrip 캐시된 응답이 있으면 반환하고, 없으면 next로 요청한 뒤 캐시할 수 있는 응답을 저장
+//
+// 저장소 에러는 요청 실패로 이어지지 않도록 캐시 미스로 처리합니다.
+func (c *cacheLayer) roundTrip(req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	if req.Method != http.MethodGet || hasDirective(req.Header, "no-store") || noCacheFromContext(req.Context()) {
+		return next(req)
+	}
+	key := cacheKey(req)
+
+	// no-cache 요청은 캐시된 응답을 사용하지 않고, 새 응답을 저장
+	if !hasDirective(req.Header, "no-cache") {
+		data, ok := c.lookup(req, key)
+		if ok {
+			resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), req)
+			if err == nil {
+				resp.Header.Set(HeaderCache, "HIT")
+				return resp, nil
+			}
+			c.debugLog("decoding cached response", err)
+		}
+	}
+
+	resp, err := next(req)
+	if err != nil {
+		return nil, err
+	}
+	ttl, ok := cacheTTL(resp, c.defaultTTL)
+	vary, varyOK := varyHeaders(resp)
+	if !ok || !varyOK || !shareable(req, resp) || (c.maxEntrySize > 0 && resp.ContentLength > c.maxEntrySize) {
+		return resp, nil
+	}
+
+	body, ok, err := c.readBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return resp, nil
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	data, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		c.debugLog("encoding response", err)
+	} else {
+		c.save(req, key, vary, data, ttl)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// lookup 캐시된 응답을 조회
+//
+// 기본 키에 Vary 헤더 목록이 저장되어 있으면, 요청의 해당 헤더 값으로 만든 키로 다시 조회합니다.
+func (c *cacheLayer) lookup(req *http.Request, key string) ([]byte, bool) {
+	data, ok, err := c.store.Get(req.Context(), key)
+	if err != nil {
+		c.debugLog("reading cache", err)
+		return nil, false
+	}
+	if !ok || !bytes.HasPrefix(data, []byte(varyMarker)) {
+		return data, ok
+	}
+
+	vary := strings.Split(strings.TrimPrefix(string(data), varyMarker), ",")
+	data, ok, err = c.store.Get(req.Context(), variantKey(key, vary, req.Header))
+	if err != nil {
+		c.debugLog("reading cache", err)
+		return nil, false
+	}
+	return data, ok
+}
+
+// save 응답을 저장. Vary 헤더가 있으면 기본 키에 헤더 목록을, 요청 헤더 값으로 만든 키에 응답을 저장
+func (c *cacheLayer) save(req *http.Request, key string, vary []string, data []byte, ttl time.Duration) {
+	if len(vary) > 0 {
+		if err := c.store.Set(req.Context(), key, []byte(varyMarker+strings.Join(vary, ",")), ttl); err != nil {
+			c.debugLog("writing cache", err)
+			return
+		}
+		key = variantKey(key, vary, req.Header)
+	}
+	if err := c.store.Set(req.Context(), key, data, ttl); err != nil {
+		c.debugLog("writing cache", err)
+	}
+}
+
+// readBody 캐시할 응답 바디를 읽음
+//
+// 바디가 최대 크기를 넘으면 캐시하지 않으며, 읽은 부분과 나머지를 이어 붙인 바디로 응답을 복원합니다.
+func (c *cacheLayer) readBody(resp *http.Response) ([]byte, bool, error) {
+	if c.maxEntrySize <= 0 {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return body, err == nil, err
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.maxEntrySize+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, false, err
+	}
+	if int64(len(body)) > c.maxEntrySize {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return nil, false, nil
+	}
+	resp.Body.Close()
+	return body, true, nil
+}
+
+// debugLog 캐시 에러를 출력
+func (c *cacheLayer) debugLog(action string, err error) {
+	c.logger.Debug("cache error", "action", action, "error", err)
+}
+
+// cacheKey 요청의 캐시 키
+func cacheKey(req *http.Request) string {
+	return req.Method + " " + req.URL.String()
+}
+
+// variantKey Vary 헤더에 나열된 요청 헤더 값을 포함한 캐시 키
+//
+// Authorization 등 민감한 값이 저장소 키에 그대로 남지 않도록 헤더 값은 해시로 변환합니다.
+func variantKey(key string, vary []string, header http.Header) string {
+	hash := sha256.New()
+	for _, name := range vary {
+		hash.Write([]byte(name + ":" + strings.Join(header.Values(name), ",") + "\n"))
+	}
+	return key + " vary=" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// varyHeaders 응답의 Vary 헤더 이름을 정렬하여 반환. Vary: * 응답은 캐시할 수 없으므로 false를 반환
+func varyHeaders(resp *http.Response) ([]string, bool) {
+	var vary []string
+	for _, value := range resp.Header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			if name == "*" {
+				return nil, false
+			}
+			if name != "" {
+				vary = append(vary, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+	slices.Sort(vary)
+	return slices.Compact(vary), true
+}
+
+// shareable 응답을 다른 요청과 공유해도 되는지 확인
+//
+// Authorization 헤더가 있는 요청의 응답은 사용자별 응답일 수 있으므로,
+// 응답이 public, s-maxage, must-revalidate로 공유를 허용한 경우에만 저장합니다. (RFC 9111 3.5)
+// private 응답은 여러 replica가 공유하는 저장소에 저장하지 않습니다.
+func shareable(req *http.Request, resp *http.Response) bool {
+	if hasDirective(resp.Header, "private") {
+		return false
+	}
+	if req.Header.Get("Authorization") == "" {
+		return true
+	}
+	return hasDirective(resp.Header, "public") ||
+		hasDirective(resp.Header, "s-maxage") ||
+		hasDirective(resp.Header, "must-revalidate")
+}
+
+// cacheTTL 응답의 Cache-Control, Expires 헤더로 캐시 유지 시간을 계산. 캐시할 수 없는 응답은 false를 반환
+//
+// 두 헤더가 모두 없으면 defaultTTL을 사용합니다.
+func cacheTTL(resp *http.Response, defaultTTL time.Duration) (time.Duration, bool) {
+	if _, ok := cacheableStatus[resp.StatusCode]; !ok {
+		return 0, false
+	}
+	if hasDirective(resp.Header, "no-store") || hasDirective(resp.Header, "no-cache") {
+		return 0, false
+	}
+
+	var ttl time.Duration
+	if maxAge, ok := directiveValue(resp.Header, "max-age"); ok {
+		seconds, err := strconv.Atoi(maxAge)
+		if err != nil {
+			return 0, false
+		}
+		ttl = time.Duration(seconds) * time.Second
+		if age, err := strconv.Atoi(resp.Header.Get("Age")); err == nil {
+			ttl -= time.Duration(age) * time.Second
+		}
+	} else if expires := resp.Header.Get("Expires"); expires != "" {
+		expiresAt, err := http.ParseTime(expires)
+		if err != nil {
+			return 0, false
+		}
+		now := time.Now()
+		if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+			now = date
+		}
+		ttl = expiresAt.Sub(now)
+	} else {
+		ttl = defaultTTL
+	}
+	return ttl, ttl > 0
+}
+
+// hasDirective Cache-Control 헤더에 지시자가 있는지 확인
+func hasDirective(header http.Header, directive string) bool {
+	_, ok := directiveValue(header, directive)
+	return ok
+}
+
+// directiveValue Cache-Control 헤더에서 지시자의 값을 반환
+func directiveValue(header http.Header, directive string) (string, bool) {
+	for _, value := range header.Values("Cache-Control") {
+		for _, part := range strings.Split(value, ",") {
+			name, arg, _ := strings.Cut(strings.TrimSpace(part), "=")
+			if strings.EqualFold(name, directive) {
+				return strings.Trim(arg, `"`), true
+			}
+		}
+	}
+	return "", false
+}
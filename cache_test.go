@@ -0,0 +1,312 @@
+package httpretry_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheStore(t *testing.T) {
+	ctx := context.Background()
+	disk, err := httpretry.NewDiskCacheStore(t.TempDir())
+	assert.NoError(t, err)
+	stores := map[string]httpretry.CacheStore{
+		"memory": httpretry.NewMemoryCacheStore(),
+		"disk":   disk,
+	}
+
+	for name, store := range stores {
+		t.Run(name+" 저장소에 저장한 값을 조회하고, 만료되거나 삭제되면 조회되지 않는 테스트", func(t *testing.T) {
+			// given
+			assert.NoError(t, store.Set(ctx, "live", []byte("cached"), time.Minute))
+			assert.NoError(t, store.Set(ctx, "expired", []byte("cached"), -time.Second))
+			assert.NoError(t, store.Set(ctx, "deleted", []byte("cached"), time.Minute))
+
+			// when
+			value, ok, getErr := store.Get(ctx, "live")
+			_, expiredOK, _ := store.Get(ctx, "expired")
+			deleteErr := store.Delete(ctx, "deleted")
+			_, deletedOK, _ := store.Get(ctx, "deleted")
+
+			// then
+			assert.NoError(t, getErr)
+			assert.True(t, ok)
+			assert.Equal(t, []byte("cached"), value)
+			assert.False(t, expiredOK)
+			assert.NoError(t, deleteErr)
+			assert.False(t, deletedOK)
+		})
+	}
+}
+
+func TestMemoryCacheStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("최대 항목 수를 넘으면 가장 오래 사용하지 않은 항목을 제거하는 테스트", func(t *testing.T) {
+		// given
+		store := httpretry.NewMemoryCacheStore(httpretry.WithMemoryCacheMaxEntries(2))
+		assert.NoError(t, store.Set(ctx, "a", []byte("1"), time.Minute))
+		assert.NoError(t, store.Set(ctx, "b", []byte("2"), time.Minute))
+		_, _, _ = store.Get(ctx, "a")
+
+		// when
+		assert.NoError(t, store.Set(ctx, "c", []byte("3"), time.Minute))
+
+		// then
+		_, aOK, _ := store.Get(ctx, "a")
+		_, bOK, _ := store.Get(ctx, "b")
+		_, cOK, _ := store.Get(ctx, "c")
+		assert.True(t, aOK)
+		assert.False(t, bOK)
+		assert.True(t, cOK)
+		assert.Equal(t, 2, store.Len())
+	})
+
+	t.Run("최대 크기를 넘으면 오래된 항목을 제거하고, 더 큰 값은 보관하지 않는 테스트", func(t *testing.T) {
+		// given
+		store := httpretry.NewMemoryCacheStore(httpretry.WithMemoryCacheMaxBytes(10))
+		assert.NoError(t, store.Set(ctx, "a", []byte("1234"), time.Minute))
+		assert.NoError(t, store.Set(ctx, "b", []byte("1234"), time.Minute))
+
+		// when
+		assert.NoError(t, store.Set(ctx, "c", []byte("1234"), time.Minute))
+		assert.NoError(t, store.Set(ctx, "huge", []byte("12345678901"), time.Minute))
+
+		// then
+		_, aOK, _ := store.Get(ctx, "a")
+		_, hugeOK, _ := store.Get(ctx, "huge")
+		assert.False(t, aOK)
+		assert.False(t, hugeOK)
+		assert.Equal(t, 2, store.Len())
+	})
+
+	t.Run("제한을 넘으면 사용 중인 항목보다 만료된 항목을 먼저 정리하는 테스트", func(t *testing.T) {
+		// given
+		store := httpretry.NewMemoryCacheStore(httpretry.WithMemoryCacheMaxEntries(2))
+		assert.NoError(t, store.Set(ctx, "live", []byte("1"), time.Minute))
+		assert.NoError(t, store.Set(ctx, "expired", []byte("2"), -time.Second))
+
+		// when
+		assert.NoError(t, store.Set(ctx, "new", []byte("3"), time.Minute))
+
+		// then
+		_, liveOK, _ := store.Get(ctx, "live")
+		_, newOK, _ := store.Get(ctx, "new")
+		assert.True(t, liveOK)
+		assert.True(t, newOK)
+		assert.Equal(t, 2, store.Len())
+	})
+}
+
+func TestWithCache(t *testing.T) {
+	newServer := func(t *testing.T, cacheControl string) (*httptest.Server, *int32) {
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			w.Header().Set("Cache-Control", cacheControl)
+			w.Write([]byte("hello"))
+		}))
+		t.Cleanup(server.Close)
+		return server, &requests
+	}
+	get := func(t *testing.T, client *http.Client, url string, header http.Header) (*http.Response, string) {
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return resp, string(body)
+	}
+
+	t.Run("max-age가 지정된 응답은 캐시된 응답을 반환하는 테스트", func(t *testing.T) {
+		// given
+		server, requests := newServer(t, "max-age=60")
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(httpretry.WithCache(httpretry.NewMemoryCacheStore())))
+
+		// when
+		first, firstBody := get(t, client, server.URL, nil)
+		second, secondBody := get(t, client, server.URL, nil)
+
+		// then
+		assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+		assert.Equal(t, "hello", firstBody)
+		assert.Equal(t, "hello", secondBody)
+		assert.Empty(t, first.Header.Get(httpretry.HeaderCache))
+		assert.Equal(t, "HIT", second.Header.Get(httpretry.HeaderCache))
+		assert.Equal(t, http.StatusOK, second.StatusCode)
+	})
+
+	t.Run("no-store 응답은 캐시하지 않는 테스트", func(t *testing.T) {
+		// given
+		server, requests := newServer(t, "no-store")
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(httpretry.WithCache(httpretry.NewMemoryCacheStore())))
+
+		// when
+		get(t, client, server.URL, nil)
+		second, _ := get(t, client, server.URL, nil)
+
+		// then
+		assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+		assert.Empty(t, second.Header.Get(httpretry.HeaderCache))
+	})
+
+	t.Run("no-cache 요청은 캐시된 응답을 사용하지 않는 테스트", func(t *testing.T) {
+		// given
+		server, requests := newServer(t, "max-age=60")
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(httpretry.WithCache(httpretry.NewMemoryCacheStore())))
+		get(t, client, server.URL, nil)
+
+		// when
+		resp, _ := get(t, client, server.URL, http.Header{"Cache-Control": {"no-cache"}})
+
+		// then
+		assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+		assert.Empty(t, resp.Header.Get(httpretry.HeaderCache))
+	})
+
+	t.Run("디스크 저장소는 클라이언트가 바뀌어도 캐시를 공유하는 테스트", func(t *testing.T) {
+		// given
+		server, requests := newServer(t, "max-age=60")
+		dir := t.TempDir()
+		firstStore, _ := httpretry.NewDiskCacheStore(dir)
+		secondStore, _ := httpretry.NewDiskCacheStore(dir)
+		first := httpretry.NewClient(httpretry.NewHTTPSettings(httpretry.WithCache(firstStore)))
+		second := httpretry.NewClient(httpretry.NewHTTPSettings(httpretry.WithCache(secondStore)))
+
+		// when
+		get(t, first, server.URL, nil)
+		resp, body := get(t, second, server.URL, nil)
+
+		// then
+		assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+		assert.Equal(t, "HIT", resp.Header.Get(httpretry.HeaderCache))
+		assert.Equal(t, "hello", body)
+	})
+
+	t.Run("캐시 헤더가 없는 응답은 기본 유지 시간 동안 캐시하는 테스트", func(t *testing.T) {
+		// given
+		server, requests := newServer(t, "")
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithCache(httpretry.NewMemoryCacheStore()),
+			httpretry.WithCacheDefaultTTL(time.Minute),
+		))
+
+		// when
+		get(t, client, server.URL, nil)
+		resp, _ := get(t, client, server.URL, nil)
+
+		// then
+		assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+		assert.Equal(t, "HIT", resp.Header.Get(httpretry.HeaderCache))
+	})
+
+	t.Run("최대 크기를 넘는 응답은 캐시하지 않고 바디를 그대로 반환하는 테스트", func(t *testing.T) {
+		// given
+		server, requests := newServer(t, "max-age=60")
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithCache(httpretry.NewMemoryCacheStore()),
+			httpretry.WithCacheMaxEntrySize(3),
+		))
+
+		// when
+		_, firstBody := get(t, client, server.URL, nil)
+		second, _ := get(t, client, server.URL, nil)
+
+		// then
+		assert.Equal(t, "hello", firstBody)
+		assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+		assert.Empty(t, second.Header.Get(httpretry.HeaderCache))
+	})
+
+	t.Run("NoCache context 요청은 캐시를 사용하지 않는 테스트", func(t *testing.T) {
+		// given
+		server, requests := newServer(t, "max-age=60")
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(httpretry.WithCache(httpretry.NewMemoryCacheStore())))
+		req, _ := http.NewRequestWithContext(httpretry.NoCache(context.Background()), http.MethodGet, server.URL, nil)
+
+		// when
+		resp, err := client.Do(req)
+		get(t, client, server.URL, nil)
+
+		// then
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, int32(2), atomic.LoadInt32(requests), "NoCache 요청의 응답은 저장되지 않아야 합니다.")
+	})
+
+	t.Run("Vary 헤더에 나열된 요청 헤더 값별로 응답을 캐시하는 테스트", func(t *testing.T) {
+		// given
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			w.Header().Set("Cache-Control", "max-age=60")
+			w.Header().Set("Vary", "Accept")
+			w.Write([]byte(r.Header.Get("Accept")))
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(httpretry.WithCache(httpretry.NewMemoryCacheStore())))
+		jsonHeader := http.Header{"Accept": {"application/json"}}
+		xmlHeader := http.Header{"Accept": {"application/xml"}}
+
+		// when
+		get(t, client, server.URL, jsonHeader)
+		_, xmlBody := get(t, client, server.URL, xmlHeader)
+		jsonResp, jsonBody := get(t, client, server.URL, jsonHeader)
+		xmlResp, cachedXMLBody := get(t, client, server.URL, xmlHeader)
+
+		// then
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+		assert.Equal(t, "application/xml", xmlBody)
+		assert.Equal(t, "application/json", jsonBody)
+		assert.Equal(t, "application/xml", cachedXMLBody)
+		assert.Equal(t, "HIT", jsonResp.Header.Get(httpretry.HeaderCache))
+		assert.Equal(t, "HIT", xmlResp.Header.Get(httpretry.HeaderCache))
+	})
+
+	t.Run("Vary: * 응답은 캐시하지 않는 테스트", func(t *testing.T) {
+		// given
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			w.Header().Set("Cache-Control", "max-age=60")
+			w.Header().Set("Vary", "*")
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(httpretry.WithCache(httpretry.NewMemoryCacheStore())))
+
+		// when
+		get(t, client, server.URL, nil)
+		get(t, client, server.URL, nil)
+
+		// then
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("Authorization 요청의 응답은 public인 경우에만 캐시하는 테스트", func(t *testing.T) {
+		// given
+		privateServer, privateRequests := newServer(t, "max-age=60")
+		publicServer, publicRequests := newServer(t, "public, max-age=60")
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(httpretry.WithCache(httpretry.NewMemoryCacheStore())))
+		auth := http.Header{"Authorization": {"Bearer token"}}
+
+		// when
+		get(t, client, privateServer.URL, auth)
+		get(t, client, privateServer.URL, auth)
+		get(t, client, publicServer.URL, auth)
+		get(t, client, publicServer.URL, auth)
+
+		// then
+		assert.Equal(t, int32(2), atomic.LoadInt32(privateRequests))
+		assert.Equal(t, int32(1), atomic.LoadInt32(publicRequests))
+	})
+}
@@ -0,0 +1,267 @@
+package httpretry
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// 메모리 캐시 저장소 기본 설정
+const (
+	// defaultMemoryCacheMaxEntries 기본 최대 항목 수
+	defaultMemoryCacheMaxEntries = 10000
+	// defaultMemoryCacheMaxBytes 기본 최대 크기 (키와 값의 합)
+	defaultMemoryCacheMaxBytes = 64 << 20
+	// memoryCacheSweepInterval 만료된 항목을 정리하는 주기
+	memoryCacheSweepInterval = time.Minute
+)
+
+type (
+	// memoryCacheEntry 메모리 캐시 항목
+	memoryCacheEntry struct {
+		key       string
+		value     []byte
+		expiresAt time.Time
+	}
+
+	// MemoryCacheOption MemoryCacheStore 설정 함수
+	MemoryCacheOption func(*MemoryCacheStore)
+
+	// MemoryCacheStore 프로세스 메모리에 응답을 보관하는 캐시 저장소
+	//
+	// 최대 항목 수나 최대 크기를 넘으면 가장 오래 사용하지 않은 항목부터 제거(LRU)합니다.
+	// 만료된 항목은 조회 시, 그리고 저장할 때 주기적으로 정리됩니다.
+	MemoryCacheStore struct {
+		mu         sync.Mutex
+		entries    map[string]*list.Element
+		lru        *list.List // 앞쪽이 최근에 사용한 항목
+		size       int64
+		maxEntries int
+		maxBytes   int64
+		lastSweep  time.Time
+	}
+
+	// DiskCacheStore 디렉터리에 응답을 파일로 보관하는 캐시 저장소
+	//
+	// 프로세스를 재시작해도 캐시가 유지됩니다. 만료된 파일은 조회 시 삭제됩니다.
+	DiskCacheStore struct {
+		dir string
+	}
+)
+
+var (
+	_ CacheStore = (*MemoryCacheStore)(nil)
+	_ CacheStore = (*DiskCacheStore)(nil)
+)
+
+// WithMemoryCacheMaxEntries 메모리 캐시의 최대 항목 수를 설정하는 MemoryCacheOption
+//
+// Parameters:
+//   - maxEntries: (int) 최대 항목 수. 0 이하이면 제한하지 않음 (기본값 10000)
+func WithMemoryCacheMaxEntries(maxEntries int) MemoryCacheOption {
+	return func(s *MemoryCacheStore) {
+		s.maxEntries = maxEntries
+	}
+}
+
+// WithMemoryCacheMaxBytes 메모리 캐시의 최대 크기를 설정하는 MemoryCacheOption
+//
+// Parameters:
+//   - maxBytes: (int64) 키와 값 크기의 합의 최대값. 0 이하이면 제한하지 않음 (기본값 64MB)
+func WithMemoryCacheMaxBytes(maxBytes int64) MemoryCacheOption {
+	return func(s *MemoryCacheStore) {
+		s.maxBytes = maxBytes
+	}
+}
+
+// NewMemoryCacheStore 메모리 캐시 저장소를 생성
+//
+// Parameters:
+//   - opts: (...MemoryCacheOption) 최대 항목 수, 최대 크기 설정
+func NewMemoryCacheStore(opts ...MemoryCacheOption) *MemoryCacheStore {
+	s := &MemoryCacheStore{
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		maxEntries: defaultMemoryCacheMaxEntries,
+		maxBytes:   defaultMemoryCacheMaxBytes,
+		lastSweep:  time.Now(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Get 키에 해당하는 값을 반환
+func (s *MemoryCacheStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	elem, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := elem.Value.(*memoryCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		s.remove(elem)
+		return nil, false, nil
+	}
+	s.lru.MoveToFront(elem)
+	return entry.value, true, nil
+}
+
+// Set 값을 ttl 동안 보관
+//
+// 최대 크기보다 큰 값은 보관하지 않습니다. 제한을 넘으면 만료된 항목을 먼저 정리한 뒤 오래된 항목을 제거합니다.
+func (s *MemoryCacheStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if elem, ok := s.entries[key]; ok {
+		s.remove(elem)
+	}
+	entry := &memoryCacheEntry{key: key, value: value, expiresAt: time.Now().Add(ttl)}
+	if s.maxBytes > 0 && entry.size() > s.maxBytes {
+		return nil
+	}
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) >= memoryCacheSweepInterval {
+		s.sweep(now)
+	}
+	s.entries[key] = s.lru.PushFront(entry)
+	s.size += entry.size()
+	if s.overLimit() {
+		s.sweep(now)
+	}
+	for s.overLimit() {
+		s.remove(s.lru.Back())
+	}
+	return nil
+}
+
+// Delete 키에 해당하는 값을 삭제
+func (s *MemoryCacheStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if elem, ok := s.entries[key]; ok {
+		s.remove(elem)
+	}
+	return nil
+}
+
+// Len 보관 중인 항목 수를 반환. 아직 정리되지 않은 만료 항목을 포함
+func (s *MemoryCacheStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lru.Len()
+}
+
+// overLimit 최대 항목 수나 최대 크기를 넘었는지 판단
+func (s *MemoryCacheStore) overLimit() bool {
+	return (s.maxEntries > 0 && s.lru.Len() > s.maxEntries) || (s.maxBytes > 0 && s.size > s.maxBytes)
+}
+
+// sweep 만료된 항목을 모두 정리
+func (s *MemoryCacheStore) sweep(now time.Time) {
+	s.lastSweep = now
+	for elem := s.lru.Front(); elem != nil; {
+		next := elem.Next()
+		if now.After(elem.Value.(*memoryCacheEntry).expiresAt) {
+			s.remove(elem)
+		}
+		elem = next
+	}
+}
+
+// remove 항목을 제거
+func (s *MemoryCacheStore) remove(elem *list.Element) {
+	entry := s.lru.Remove(elem).(*memoryCacheEntry)
+	delete(s.entries, entry.key)
+	s.size -= entry.size()
+}
+
+// size 항목이 차지하는 크기 (키와 값의 합)
+func (e *memoryCacheEntry) size() int64 {
+	return int64(len(e.key) + len(e.value))
+}
+
+// NewDiskCacheStore 디스크 캐시 저장소를 생성
+//
+// Parameters:
+//   - dir: (string) 캐시 파일을 보관할 디렉터리. 없으면 생성
+func NewDiskCacheStore(dir string) (*DiskCacheStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating cache directory: %w", err)
+	}
+	return &DiskCacheStore{dir: dir}, nil
+}
+
+// Get 키에 해당하는 값을 반환
+//
+// 파일은 만료 시각(unix nano, 8바이트)과 값으로 구성됩니다.
+func (s *DiskCacheStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if len(data) < 8 {
+		return nil, false, fmt.Errorf("corrupted cache file for %q", key)
+	}
+	expiresAt := time.Unix(0, int64(binary.BigEndian.Uint64(data[:8])))
+	if time.Now().After(expiresAt) {
+		os.Remove(s.path(key))
+		return nil, false, nil
+	}
+	return data[8:], true, nil
+}
+
+// Set 값을 ttl 동안 보관
+//
+// 동시에 읽는 요청이 일부만 쓰인 파일을 읽지 않도록 임시 파일에 쓴 뒤 이름을 변경합니다.
+func (s *DiskCacheStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	file, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	var header [8]byte
+	binary.BigEndian.PutUint64(header[:], uint64(time.Now().Add(ttl).UnixNano()))
+	if _, err := file.Write(header[:]); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Write(value); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), s.path(key))
+}
+
+// Delete 키에 해당하는 값을 삭제
+func (s *DiskCacheStore) Delete(_ context.Context, key string) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path 키의 캐시 파일 경로. 키에 경로로 사용할 수 없는 문자가 있으므로 해시를 사용
+func (s *DiskCacheStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:]))
+}
@@ -0,0 +1,46 @@
+package httpretry
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+)
+
+// canary stable 엔드포인트로 향하는 요청 일부를 canary 엔드포인트로 분배
+type canary struct {
+	stable  *url.URL
+	canary  *url.URL
+	percent float64
+}
+
+// newCanary canary 설정을 생성. canary 분배를 하지 않는 경우 nil을 반환
+func newCanary(settings *Settings) *canary {
+	if settings.CanaryStableURL == "" || settings.CanaryURL == "" || settings.CanaryPercent <= 0 {
+		return nil
+	}
+	stable, err := url.Parse(settings.CanaryStableURL)
+	if err == nil {
+		var target *url.URL
+		if target, err = url.Parse(settings.CanaryURL); err == nil {
+			return &canary{stable: stable, canary: target, percent: settings.CanaryPercent}
+		}
+	}
+	newLogger(settings).Debug("canary routing disabled. invalid url", "error", err)
+	return nil
+}
+
+// pick 요청을 canary 엔드포인트로 보낼지 결정. stable 엔드포인트로 향하는 요청만 분배
+func (c *canary) pick(req *http.Request) bool {
+	if c == nil || req.URL.Scheme != c.stable.Scheme || req.URL.Host != c.stable.Host {
+		return false
+	}
+	return rand.Float64()*100 < c.percent
+}
+
+// target canary 엔드포인트로 scheme과 host를 변경한 URL을 반환
+func (c *canary) target(u *url.URL) *url.URL {
+	target := *u
+	target.Scheme = c.canary.Scheme
+	target.Host = c.canary.Host
+	return &target
+}
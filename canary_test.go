@@ -0,0 +1,73 @@
+package httpretry_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithCanary(t *testing.T) {
+	newServer := func(name string, status int, count *int) *httptest.Server {
+		return httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				*count++
+				w.WriteHeader(status)
+				w.Write([]byte(name))
+			}),
+		)
+	}
+
+	t.Run("canary로 분배된 요청을 canary 엔드포인트로 전송하는 테스트", func(t *testing.T) {
+		// given
+		var stableCount, canaryCount int
+		stable := newServer("stable", http.StatusOK, &stableCount)
+		defer stable.Close()
+		canary := newServer("canary", http.StatusOK, &canaryCount)
+		defer canary.Close()
+
+		client := httpretry.NewClient(
+			httpretry.NewHTTPSettings(httpretry.WithCanary(stable.URL, canary.URL, 100)),
+		)
+
+		// when
+		resp, err := client.Get(stable.URL + "/items")
+
+		// then
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "canary", string(body))
+		assert.Equal(t, 0, stableCount)
+		assert.Equal(t, stable.URL+"/items", resp.Request.URL.String(), "응답의 요청은 원본 요청이어야 합니다.")
+	})
+
+	t.Run("canary 실패 시 백오프 없이 stable 엔드포인트로 재시도하는 테스트", func(t *testing.T) {
+		// given
+		var stableCount, canaryCount int
+		stable := newServer("stable", http.StatusOK, &stableCount)
+		defer stable.Close()
+		canary := newServer("canary", http.StatusServiceUnavailable, &canaryCount)
+		defer canary.Close()
+
+		client := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithBackoffPolicy(func(int) time.Duration { return time.Minute }),
+				httpretry.WithCanary(stable.URL, canary.URL, 100),
+			),
+		)
+
+		// when
+		resp, err := client.Get(stable.URL)
+
+		// then
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "stable", string(body))
+		assert.Equal(t, 1, canaryCount)
+		assert.Equal(t, 1, stableCount)
+	})
+}
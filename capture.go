@@ -0,0 +1,57 @@
+package httpretry
+
+import (
+	"io"
+	"net/http"
+)
+
+// CapturedResponse 실패한 시도의 응답 사본
+type CapturedResponse struct {
+	// StatusCode 응답 상태 코드
+	StatusCode int
+	// Header 응답 헤더
+	Header http.Header
+	// Body 응답 바디의 앞부분 (최대 Settings.CaptureAttempts 바이트)
+	Body []byte
+	// Truncated 바디가 잘렸는지 여부
+	Truncated bool
+}
+
+// captureResponse 응답의 상태 코드, 헤더, 바디 앞부분을 복사. 캡처하지 않는 경우 nil을 반환
+//
+// 바디는 읽은 만큼 소비되므로, 응답을 폐기하기 전에 호출해야 합니다.
+func (rt *retriableTransport) captureResponse(response *http.Response) *CapturedResponse {
+	if rt.captureLimit <= 0 || response == nil {
+		return nil
+	}
+	captured := &CapturedResponse{StatusCode: response.StatusCode, Header: response.Header.Clone()}
+	if response.Body == nil {
+		return captured
+	}
+	body, _ := io.ReadAll(io.LimitReader(response.Body, int64(rt.captureLimit)+1))
+	if len(body) > rt.captureLimit {
+		body, captured.Truncated = body[:rt.captureLimit], true
+	}
+	captured.Body = body
+	return captured
+}
+
+// Responses 응답을 받은 실패한 시도의 응답 사본을 시도 순서대로 반환
+//
+// Settings.CaptureAttempts가 0이면 빈 슬라이스를 반환합니다.
+//
+//	var retryErr *httpretry.RetryError
+//	if errors.As(err, &retryErr) {
+//		for _, resp := range retryErr.Responses() {
+//			log.Printf("%d %s", resp.StatusCode, resp.Body)
+//		}
+//	}
+func (e *RetryError) Responses() []*CapturedResponse {
+	var responses []*CapturedResponse
+	for _, err := range e.errs {
+		if attemptErr, ok := err.(*AttemptError); ok && attemptErr.Response != nil {
+			responses = append(responses, attemptErr.Response)
+		}
+	}
+	return responses
+}
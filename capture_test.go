@@ -0,0 +1,66 @@
+package httpretry_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithCaptureAttempts(t *testing.T) {
+	t.Run("실패한 시도의 상태 코드, 헤더, 바디 앞부분을 보관하는 테스트", func(t *testing.T) {
+		// given
+		var received atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Attempt", strconv.Itoa(int(received.Add(1))))
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("upstream unavailable"))
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithCaptureAttempts(8),
+		))
+
+		// when
+		_, err := client.Get(server.URL)
+
+		// then
+		var retryErr *httpretry.RetryError
+		assert.True(t, errors.As(err, &retryErr))
+		responses := retryErr.Responses()
+		assert.Len(t, responses, 2)
+		for i, resp := range responses {
+			assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+			assert.Equal(t, strconv.Itoa(i+1), resp.Header.Get("X-Attempt"))
+			assert.Equal(t, "upstream", string(resp.Body))
+			assert.True(t, resp.Truncated)
+		}
+	})
+
+	t.Run("캡처하지 않으면 응답 사본이 없는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(noBackoff),
+		))
+
+		// when
+		_, err := client.Get(server.URL)
+
+		// then
+		var retryErr *httpretry.RetryError
+		assert.True(t, errors.As(err, &retryErr))
+		assert.Empty(t, retryErr.Responses())
+	})
+}
@@ -0,0 +1,147 @@
+package httpretry
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"net/http"
+)
+
+// ErrChecksumMismatch 응답 바디의 체크섬이 기대값과 다른 경우. 재시도 대상 에러
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+type (
+	// checksumKey 호출자가 지정한 체크섬을 저장하는 context key
+	checksumKey struct{}
+
+	// expectedChecksum 응답 바디에 기대하는 체크섬
+	expectedChecksum struct {
+		newHash func() hash.Hash
+		digest  []byte
+		source  string
+	}
+)
+
+// checksumHeaders 체크섬 검증에 사용하는 응답 헤더. 값은 base64로 인코딩된 digest
+var checksumHeaders = []struct {
+	header  string
+	newHash func() hash.Hash
+}{
+	{"Content-MD5", md5.New},
+	{"X-Amz-Checksum-Sha256", sha256.New},
+	{"X-Amz-Checksum-Sha1", sha1.New},
+	{"X-Amz-Checksum-Crc32", func() hash.Hash { return crc32.NewIEEE() }},
+	{"X-Amz-Checksum-Crc32c", func() hash.Hash { return crc32.New(crc32.MakeTable(crc32.Castagnoli)) }},
+}
+
+// ExpectChecksum 응답 바디의 체크섬을 검증하도록 표시한 context를 반환
+//
+// WithChecksumVerification 설정과 관계없이 검증하며, 체크섬이 다르면 ErrChecksumMismatch로 재시도합니다.
+// Content-Length가 1MB 이하인 응답만 반환 전에 읽어 검증하며, 더 크거나 크기를 알 수 없는 응답은
+// 바디를 읽는 동안 검증하여 EOF에서 ErrChecksumMismatch를 반환합니다.
+//
+//	req, _ := http.NewRequestWithContext(httpretry.ExpectChecksum(ctx, sha256.New, digest), http.MethodGet, url, nil)
+//
+// Parameters:
+//   - ctx: (context.Context) 부모 context
+//   - newHash: (func() hash.Hash) 해시 생성 함수
+//   - digest: ([]byte) 기대하는 체크섬
+func ExpectChecksum(ctx context.Context, newHash func() hash.Hash, digest []byte) context.Context {
+	return context.WithValue(ctx, checksumKey{}, &expectedChecksum{
+		newHash: newHash,
+		digest:  digest,
+		source:  "expected checksum",
+	})
+}
+
+// expectedChecksumFor 응답에 대해 검증할 체크섬을 반환. 검증하지 않는 경우 nil을 반환
+//
+// 호출자가 context로 지정한 체크섬을 우선 사용하고, 검증이 설정된 경우 응답 헤더의 체크섬을 사용합니다.
+// 전송 계층에서 압축을 해제한 응답은 헤더의 체크섬이 압축된 바디 기준이므로 검증하지 않습니다.
+func (rt *retriableTransport) expectedChecksumFor(req *http.Request, resp *http.Response) *expectedChecksum {
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil
+	}
+	if expected, ok := req.Context().Value(checksumKey{}).(*expectedChecksum); ok {
+		return expected
+	}
+	if !rt.verifyChecksum || resp.Uncompressed {
+		return nil
+	}
+	for _, candidate := range checksumHeaders {
+		value := resp.Header.Get(candidate.header)
+		if value == "" {
+			continue
+		}
+		digest, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			continue
+		}
+		return &expectedChecksum{newHash: candidate.newHash, digest: digest, source: candidate.header}
+	}
+	return nil
+}
+
+// checksumBufferLimit 응답 반환 전에 읽어 검증하는 바디의 최대 크기
+//
+// Content-Length가 이 크기 이하인 응답만 메모리에 읽어 검증하며, 체크섬이 다르면 재시도합니다.
+const checksumBufferLimit = 1 << 20
+
+// verify 응답 바디의 체크섬을 검증
+//
+// Content-Length가 checksumBufferLimit 이하이면 바디를 모두 읽어 검증하고, 읽은 내용으로 바디를 교체합니다.
+// 바디를 읽지 못한 경우에도 재시도할 수 있도록 에러를 반환합니다.
+// 크기를 알 수 없거나 더 큰 바디는 메모리에 보관하지 않고, 호출자가 읽는 동안 해시를 계산하여
+// EOF에서 체크섬이 다르면 ErrChecksumMismatch를 반환하도록 바디를 감쌉니다. 이 경우 재시도하지 않습니다.
+func (c *expectedChecksum) verify(resp *http.Response) error {
+	if resp.ContentLength < 0 || resp.ContentLength > checksumBufferLimit {
+		resp.Body = &checksumReader{ReadCloser: resp.Body, hash: c.newHash(), expected: c}
+		return nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return fmt.Errorf("reading body for checksum verification: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	h := c.newHash()
+	h.Write(body)
+	return c.compare(h.Sum(nil))
+}
+
+// compare 계산한 체크섬을 기대값과 비교
+func (c *expectedChecksum) compare(sum []byte) error {
+	if !bytes.Equal(sum, c.digest) {
+		return fmt.Errorf("%s: got %x, want %x: %w", c.source, sum, c.digest, ErrChecksumMismatch)
+	}
+	return nil
+}
+
+// checksumReader 읽은 바디의 해시를 계산하고, EOF에서 체크섬이 다르면 에러를 반환하는 바디
+type checksumReader struct {
+	io.ReadCloser
+	hash     hash.Hash
+	expected *expectedChecksum
+}
+
+// Read 바디를 읽고 해시에 더함. EOF에서 체크섬이 다르면 io.EOF 대신 ErrChecksumMismatch를 반환
+func (r *checksumReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.hash.Write(p[:n])
+	if err == io.EOF {
+		if mismatch := r.expected.compare(r.hash.Sum(nil)); mismatch != nil {
+			return n, mismatch
+		}
+	}
+	return n, err
+}
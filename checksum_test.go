@@ -0,0 +1,134 @@
+package httpretry_test
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecksumVerification(t *testing.T) {
+	content := []byte("payload")
+	md5Sum := md5.Sum(content)
+	sha256Sum := sha256.Sum256(content)
+
+	t.Run("Content-MD5와 바디가 다르면 재시도하는 테스트", func(t *testing.T) {
+		// given
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-MD5", base64.StdEncoding.EncodeToString(md5Sum[:]))
+			if atomic.AddInt32(&requests, 1) == 1 {
+				w.Write([]byte("corrupt"))
+				return
+			}
+			w.Write(content)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithChecksumVerification(true),
+			httpretry.WithBackoffPolicy(noBackoff),
+		))
+
+		// when
+		resp, err := client.Get(server.URL)
+
+		// then
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, content, body)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("검증을 설정하지 않으면 응답 헤더의 체크섬을 확인하지 않는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Amz-Checksum-Sha256", base64.StdEncoding.EncodeToString(sha256Sum[:]))
+			w.Write([]byte("corrupt"))
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings())
+
+		// when
+		resp, err := client.Get(server.URL)
+
+		// then
+		assert.NoError(t, err)
+		resp.Body.Close()
+	})
+
+	t.Run("호출자가 지정한 체크섬과 계속 다르면 ErrChecksumMismatch를 반환하는 테스트", func(t *testing.T) {
+		// given
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			w.Write([]byte("corrupt"))
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(3),
+			httpretry.WithBackoffPolicy(noBackoff),
+		))
+		ctx := httpretry.ExpectChecksum(context.Background(), sha256.New, sha256Sum[:])
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+		// when
+		_, err := client.Do(req)
+
+		// then
+		assert.True(t, errors.Is(err, httpretry.ErrChecksumMismatch))
+		assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("크기를 알 수 없는 바디는 버퍼링하지 않고 읽는 동안 검증하는 테스트", func(t *testing.T) {
+		tests := []struct {
+			name string
+			body []byte
+			err  error
+		}{
+			{"체크섬 일치", content, nil},
+			{"체크섬 불일치", []byte("corrupt"), httpretry.ErrChecksumMismatch},
+		}
+
+		for _, tt := range tests {
+			// given
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				w.Header().Set("Content-MD5", base64.StdEncoding.EncodeToString(md5Sum[:]))
+				w.(http.Flusher).Flush() // Content-Length 없이 chunked로 전송
+				w.Write(tt.body)
+			}))
+			client := httpretry.NewClient(httpretry.NewHTTPSettings(
+				httpretry.WithChecksumVerification(true),
+				httpretry.WithBackoffPolicy(noBackoff),
+			))
+
+			// when
+			resp, err := client.Get(server.URL)
+
+			// then
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, int64(-1), resp.ContentLength, tt.name)
+			body, readErr := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			assert.Equal(t, tt.body, body, tt.name)
+			if tt.err == nil {
+				assert.NoError(t, readErr, tt.name)
+			} else {
+				assert.ErrorIs(t, readErr, tt.err, tt.name)
+			}
+			assert.Equal(t, int32(1), requests.Load(), "응답 반환 후 검증하므로 재시도하지 않음")
+			server.Close()
+		}
+	})
+}
@@ -0,0 +1,182 @@
+package httpretry
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"strings"
+	"syscall"
+)
+
+// ErrorClassifier 전송 에러의 재시도 가능 여부를 판단하는 함수
+//
+// true를 반환하면 재시도하며, false를 반환하면 남은 재시도 횟수와 관계없이 즉시 에러를 반환합니다.
+type ErrorClassifier func(err error) bool
+
+// retryableErrnos 일시적인 장애로 보아 재시도하는 syscall 에러
+var retryableErrnos = []syscall.Errno{
+	syscall.ECONNRESET,
+	syscall.ECONNREFUSED,
+	syscall.ECONNABORTED,
+	syscall.EPIPE,
+	syscall.ETIMEDOUT,
+	syscall.EHOSTUNREACH,
+	syscall.ENETUNREACH,
+}
+
+// retryableMessages net/http가 내부 에러 타입 없이 반환하는 일시적 장애 메시지
+var retryableMessages = []string{
+	"server closed idle connection",
+	"transport connection broken",
+	"connection reset by peer",
+}
+
+// DefaultErrorClassifier 기본 에러 분류기
+//
+// 타임아웃, EOF, 커넥션 리셋/거부 등 일시적인 네트워크 장애는 재시도하고,
+// "unsupported protocol scheme", DNS NXDOMAIN, 인증서 검증 실패, 서버가 보낸 TLS alert처럼 재시도해도 결과가 같은 에러는 재시도하지 않습니다.
+func DefaultErrorClassifier(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// 영구적인 에러는 타임아웃 여부와 관계없이 즉시 실패
+	if isCertificateError(err) || isTLSAlertError(err) {
+		return false
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return !dnsErr.IsNotFound && (dnsErr.IsTimeout || dnsErr.IsTemporary)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	for _, errno := range retryableErrnos {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+
+	// Temporary()는 deprecated 되었으나, 일부 구현은 여전히 일시적 장애를 이 방식으로 알림
+	var temporary interface{ Temporary() bool }
+	if errors.As(err, &temporary) && temporary.Temporary() {
+		return true
+	}
+
+	message := err.Error()
+	for _, retryable := range retryableMessages {
+		if strings.Contains(message, retryable) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCertificateError 인증서 검증 실패 여부를 판단
+func isCertificateError(err error) bool {
+	var (
+		verificationErr *tls.CertificateVerificationError
+		unknownAuthErr  x509.UnknownAuthorityError
+		invalidErr      x509.CertificateInvalidError
+		hostnameErr     x509.HostnameError
+	)
+	return errors.As(err, &verificationErr) ||
+		errors.As(err, &unknownAuthErr) ||
+		errors.As(err, &invalidErr) ||
+		errors.As(err, &hostnameErr)
+}
+
+// isTLSAlertError 서버가 보낸 TLS alert(bad_certificate, handshake_failure 등)인지 판단
+//
+// 클라이언트 인증서 거부, 지원하지 않는 프로토콜처럼 서버가 연결을 거부한 것이므로 재시도해도 결과가 같습니다.
+// crypto/tls는 서버가 보낸 alert를 "remote error: tls: ..." 메시지의 비공개 타입으로 반환하므로 메시지로 판단합니다.
+func isTLSAlertError(err error) bool {
+	var alertErr tls.AlertError
+	if errors.As(err, &alertErr) {
+		return true
+	}
+	return strings.Contains(err.Error(), "remote error: tls: ")
+}
+
+// isTLSError 핸드셰이크 타임아웃, 잘못된 레코드처럼 일시적일 수 있는 TLS 에러인지 판단
+//
+// 배포 중 핸드셰이크 타임아웃이나 TLS가 준비되지 않은 포트가 해당하며,
+// 인증서 검증 실패(isCertificateError)와 서버가 보낸 alert(isTLSAlertError)는 해당하지 않습니다.
+func isTLSError(err error) bool {
+	var recordErr tls.RecordHeaderError
+	if errors.As(err, &recordErr) {
+		return true
+	}
+	return strings.Contains(err.Error(), "TLS handshake timeout")
+}
+
+// unprocessedMessages 서버가 요청을 처리하지 않았음이 보장되는 HTTP/2 에러 메시지
+//
+// net/http에 번들된 http2 구현은 에러 타입을 노출하지 않으므로 메시지로 판단합니다.
+// "server sent GOAWAY and closed the connection"은 LastStreamID 이하의 스트림으로, 처리되었을 수 있으므로 제외합니다.
+var unprocessedMessages = []string{
+	"received Server's graceful shutdown GOAWAY",
+	"received GOAWAY from server",
+	"client conn not usable",
+}
+
+// isUnprocessedError 서버가 요청을 처리하지 않았음이 보장되는 에러인지 판단
+//
+// GOAWAY 이후의 스트림이나 REFUSED_STREAM은 표준 라이브러리와 마찬가지로 메서드와 관계없이 재시도할 수 있습니다.
+func isUnprocessedError(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := err.Error()
+	if strings.Contains(message, "stream error") && strings.Contains(message, "REFUSED_STREAM") {
+		return true
+	}
+	for _, unprocessed := range unprocessedMessages {
+		if strings.Contains(message, unprocessed) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAny 에러가 대상 중 하나와 일치하는지 판단
+func matchesAny(err error, targets []error) bool {
+	for _, target := range targets {
+		if matchesError(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesError 에러가 대상과 일치하는지 판단
+//
+// errors.Is로 비교하며, 대상이 에러 타입의 zero value(또는 zero value의 포인터)인 경우 errors.As로 타입을 비교합니다.
+// 예) io.ErrUnexpectedEOF는 sentinel로, x509.UnknownAuthorityError{}는 타입으로 비교
+func matchesError(err, target error) bool {
+	if target == nil {
+		return false
+	}
+	if errors.Is(err, target) {
+		return true
+	}
+
+	value := reflect.ValueOf(target)
+	if value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if !value.IsZero() {
+		return false
+	}
+	return errors.As(err, reflect.New(reflect.TypeOf(target)).Interface())
+}
@@ -0,0 +1,386 @@
+package httpretry_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingMiddleware 시도 횟수를 세는 미들웨어
+func countingMiddleware(count *int) func(http.RoundTripper) http.RoundTripper {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			*count++
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func noBackoff(int) time.Duration { return 0 }
+
+type timeoutError struct{}
+
+func (*timeoutError) Error() string   { return "i/o timeout" }
+func (*timeoutError) Timeout() bool   { return true }
+func (*timeoutError) Temporary() bool { return true }
+
+func TestDefaultErrorClassifier(t *testing.T) {
+	testCases := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{"EOF", io.EOF, true},
+		{"unexpected EOF", fmt.Errorf("read body: %w", io.ErrUnexpectedEOF), true},
+		{"connection reset", &net.OpError{Op: "read", Err: syscall.ECONNRESET}, true},
+		{"connection refused", &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, true},
+		{"server closed idle connection", errors.New("http: server closed idle connection"), true},
+		{"unsupported protocol scheme", errors.New(`unsupported protocol scheme "ftp"`), false},
+		{"dial timeout", &net.OpError{Op: "dial", Err: &timeoutError{}}, true},
+		{"dns not found", &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", IsNotFound: true}}, false},
+		{"dns temporary", &net.OpError{Op: "dial", Err: &net.DNSError{Err: "server misbehaving", IsTemporary: true}}, true},
+		{"unknown certificate authority", &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}, false},
+		{"unknown dial error", &net.OpError{Op: "dial", Err: errors.New("permission denied")}, false},
+		{"nil", nil, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.retryable, httpretry.DefaultErrorClassifier(tc.err))
+		})
+	}
+}
+
+func TestRetriableTransport_ErrorClassifier(t *testing.T) {
+	t.Run("재시도 불가능한 에러 발생 시, 즉시 에러 반환 테스트", func(t *testing.T) {
+		// given
+		attempts := 0
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(3),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithTransportMiddleware(countingMiddleware(&attempts)),
+			),
+		)
+
+		// when
+		_, err := retryClient.Get("ftp://example.com")
+
+		// then
+		assert.ErrorContains(t, err, "unsupported protocol scheme")
+		assert.Equal(t, 1, attempts, "재시도 하지 않아야 합니다.")
+	})
+
+	t.Run("커넥션 거부 시, 재시도 테스트", func(t *testing.T) {
+		// given
+		testServer := httptest.NewServer(http.NotFoundHandler())
+		testServer.Close() // 닫힌 서버로 요청하여 커넥션 거부 유도
+
+		attempts := 0
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(3),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithTransportMiddleware(countingMiddleware(&attempts)),
+			),
+		)
+
+		// when
+		_, err := retryClient.Get(testServer.URL)
+
+		// then
+		assert.ErrorContains(t, err, "max retries reached")
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("분류기 변경 시, 변경된 분류기로 재시도 여부 판단 테스트", func(t *testing.T) {
+		// given
+		testServer := httptest.NewServer(http.NotFoundHandler())
+		testServer.Close()
+
+		attempts := 0
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(3),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithTransportMiddleware(countingMiddleware(&attempts)),
+				httpretry.WithErrorClassifier(func(err error) bool { return false }),
+			),
+		)
+
+		// when
+		_, err := retryClient.Get(testServer.URL)
+
+		// then
+		assert.Error(t, err)
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+func TestRetriableTransport_UnprocessedHTTP2Errors(t *testing.T) {
+	unprocessedErrors := []error{
+		errors.New("http2: Transport received Server's graceful shutdown GOAWAY"),
+		errors.New("stream error: stream ID 3; REFUSED_STREAM"),
+	}
+
+	for _, unprocessedErr := range unprocessedErrors {
+		t.Run(unprocessedErr.Error()+" 발생 시, 분류기와 관계없이 바디와 함께 재시도 테스트", func(t *testing.T) {
+			// given
+			var receivedBody string
+			testServer := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					body, _ := io.ReadAll(r.Body)
+					receivedBody = string(body)
+					w.WriteHeader(http.StatusCreated)
+				}),
+			)
+			defer testServer.Close()
+
+			attempts := 0
+			refuseFirst := func(next http.RoundTripper) http.RoundTripper {
+				return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					attempts++
+					if attempts == 1 {
+						req.Body.Close()
+						return nil, unprocessedErr
+					}
+					return next.RoundTrip(req)
+				})
+			}
+
+			retryClient := httpretry.NewClient(
+				httpretry.NewHTTPSettings(
+					httpretry.WithMaxRetry(3),
+					httpretry.WithBackoffPolicy(noBackoff),
+					httpretry.WithErrorClassifier(func(err error) bool { return false }),
+					httpretry.WithTransportMiddleware(refuseFirst),
+				),
+			)
+
+			// when
+			resp, err := retryClient.Post(testServer.URL, "text/plain", strings.NewReader("payload"))
+
+			// then
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusCreated, resp.StatusCode)
+			assert.Equal(t, 2, attempts)
+			assert.Equal(t, "payload", receivedBody)
+		})
+	}
+}
+
+func TestRetriableTransport_TLSErrors(t *testing.T) {
+	// newGarbageServer TLS 핸드셰이크 대신 잘못된 레코드를 응답하는 서버
+	newGarbageServer := func(t *testing.T) string {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		t.Cleanup(func() { listener.Close() })
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				conn.Write([]byte("garbage record\n\n"))
+				conn.Close()
+			}
+		}()
+		return "https://" + listener.Addr().String()
+	}
+
+	t.Run("TLS 에러 재시도 활성화 시, 재시도 테스트", func(t *testing.T) {
+		// given
+		attempts := 0
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(3),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithTransportMiddleware(countingMiddleware(&attempts)),
+			),
+		)
+
+		// when
+		_, err := retryClient.Get(newGarbageServer(t))
+
+		// then
+		assert.ErrorContains(t, err, "max retries reached")
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("TLS 에러 재시도 비활성화 시, 즉시 에러 반환 테스트", func(t *testing.T) {
+		// given
+		attempts := 0
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(3),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithRetryTLSErrors(false),
+				httpretry.WithTransportMiddleware(countingMiddleware(&attempts)),
+			),
+		)
+
+		// when
+		_, err := retryClient.Get(newGarbageServer(t))
+
+		// then
+		assert.Error(t, err)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("인증서 검증 실패 시, TLS 에러 재시도 설정과 관계없이 즉시 에러 반환 테스트", func(t *testing.T) {
+		// given
+		testServer := httptest.NewTLSServer(http.NotFoundHandler())
+		defer testServer.Close()
+
+		attempts := 0
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(3),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithInsecure(false),
+				httpretry.WithRetryTLSErrors(true),
+				httpretry.WithTransportMiddleware(countingMiddleware(&attempts)),
+			),
+		)
+
+		// when
+		_, err := retryClient.Get(testServer.URL)
+
+		// then
+		assert.ErrorContains(t, err, "certificate")
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("서버가 bad_certificate alert를 보내면, TLS 에러 재시도 설정과 관계없이 즉시 에러 반환 테스트", func(t *testing.T) {
+		// given
+		testServer := httptest.NewUnstartedServer(http.NotFoundHandler())
+		// 클라이언트 인증서를 거부하면 서버가 bad_certificate alert를 보냄
+		testServer.TLS = &tls.Config{
+			ClientAuth: tls.RequireAnyClientCert,
+			MaxVersion: tls.VersionTLS12,
+			VerifyPeerCertificate: func([][]byte, [][]*x509.Certificate) error {
+				return errors.New("client certificate rejected")
+			},
+		}
+		testServer.StartTLS()
+		defer testServer.Close()
+		clientCert := testServer.TLS.Certificates[0]
+
+		attempts := 0
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(3),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithTLSConfig(&tls.Config{InsecureSkipVerify: true, Certificates: []tls.Certificate{clientCert}}),
+				httpretry.WithRetryTLSErrors(true),
+				httpretry.WithTransportMiddleware(countingMiddleware(&attempts)),
+			),
+		)
+
+		// when
+		_, err := retryClient.Get(testServer.URL)
+
+		// then
+		assert.ErrorContains(t, err, "remote error: tls: bad certificate")
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+func TestRetriableTransport_ErrorTargets(t *testing.T) {
+	errSentinel := errors.New("sentinel")
+
+	// failingMiddleware 항상 지정한 에러를 반환하는 미들웨어
+	failingMiddleware := func(count *int, err error) func(http.RoundTripper) http.RoundTripper {
+		return func(http.RoundTripper) http.RoundTripper {
+			return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				*count++
+				return nil, err
+			})
+		}
+	}
+
+	t.Run("WithRetryOnError 등록 시, 분류기가 재시도하지 않는 에러도 재시도 테스트", func(t *testing.T) {
+		// given
+		attempts := 0
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(3),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithRetryOnError(errSentinel),
+				httpretry.WithTransportMiddleware(
+					failingMiddleware(&attempts, fmt.Errorf("wrapped: %w", errSentinel)),
+				),
+			),
+		)
+
+		// when
+		_, err := retryClient.Get("http://example.com")
+
+		// then
+		assert.ErrorContains(t, err, "max retries reached")
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("WithNoRetryOnError 등록 시, 분류기가 재시도하는 에러도 즉시 반환 테스트", func(t *testing.T) {
+		// given
+		attempts := 0
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(3),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithRetryOnError(io.ErrUnexpectedEOF),
+				httpretry.WithNoRetryOnError(io.ErrUnexpectedEOF),
+				httpretry.WithTransportMiddleware(failingMiddleware(&attempts, io.ErrUnexpectedEOF)),
+			),
+		)
+
+		// when
+		_, err := retryClient.Get("http://example.com")
+
+		// then
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		assert.Equal(t, 1, attempts, "재시도 금지가 우선되어야 합니다.")
+	})
+
+	t.Run("에러 타입의 zero value 등록 시, 타입으로 비교하는 테스트", func(t *testing.T) {
+		// given
+		testServer := httptest.NewTLSServer(http.NotFoundHandler())
+		defer testServer.Close()
+
+		attempts := 0
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(3),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithInsecure(false),
+				httpretry.WithRetryOnError(x509.UnknownAuthorityError{}),
+				httpretry.WithTransportMiddleware(countingMiddleware(&attempts)),
+			),
+		)
+
+		// when
+		_, err := retryClient.Get(testServer.URL)
+
+		// then
+		assert.ErrorContains(t, err, "max retries reached")
+		assert.Equal(t, 3, attempts)
+	})
+}
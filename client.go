@@ -1,180 +1,902 @@
 package httpretry
 
 import (
+	"cmp"
+	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
-	"log"
+	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
-
-	"github.com/pkg/errors"
-	"go.uber.org/multierr"
 )
 
-var defaultRetryStatusMap = map[int]string{
-	http.StatusInternalServerError: "서버 처리 불가로 재시도",
-	http.StatusBadGateway:          "게이트웨이 오류로 재시도",
-	http.StatusServiceUnavailable:  "서비스 사용 불가상태로 재시도",
-	http.StatusGatewayTimeout:      "게이트웨이 타임아웃으로 재시도",
-}
+// errAttemptTimeout 시도별 타임아웃으로 context가 취소되었음을 나타내는 cause
+var errAttemptTimeout error = &timeoutError{msg: "request timeout"}
+
+// errTotalTimeout 전체 타임아웃으로 context가 취소되었음을 나타내는 cause
+var errTotalTimeout error = &timeoutError{msg: "total timeout exceeded"}
+
+// errRetryByBody 응답 바디 검사 결과 재시도가 필요함을 나타내는 에러
+var errRetryByBody = errors.New("retry requested by body inspection")
 
 type retriableTransport struct {
 	http.RoundTripper
-	requestTimeout   time.Duration
-	maxRetries       int
-	retryStatusCodes map[int]string
-	backoffPolicy    func(attempt int) time.Duration
-	debugMode        bool
+	// transport 미들웨어가 적용되기 전의 기본 Transport. 복제한 클라이언트와 커넥션 풀을 공유
+	transport http.RoundTripper
+	// settings 클라이언트 복제 시 사용하는 생성 당시의 설정
+	settings           *Settings
+	statusCodes        []int
+	lifecycle          *lifecycle
+	attemptTimeout     time.Duration
+	totalTimeout       time.Duration
+	policy             retryPolicy
+	hostPolicies       map[string]retryPolicy
+	routes             *http.ServeMux
+	retryMethods       map[string]struct{}
+	noRetryMethods     map[string]struct{}
+	errorClassifier    ErrorClassifier
+	retryTLSErrors     bool
+	retryOnErrors      []error
+	noRetryOnErrors    []error
+	bodyPeekLimit      int
+	bodyInspector      BodyInspector
+	breakers           *breakerGroup
+	mirror             *mirror
+	canary             *canary
+	endpoints          *endpointGroup
+	cache              *cacheLayer
+	logger             Logger
+	debugSampleRate    int
+	debugSampled       atomic.Uint64
+	events             *eventStream
+	pprofLabels        bool
+	tracing            *tracing
+	observer           Observer
+	retryRate          *retryRate
+	sharedLimit        *SharedLimit
+	fallback           FallbackFunc
+	beforeAttemptHook  BeforeAttemptFunc
+	bulkheads          bulkheads
+	shedder            *shedder
+	adaptive           *adaptiveLimits
+	streamingMode      bool
+	verifyChecksum     bool
+	maxRetryAfter      time.Duration
+	retryAfterPolicy   RetryAfterPolicy
+	deadlinePolicy     DeadlinePolicy
+	rateLimitJitterMin time.Duration
+	rateLimitJitterMax time.Duration
+	priority           *PriorityPolicy
+	errorAggregation   ErrorAggregation
+	timings            *timingStats
+	bodySpill          bodySpill
+	maxReplayableBody  int64
+	captureLimit       int
+	locale             Locale
+	connectionPolicy   RetryConnectionPolicy
 }
 
 // NewClient HTTP 클라이언트를 생성하고 재시도 설정을 적용
 func NewClient(settings *Settings, retryStatusCodes ...int) *http.Client {
-	return &http.Client{Transport: newRetriableTransport(settings, retryStatusCodes...)}
+	return &http.Client{Transport: NewTransport(settings, retryStatusCodes...)}
+}
+
+// NewClientFrom 기존 클라이언트에 재시도 설정을 적용한 클라이언트를 생성
+//
+// 기존 클라이언트의 Jar, CheckRedirect, Timeout과 Transport를 유지하고, Transport 앞에 재시도 계층을 추가합니다.
+// 기존 클라이언트는 변경하지 않으며, Timeout은 모든 재시도를 포함한 전체 요청 시간을 제한합니다.
+// 기존 Transport가 nil이면 설정으로 기본 Transport를 생성하며, 이미 재시도 Transport라면 그 안쪽 Transport를 사용합니다.
+// 기존 Transport를 사용하는 경우, 커넥션 관련 설정(Insecure, MaxIdleConns 등)은 적용되지 않습니다.
+//
+// Parameters:
+//   - existing: (*http.Client) 재시도를 적용할 기존 클라이언트
+//   - settings: (*Settings) 재시도 설정. nil인 경우 기본 설정을 사용
+//   - retryStatusCodes: (...int) 기본 재시도 상태 코드에 추가할 상태 코드
+func NewClientFrom(existing *http.Client, settings *Settings, retryStatusCodes ...int) *http.Client {
+	if settings == nil {
+		settings = NewHTTPSettings()
+	}
+	base := existing.Transport
+	if rt, ok := base.(*retriableTransport); ok {
+		base = rt.transport
+	}
+	if base == nil {
+		base = newBaseTransport(settings.guarded())
+	}
+	startWarmup(base, settings)
+
+	client := *existing
+	client.Transport = newRetriableTransportWith(base, settings, retryStatusCodes...)
+	return &client
+}
+
+// NewTransport 재시도 설정을 적용한 http.RoundTripper를 생성
+//
+// otelhttp, 인증 Transport 등과 조합하여 원하는 클라이언트 구성에 재시도 계층을 넣을 때 사용합니다.
+// 재시도 Transport를 감싸는 Transport는 요청마다 한 번, 재시도 Transport 안쪽에서 시도마다 호출하려면
+// WithTransportMiddleware를 사용합니다.
+//
+//	client := &http.Client{Transport: otelhttp.NewTransport(httpretry.NewTransport(settings))}
+//
+// Parameters:
+//   - settings: (*Settings) 재시도 설정. nil인 경우 기본 설정을 사용
+//   - retryStatusCodes: (...int) 기본 재시도 상태 코드에 추가할 상태 코드
+func NewTransport(settings *Settings, retryStatusCodes ...int) http.RoundTripper {
+	return newRetriableTransport(settings, retryStatusCodes...)
 }
 
 // newRetriableTransport는 재시도 가능한 Transport를 생성합니다.
 func newRetriableTransport(
 	settings *Settings,
 	retryStatusCodes ...int,
-) (customTransport *retriableTransport) {
+) *retriableTransport {
 	if settings == nil {
 		settings = NewHTTPSettings()
 	}
-	var (
-		transport *http.Transport
-	)
-	{
-		// transport 설정
-		transport = http.DefaultTransport.(*http.Transport)
-		transport.MaxIdleConns = settings.MaxIdleConns
-		transport.IdleConnTimeout = settings.IdleConnTimeout
-		transport.TLSHandshakeTimeout = settings.TLSHandshakeTimeout
-		transport.ExpectContinueTimeout = settings.ExpectContinueTimeout
-		transport.ResponseHeaderTimeout = settings.ResponseHeaderTimeout
+	settings = settings.guarded()
+	transport := newBaseTransport(settings)
+	startWarmup(transport, settings)
+	return newRetriableTransportWith(transport, settings, retryStatusCodes...)
+}
+
+// newBaseTransport 커넥션 풀을 관리하는 기본 Transport를 생성
+//
+// 전역 http.DefaultTransport를 변경하지 않도록 복제하여 설정합니다.
+func newBaseTransport(settings *Settings) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = newDialContext(settings)
+	if len(settings.Proxies) > 0 {
+		transport.Proxy = proxyFromContext
+	}
+	transport.MaxIdleConns = settings.MaxIdleConns
+	transport.IdleConnTimeout = settings.IdleConnTimeout
+	transport.TLSHandshakeTimeout = settings.TLSHandshakeTimeout
+	transport.ExpectContinueTimeout = settings.ExpectContinueTimeout
+	transport.ResponseHeaderTimeout = settings.ResponseHeaderTimeout
+	transport.DisableKeepAlives = settings.DisableKeepAlives
+	transport.DisableCompression = settings.DisableCompression
+	transport.ReadBufferSize = settings.ReadBufferSize
+	transport.WriteBufferSize = settings.WriteBufferSize
+	transport.MaxResponseHeaderBytes = settings.MaxResponseHeaderBytes
+	if len(settings.WarmupHosts) > 0 && settings.WarmupConnections > http.DefaultMaxIdleConnsPerHost {
+		// 미리 생성한 커넥션이 유휴 상태로 유지되도록 호스트별 유휴 커넥션 수를 늘림
+		transport.MaxIdleConnsPerHost = settings.WarmupConnections
+	}
+	if settings.TLSConfig != nil {
+		// 호출자가 구성한 TLS 설정을 그대로 사용하며, 이후 호출자의 변경이 커넥션에 영향을 주지 않도록 복제
+		transport.TLSClientConfig = settings.TLSConfig.Clone()
+	} else {
 		transport.TLSClientConfig = &tls.Config{
 			MinVersion:         tls.VersionTLS12,
-			InsecureSkipVerify: false,
+			InsecureSkipVerify: settings.Insecure,
 		}
+	}
 
-		if settings.Insecure {
-			transport.TLSClientConfig.InsecureSkipVerify = true
-		}
+	for _, tweak := range settings.TransportTweaks {
+		tweak(transport)
 	}
+	return transport
+}
+
+// newRetriableTransportWith 주어진 기본 Transport 위에 재시도 Transport를 생성
+//
+// 설정은 복제하여 사용하므로, 생성 이후 호출자가 설정을 변경해도 Transport에 영향을 주지 않습니다.
+func newRetriableTransportWith(
+	transport http.RoundTripper,
+	settings *Settings,
+	retryStatusCodes ...int,
+) (customTransport *retriableTransport) {
+	settings = settings.clone()
+	settings.Guardrails.clamp(settings)
 	{
 		// customTransport 설정
-		retryMap := extendDefault(retryStatusCodes)
+		retryMap := extendDefault(retryStatusCodes, settings.NoRetryStatusCodes, settings.Locale)
 		if settings.BackoffPolicy == nil {
 			settings.BackoffPolicy = defaultBackoffPolicy
 		}
-		customTransport = &retriableTransport{
-			RoundTripper:     transport,
-			requestTimeout:   settings.RequestTimeout,
+		if settings.ErrorClassifier == nil {
+			settings.ErrorClassifier = DefaultErrorClassifier
+		}
+		var base http.RoundTripper = transport
+		if len(settings.Proxies) > 0 {
+			base = newProxyRotation(base, settings.Proxies, settings.ProxyFailureThreshold, settings.ProxyCooldown)
+		}
+		for i := len(settings.TransportMiddlewares) - 1; i >= 0; i-- {
+			base = settings.TransportMiddlewares[i](base)
+		}
+		var breakers *breakerGroup
+		if settings.CircuitBreakerThreshold > 0 {
+			breakers = &breakerGroup{
+				threshold: settings.CircuitBreakerThreshold,
+				cooldown:  settings.CircuitBreakerCooldown,
+				probe:     settings.CircuitProbePolicy,
+				onChange:  settings.CircuitStateListener,
+			}
+		}
+		policy := retryPolicy{
 			maxRetries:       settings.MaxRetry,
-			retryStatusCodes: retryMap,
 			backoffPolicy:    settings.BackoffPolicy,
-			debugMode:        settings.DebugMode,
+			retryStatusCodes: retryMap,
+		}
+		var hostPolicies map[string]retryPolicy
+		if len(settings.HostPolicies) > 0 {
+			hostPolicies = make(map[string]retryPolicy, len(settings.HostPolicies))
+			for host, hostPolicy := range settings.HostPolicies {
+				hostPolicies[host] = hostPolicy.resolve(policy)
+			}
+		}
+		var timings *timingStats
+		if settings.AttemptTimings {
+			timings = newTimingStats()
+		}
+		customTransport = &retriableTransport{
+			RoundTripper:       base,
+			transport:          transport,
+			settings:           settings.clone(),
+			statusCodes:        retryStatusCodes,
+			lifecycle:          newLifecycle(),
+			attemptTimeout:     settings.attemptTimeout(),
+			totalTimeout:       settings.TotalTimeout,
+			policy:             policy,
+			hostPolicies:       hostPolicies,
+			routes:             newRouteMux(settings.RoutePolicies, policy),
+			retryMethods:       methodSet(settings.RetryMethods),
+			noRetryMethods:     methodSet(settings.NoRetryMethods),
+			errorClassifier:    settings.ErrorClassifier,
+			retryTLSErrors:     settings.RetryTLSErrors,
+			retryOnErrors:      settings.RetryOnErrors,
+			noRetryOnErrors:    settings.NoRetryOnErrors,
+			bodyPeekLimit:      settings.BodyPeekLimit,
+			bodyInspector:      settings.BodyInspector,
+			breakers:           breakers,
+			mirror:             newMirror(settings),
+			canary:             newCanary(settings),
+			endpoints:          newEndpointGroup(settings),
+			cache:              newCacheLayer(settings),
+			logger:             newLogger(settings),
+			debugSampleRate:    settings.DebugSampleRate,
+			events:             newEventStream(settings.EventBufferSize),
+			pprofLabels:        settings.PprofLabels,
+			tracing:            newTracing(settings),
+			observer:           settings.Observer,
+			retryRate:          newRetryRate(settings.RetryRateAlert),
+			sharedLimit:        sharedLimitFor(settings.SharedLimiter),
+			fallback:           settings.Fallback,
+			beforeAttemptHook:  settings.BeforeAttempt,
+			bulkheads:          newBulkheads(settings.Bulkheads),
+			shedder:            newShedder(settings.LoadShedding),
+			adaptive:           newAdaptiveLimits(settings.AdaptiveConcurrency),
+			streamingMode:      settings.StreamingMode,
+			verifyChecksum:     settings.VerifyChecksum,
+			maxRetryAfter:      settings.MaxRetryAfter,
+			retryAfterPolicy:   settings.RetryAfterPolicy,
+			deadlinePolicy:     settings.DeadlinePolicy,
+			rateLimitJitterMin: settings.RateLimitJitterMin,
+			rateLimitJitterMax: settings.RateLimitJitterMax,
+			priority:           settings.PriorityPolicy,
+			errorAggregation:   settings.ErrorAggregation,
+			timings:            timings,
+			bodySpill:          bodySpill{threshold: settings.BodySpillThreshold, dir: settings.BodySpillDir},
+			maxReplayableBody:  settings.MaxReplayableBodySize,
+			captureLimit:       settings.CaptureAttempts,
+			locale:             settings.Locale,
+			connectionPolicy:   settings.RetryConnectionPolicy,
 		}
 	}
 	return
 }
 
-// RoundTrip 요청을 처리하며 재시도를 구현
+// RoundTrip 요청을 처리하며, 캐시를 사용하는 경우 캐시된 응답을 먼저 확인
+//
+// 재시도를 포기하면 Fallback으로 대체 응답을 만들며, 대체 응답은 캐시하지 않습니다.
+func (rt *retriableTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt.cache != nil {
+		resp, err := rt.cache.roundTrip(req, rt.roundTrip)
+		return rt.withFallback(req, resp, err)
+	}
+	resp, err := rt.roundTrip(req)
+	return rt.withFallback(req, resp, err)
+}
+
+// roundTrip 요청을 처리하며 재시도를 구현
 //
-// 각 Request는 Timeout을 가지며, Timeout을 초과하는 경우 재시도를 수행
+// 각 시도는 AttemptTimeout을 가지며, Timeout을 초과하는 경우 재시도를 수행
+// TotalTimeout이 설정된 경우, 모든 시도와 백오프 대기 시간의 합이 TotalTimeout을 넘지 않음
 //
 //   - 요청 실패 시, 재시도를 수행
 //   - 요청 성공 시, 응답을 반환
 //   - 재시도 횟수를 초과하면 에러 반환
-func (rt *retriableTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	var allErrors error // 모든 시도에서 발생한 에러를 저장
+func (rt *retriableTransport) roundTrip(origReq *http.Request) (*http.Response, error) {
+	var allErrors []error // 모든 시도에서 발생한 에러를 저장
+	var reason Reason     // 재시도를 포기한 이유
+	attempts := 0         // 실제로 전송한 시도 수
+	var lastErr error     // 직전 시도의 전송 에러
+	var lastConn net.Conn // 직전 시도가 사용한 커넥션 (RetryConnectionFresh)
+
+	// 요청 헤더로 지정한 재시도 설정을 읽고, 헤더는 전송하지 않도록 제거
+	req, overrides, err := parseOverrides(origReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// 재시도 시 요청 바디를 다시 전송할 수 있도록 준비
+	// 최대 크기를 넘는 바디는 버퍼링하지 않고 한 번만 전송하므로 재시도하지 않음
+	getBody, releaseBody, replayable, err := replayableBody(req, rt.bodySpill, rt.maxReplayableBody)
+	if err != nil {
+		return nil, err
+	}
+	// 임시 파일에 저장한 바디는 마지막 시도가 끝나고 전송 중인 바디를 닫으면 삭제
+	defer releaseBody()
+
+	// 지연 시간이 임계값을 넘은 호스트는 연결 전에 일부 요청을 거절
+	if rt.shedder != nil {
+		if err := rt.shedder.admit(req.URL.Host); err != nil {
+			return nil, err
+		}
+	}
+
+	// 종료된 클라이언트는 새 요청을 받지 않으며, 진행 중인 요청은 종료 시 대기
+	if !rt.lifecycle.acquire() {
+		return nil, ErrClientClosed
+	}
+	// 의존 서비스별 bulkhead에 자리가 없으면 요청하지 않음
+	policy := rt.policyFor(req)
+	releaseBulkhead, err := rt.bulkheads.acquire(req.Context(), policy.bulkhead, req.URL)
+	if err != nil {
+		rt.lifecycle.release()
+		return nil, err
+	}
+	// 호스트별 적응형 동시 요청 수 제한에 도달하면 요청하지 않음
+	var limit *adaptiveLimit
+	if rt.adaptive != nil {
+		limit = rt.adaptive.get(req.URL.Host)
+		if err := limit.acquire(req.URL.Host); err != nil {
+			releaseBulkhead()
+			rt.lifecycle.release()
+			return nil, err
+		}
+	}
+	rt.mirrorRequest(req, getBody, replayable)
 
-	for attempt := 1; attempt <= rt.maxRetries+1; attempt++ {
-		// 부모 context가 이미 만료되었는지 확인
-		if req.Context().Err() != nil {
-			allErrors = multierr.Append(allErrors, errors.New("cancelled from parent context"))
+	// 전체 타임아웃은 모든 시도와 백오프 대기를 포함
+	loopCtx, cancelLoop := context.WithCancelCause(req.Context())
+	deadline := rt.requestDeadline(req.Context(), time.Now())
+	var totalTimer *time.Timer
+	if rt.totalTimeout > 0 {
+		totalTimer = time.AfterFunc(rt.totalTimeout, func() { cancelLoop(errTotalTimeout) })
+	}
+	stopTotal := func() {
+		if totalTimer != nil {
+			totalTimer.Stop()
+		}
+	}
+
+	// canary로 분배된 요청은 canary 엔드포인트에서 실패하면 stable 엔드포인트로 재시도
+	onCanary := rt.canary.pick(req)
+	route := rt.endpoints.route(req)
+	// targetURL 시도에서 요청할 URL을 반환. 변경이 없으면 nil을 반환
+	targetURL := func(attempt int) *url.URL {
+		switch {
+		case onCanary:
+			return rt.canary.target(req.URL)
+		case route != nil:
+			return route.target(req.URL, attempt)
+		default:
+			return nil
+		}
+	}
+	attemptTimeout := rt.attemptTimeout
+	// 경합 중인 낮은 우선순위 요청은 재시도를 양보. 요청 헤더로 지정한 값이 우선
+	rt.priority.apply(priorityFromContext(req.Context()), rt.lifecycle.inFlight(), &policy, &attemptTimeout)
+	overrides.apply(&policy, &attemptTimeout)
+	methodRetryable := rt.methodRetryable(req.Method)
+	noRetry := noRetryFromContext(req.Context()) || !replayable
+	// retryAllowed 재시도가 제한된 요청인지 확인. 서버가 처리하지 않은 요청은 메서드 제한과 관계없이 재시도
+	retryAllowed := func(err error) bool {
+		return !noRetry && (methodRetryable || isUnprocessedError(err))
+	}
+	// retryLimited 다음 시도가 남아 있지만 프로세스 전체 재시도 제한에 도달했는지 확인
+	retryLimited := func(attempt int) bool {
+		if attempt >= policy.maxRetries || allowGlobalRetry() && rt.sharedLimit.allowRetry() {
+			return false
+		}
+		allErrors = append(allErrors, ErrRetryLimited)
+		reason = ReasonRetryLimited
+		return true
+	}
+
+	// 시도 span은 요청 헤더의 추적 정보를 부모로 하고, 직전 시도의 span을 참조
+	var parentSpan, lastSpan SpanContext
+	if rt.tracing != nil {
+		parentSpan = rt.tracing.parent(req)
+	}
+
+	for attempt := 1; attempt <= policy.maxRetries+1; attempt++ {
+		// 전체 타임아웃 또는 부모 context가 이미 만료되었는지 확인
+		if loopCtx.Err() != nil {
+			if context.Cause(loopCtx) == errTotalTimeout {
+				allErrors = append(allErrors, errTotalTimeout)
+				reason = ReasonTimeout
+			} else {
+				// 부모 context의 취소 원인(context.Canceled, context.DeadlineExceeded 등)을 유지
+				allErrors = append(allErrors, fmt.Errorf("cancelled from parent context: %w", context.Cause(loopCtx)))
+				reason = ReasonContextCancelled
+			}
+			break
+		}
+
+		// 종료 중인 클라이언트는 새 시도를 시작하지 않음
+		if rt.lifecycle.closed() {
+			allErrors = append(allErrors, ErrClientClosed)
+			reason = ReasonClientClosed
 			break
 		}
 
 		// 최대 재시도 횟수를 초과하면 종료
-		if attempt > rt.maxRetries {
-			allErrors = multierr.Append(
-				allErrors,
-				errors.New("max retries reached"),
-			)
+		if attempt > policy.maxRetries {
+			allErrors = append(allErrors, errors.New("max retries reached"))
+			reason = ReasonBudgetExhausted
+			break
+		}
+
+		// 공유 제한의 시도 속도를 넘으면 자리가 날 때까지 대기. 대기 중 만료되면 다음 루프에서 종료
+		if err := rt.sharedLimit.waitAttempt(loopCtx); err != nil {
+			continue
+		}
+
+		// 서킷 브레이커는 시도에서 요청하는 호스트별로 적용
+		target := targetURL(attempt)
+		breaker := rt.breakers.get(req.URL.Host)
+		if target != nil {
+			breaker = rt.breakers.get(target.Host)
+		}
+
+		// 서킷 브레이커가 열려 있으면 요청하지 않고 종료
+		if breakerErr := breaker.allowMethod(cmp.Or(req.Method, http.MethodGet)); breakerErr != nil {
+			allErrors = append(allErrors, fmt.Errorf("attempt(%d): %w", attempt, breakerErr))
+			reason = ReasonCircuitOpen
 			break
 		}
 
-		// 타이머를 생성하여 요청 타임아웃 관리
-		timer := time.NewTimer(rt.requestTimeout)
-		done := make(chan struct{})
-		var (
-			response   *http.Response
-			respErr    error
-			statusCode int = -1 // 응답 실패시 -1
-		)
-
-		go func() {
-			// RoundTrip 호출
-			response, respErr = rt.RoundTripper.RoundTrip(req)
-			close(done)
-		}()
-
-		select {
-		case <-timer.C:
-			timeoutErr := fmt.Errorf("request timeout attempt(%d)", attempt)
+		// 시도마다 context를 생성하여 요청 타임아웃 관리
+		attemptCtx, cancel := context.WithCancelCause(loopCtx)
+		if route != nil && !onCanary {
+			attemptCtx = route.withEndpoint(attemptCtx)
+		}
+		timer := time.AfterFunc(attemptTimeout, func() { cancel(errAttemptTimeout) })
+		statusCode := -1 // 응답 실패시 -1
+
+		attemptReq := req.WithContext(attemptCtx)
+		if target != nil {
+			attemptReq.URL = target
+			attemptReq.Host = ""
+		}
+		if getBody != nil {
+			// 첫 시도는 원본 바디를 사용하고, 이후 시도는 GetBody로 바디를 다시 생성
+			if attempt > 1 || req.GetBody == nil {
+				body, bodyErr := getBody()
+				if bodyErr != nil {
+					breaker.release()
+					timer.Stop()
+					cancel(nil)
+					allErrors = append(allErrors, fmt.Errorf("rewinding request body: %w", bodyErr))
+					reason = ReasonNetworkError
+					break
+				}
+				attemptReq.Body = body
+			}
+			if replayable {
+				attemptReq.GetBody = getBody
+			}
+		}
+		if rt.beforeAttemptHook != nil {
+			if hookErr := rt.beforeAttempt(attempt, attemptReq); hookErr != nil {
+				breaker.release()
+				timer.Stop()
+				cancel(nil)
+				if attemptReq.Body != nil {
+					attemptReq.Body.Close()
+				}
+				allErrors = append(allErrors, hookErr)
+				reason = ReasonAborted
+				break
+			}
+		}
+
+		endSpan := func(RetryEvent) {}
+		if rt.tracing != nil {
+			attemptReq, lastSpan, endSpan = rt.tracing.start(attemptReq, parentSpan, lastSpan, attempt)
+		}
+		if rt.observing() {
+			rt.publish(attemptReq, requestEvent(EventAttemptStart, attemptReq, attempt))
+		}
+		restoreLabels := func() {}
+		if rt.pprofLabels {
+			attemptReq, restoreLabels = labelAttempt(req.Context(), attemptReq, attempt)
+		}
+		if attempts > 0 {
+			rt.prepareRetryConnection(attemptReq, lastErr, lastConn)
+		}
+		var usedConn *attemptConnection
+		if rt.connectionPolicy == RetryConnectionFresh {
+			attemptReq, usedConn = trackConnection(attemptReq)
+		}
+		attemptStart := time.Now()
+		var trace *attemptTrace
+		if rt.timings != nil {
+			attemptReq, trace = traceAttempt(attemptReq, attemptStart)
+		}
+		response, expectRejected, respErr := rt.roundTripExpect(attemptReq, getBody)
+		if expectRejected {
+			req = withoutExpect(req)
+		}
+		restoreLabels()
+		timings := trace.finish()
+		rt.timings.record(timings)
+		attempts++
+		lastErr = respErr
+		lastConn = usedConn.get()
+		// attemptError 시도 정보를 포함한 에러를 생성
+		attemptError := func(err error) *AttemptError {
+			return &AttemptError{
+				Attempt:    attempt,
+				Method:     attemptReq.Method,
+				URL:        redactURL(attemptReq.URL),
+				StatusCode: statusCode,
+				Start:      attemptStart,
+				Duration:   time.Since(attemptStart),
+				Timings:    timings,
+				Err:        err,
+			}
+		}
+
+		// 바디 검사가 필요한 경우, 타임아웃 판단 전에 바디 앞부분을 읽음
+		// 상태 코드로 재시도할 응답은 바디를 기다리지 않도록 읽지 않음
+		var peeked []byte
+		if respErr == nil && rt.bodyInspector != nil {
+			if _, retryStatus := policy.retryStatusCodes[response.StatusCode]; !retryStatus {
+				if peeked, respErr = peekBody(response, rt.bodyPeekLimit); respErr != nil {
+					response = nil
+				}
+			}
+		}
+
+		if rt.observing() || rt.tracing != nil {
+			event := requestEvent(EventAttemptFinish, attemptReq, attempt)
+			event.Duration = time.Since(attemptStart)
+			event.Timings = timings
+			event.Err = respErr
+			if context.Cause(attemptCtx) == errAttemptTimeout {
+				event.Err = errAttemptTimeout
+			} else if response != nil {
+				event.StatusCode = response.StatusCode
+			}
+			rt.publish(attemptReq, event)
+			endSpan(event)
+		}
+
+		if context.Cause(attemptCtx) == errAttemptTimeout {
+			breaker.Record(false)
+			closeBody(response)
+			timeoutErr := attemptError(errAttemptTimeout)
 			rt.debugLog(attempt, statusCode, timeoutErr)
-			allErrors = multierr.Append(allErrors, timeoutErr)
-		case <-done:
-			timer.Stop() // 타이머 멈춤
-			if response != nil {
-				statusCode = response.StatusCode
-			}
-			shouldRetry, retryErr := rt.shouldRetry(statusCode, respErr)
-			if shouldRetry {
-				allErrors = multierr.Append(
-					allErrors,
-					errors.Wrapf(retryErr, "attempt(%d)", attempt),
-				)
-				rt.debugLog(attempt, statusCode, retryErr)
-				time.Sleep(rt.backoffPolicy(attempt))
+			allErrors = append(allErrors, timeoutErr)
+			if !retryAllowed(nil) {
+				reason = ReasonTimeout
+				break
+			}
+			if retryLimited(attempt) {
+				break
+			}
+			if attempt < policy.maxRetries {
+				rt.emitRetry(attemptReq, timeoutErr, 0)
+			}
+			if onCanary {
+				onCanary = false
+			}
+			continue
+		}
+
+		// 전체 타임아웃 또는 부모 context 취소로 실패한 경우, 다음 루프에서 종료
+		if respErr != nil && loopCtx.Err() != nil {
+			breaker.release()
+			cancel(nil)
+			allErrors = append(allErrors, attemptError(respErr))
+			continue
+		}
+
+		if response != nil {
+			statusCode = response.StatusCode
+		}
+		shouldRetry, retryErr := rt.shouldRetry(policy, statusCode, respErr)
+		if !shouldRetry && response != nil && rt.bodyInspector != nil &&
+			rt.bodyInspector(response, peeked) {
+			shouldRetry, retryErr = true, errRetryByBody
+		}
+		// 체크섬이 다른 응답은 전송 중 손상된 것으로 보고 재시도
+		if !shouldRetry && response != nil {
+			if expected := rt.expectedChecksumFor(req, response); expected != nil {
+				if verifyErr := expected.verify(response); verifyErr != nil {
+					shouldRetry, retryErr = true, verifyErr
+				}
+			}
+		}
+		// 재시도가 제한된 요청은 첫 시도 결과를 그대로 반환
+		failed := false
+		if shouldRetry && !retryAllowed(respErr) {
+			shouldRetry, failed = false, true
+		}
+		if onCanary && (shouldRetry || respErr != nil) && retryAllowed(respErr) {
+			// canary 실패는 백오프 없이 stable 엔드포인트로 재시도
+			breaker.Record(false)
+			timer.Stop()
+			captured := rt.captureResponse(response)
+			cancel(nil)
+			closeBody(response)
+			if retryErr == nil {
+				retryErr = respErr
+			}
+			attemptErr := attemptError(fmt.Errorf("canary: %w", retryErr))
+			attemptErr.Response = captured
+			allErrors = append(allErrors, attemptErr)
+			rt.debugLog(attempt, statusCode, retryErr)
+			if retryLimited(attempt) {
+				break
+			}
+			if attempt < policy.maxRetries {
+				rt.emitRetry(attemptReq, attemptErr, 0)
+			}
+			onCanary = false
+			continue
+		}
+		if !shouldRetry && respErr != nil {
+			// 재시도해도 결과가 같은 에러는 즉시 반환
+			breaker.release()
+			timer.Stop()
+			cancel(nil)
+			allErrors = append(allErrors, attemptError(respErr))
+			reason = ReasonNetworkError
+			break
+		}
+		if shouldRetry {
+			breaker.Record(false)
+			timer.Stop()
+			// 바디는 시도 context를 취소하기 전에 읽음
+			captured := rt.captureResponse(response)
+			cancel(nil)
+			delay, retryAfter, delayErr := rt.retryDelay(policy, attempt, response)
+			closeBody(response)
+			// 마지막 시도 이후에는 대기하지 않음
+			lastAttempt := attempt >= policy.maxRetries
+			var budgetErr error
+			if delayErr == nil && !lastAttempt {
+				delay, budgetErr = rt.fitDeadline(deadline, delay, attemptTimeout)
+			}
+			attemptErr := attemptError(retryErr)
+			attemptErr.RetryAfter = retryAfter
+			attemptErr.Response = captured
+			if delayErr == nil && budgetErr == nil && !lastAttempt {
+				attemptErr.Backoff = delay
+			}
+			allErrors = append(allErrors, attemptErr)
+			rt.debugLog(attempt, statusCode, retryErr)
+			if delayErr != nil {
+				// 대기 시간이 너무 긴 Retry-After는 기다리지 않고 실패
+				allErrors = append(allErrors, delayErr)
+				reason = ReasonRetryableStatus
+				break
+			}
+			if lastAttempt {
 				continue
 			}
-			return response, nil
+			if budgetErr != nil {
+				// 남은 기한 안에 재시도할 수 없으면 대기하지 않고 실패
+				allErrors = append(allErrors, budgetErr)
+				reason = ReasonTimeout
+				break
+			}
+			if retryLimited(attempt) {
+				break
+			}
+			rt.emitRetry(attemptReq, attemptErr, delay)
+			sleep(loopCtx, rt.lifecycle.closing, delay)
+			continue
+		}
+
+		breaker.Record(!failed)
+
+		// 스트리밍 모드에서는 헤더 수신 시점에 성공으로 보고, 바디 소비는 타임아웃에서 제외
+		if rt.streamingMode {
+			timer.Stop()
+			stopTotal()
+		}
+		// 호출자는 시도별로 복제된 요청이 아닌 원본 요청을 응답에서 확인
+		response.Request = origReq
+		rtt := time.Since(attemptStart)
+		response.Body = &attemptBody{
+			ReadCloser: response.Body,
+			ctx:        attemptCtx,
+			release: sync.OnceFunc(func() {
+				timer.Stop()
+				cancel(nil)
+				stopTotal()
+				cancelLoop(nil)
+				if limit != nil {
+					limit.succeed(rtt)
+				}
+				releaseBulkhead()
+				rt.lifecycle.release()
+			}),
 		}
+		return response, nil
+	}
+	stopTotal()
+	cancelLoop(nil)
+	if limit != nil {
+		// 호출자의 취소나 클라이언트 종료는 과부하가 아니므로 제한을 줄이지 않음
+		if reason == ReasonContextCancelled || reason == ReasonClientClosed {
+			limit.release()
+		} else {
+			limit.fail()
+		}
+	}
+	releaseBulkhead()
+	rt.lifecycle.release()
+	retryErr := newRetryError(reason, attempts, allErrors, rt.errorAggregation)
+	// 재시도 포기는 샘플링하지 않고 항상 출력
+	rt.logger.Debug(rt.locale.messages().givingUp, "reason", reason, "attempts", attempts, "error", retryErr)
+	if rt.observing() {
+		event := requestEvent(EventGiveUp, req, attempts)
+		event.Reason = reason
+		event.Err = retryErr
+		rt.publish(req, event)
 	}
-	return nil, allErrors
+	return nil, retryErr
+}
+
+// emitRetry 재시도 예정 이벤트를 발행
+func (rt *retriableTransport) emitRetry(attemptReq *http.Request, attemptErr *AttemptError, backoff time.Duration) {
+	if !rt.observing() {
+		return
+	}
+	event := requestEvent(EventRetryScheduled, attemptReq, attemptErr.Attempt)
+	event.StatusCode = attemptErr.StatusCode
+	event.Backoff = backoff
+	event.Err = attemptErr
+	rt.publish(attemptReq, event)
+}
 
+// attemptBody 응답 바디 소비가 끝나면 시도 context를 정리하는 바디
+type attemptBody struct {
+	io.ReadCloser
+	ctx     context.Context
+	release func()
+}
+
+// Read 바디를 읽으며, EOF 도달 시 시도 context를 정리
+func (b *attemptBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	switch {
+	case err == io.EOF:
+		b.release()
+	case err != nil && context.Cause(b.ctx) == errAttemptTimeout:
+		err = fmt.Errorf("reading response body: %w", errAttemptTimeout)
+	case err != nil && context.Cause(b.ctx) == errTotalTimeout:
+		err = fmt.Errorf("reading response body: %w", errTotalTimeout)
+	}
+	return n, err
+}
+
+// Close 바디를 닫고 시도 context를 정리
+func (b *attemptBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+// sleep 백오프 시간 동안 대기하며, context가 취소되거나 interrupt가 닫히면 즉시 반환
+func sleep(ctx context.Context, interrupt <-chan struct{}, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-interrupt:
+	case <-timer.C:
+	}
+}
+
+// closeBody 재시도 전 커넥션을 재사용할 수 있도록 응답 바디를 닫음
+func closeBody(response *http.Response) {
+	if response != nil && response.Body != nil {
+		response.Body.Close()
+	}
 }
 
 // shouldRetry 재시도 여부를 판단
-func (rt *retriableTransport) shouldRetry(statusCode int, err error) (bool, error) {
+func (rt *retriableTransport) shouldRetry(policy retryPolicy, statusCode int, err error) (bool, error) {
 	if err != nil {
-		return true, err
+		// 사용자가 지정한 에러는 다른 분류보다 먼저 판단하며, 재시도 금지가 우선
+		if matchesAny(err, rt.noRetryOnErrors) {
+			return false, err
+		}
+		if matchesAny(err, rt.retryOnErrors) {
+			return true, err
+		}
+		// 서버가 처리하지 않은 요청은 분류기와 관계없이 재시도
+		if isUnprocessedError(err) {
+			return true, err
+		}
+		// 인증서 검증 실패와 서버가 보낸 alert는 재시도하지 않고, 그 외 TLS 에러는 설정에 따라 재시도
+		if isCertificateError(err) || isTLSAlertError(err) {
+			return false, err
+		}
+		if isTLSError(err) {
+			return rt.retryTLSErrors, err
+		}
+		return rt.errorClassifier(err), err
 	}
 
-	if reason, shouldRetry := rt.retryStatusCodes[statusCode]; shouldRetry {
+	if reason, shouldRetry := policy.retryStatusCodes[statusCode]; shouldRetry {
 		return shouldRetry, errors.New(reason)
 	}
 
 	return false, nil
 }
 
+// methodRetryable 요청 메서드의 재시도 가능 여부를 판단
+//
+// 재시도 금지 메서드가 우선하며, 재시도 허용 메서드가 지정된 경우 해당 메서드만 재시도합니다.
+func (rt *retriableTransport) methodRetryable(method string) bool {
+	if method == "" {
+		method = http.MethodGet
+	}
+	if _, denied := rt.noRetryMethods[method]; denied {
+		return false
+	}
+	if rt.retryMethods == nil {
+		return true
+	}
+	_, allowed := rt.retryMethods[method]
+	return allowed
+}
+
+// methodSet 메서드 목록을 조회용 set으로 변환. 목록이 비어 있으면 nil을 반환
+func methodSet(methods []string) map[string]struct{} {
+	if len(methods) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(methods))
+	for _, method := range methods {
+		set[strings.ToUpper(method)] = struct{}{}
+	}
+	return set
+}
+
 // debugLog 디버그 메시지를 출력
+//
+// DebugSampleRate가 1보다 크면 재시도 N번 중 1번만 출력합니다.
 func (rt *retriableTransport) debugLog(attempt int, statusCode int, err error) {
-	if rt.debugMode {
-		log.Printf(
-			"retrying request. Attempt: %d, StatusCode: %d, Error: %v\n",
-			attempt,
-			statusCode,
-			err.Error(),
-		)
+	if rt.debugSampleRate <= 1 {
+		rt.logger.Debug(rt.locale.messages().retrying, "attempt", attempt, "status_code", statusCode, "error", err)
+		return
 	}
+	if (rt.debugSampled.Add(1)-1)%uint64(rt.debugSampleRate) != 0 {
+		return
+	}
+	rt.logger.Debug(rt.locale.messages().retrying,
+		"attempt", attempt, "status_code", statusCode, "error", err, "sample_rate", rt.debugSampleRate)
 }
 
-// extendDefault는 기본 재시도 상태 코드 맵을 확장
-func extendDefault(additional []int) map[int]string {
+// extendDefault는 기본 재시도 상태 코드 맵을 확장하고 excluded 상태 코드를 제외. 기본 상태 코드의 재시도 사유는 locale 언어를 사용
+func extendDefault(additional, excluded []int, locale Locale) map[int]string {
 	retryMap := make(map[int]string)
-	for code, msg := range defaultRetryStatusMap {
+	for code, msg := range locale.messages().statusReasons {
 		retryMap[code] = msg
 	}
 	for _, code := range additional {
@@ -182,5 +904,8 @@ func extendDefault(additional []int) map[int]string {
 			retryMap[code] = http.StatusText(code)
 		}
 	}
+	for _, code := range excluded {
+		delete(retryMap, code)
+	}
 	return retryMap
 }
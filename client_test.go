@@ -4,7 +4,9 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"net/http/cookiejar"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -244,3 +246,253 @@ func TestRetriableTransport_ParentContextCancel(t *testing.T) {
 		assert.NoError(t, readErr, "응답 body를 읽는데 에러가 발생하지 않아야 합니다.")
 	})
 }
+
+func TestRetriableTransport_StreamingMode(t *testing.T) {
+	newServer := func() *httptest.Server {
+		return httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("first chunk,"))
+				w.(http.Flusher).Flush()
+				time.Sleep(500 * time.Millisecond) // 바디 전송 지연
+				w.Write([]byte("second chunk"))
+			}),
+		)
+	}
+
+	t.Run("스트리밍 모드에서는 바디 수신 시간이 timeout에 포함되지 않는 테스트", func(t *testing.T) {
+		// given
+		testServer := newServer()
+		defer testServer.Close()
+
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithRequestTimeout(200*time.Millisecond),
+				httpretry.WithMaxRetry(1),
+				httpretry.WithStreamingMode(true),
+			),
+		)
+
+		// when
+		resp, err := retryClient.Get(testServer.URL)
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		body, readErr := io.ReadAll(resp.Body)
+		assert.NoError(t, readErr, "바디를 끝까지 읽을 수 있어야 합니다.")
+		assert.Equal(t, "first chunk,second chunk", string(body))
+	})
+
+	t.Run("기본 모드에서는 바디 수신 시간이 timeout에 포함되는 테스트", func(t *testing.T) {
+		// given
+		testServer := newServer()
+		defer testServer.Close()
+
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithRequestTimeout(200*time.Millisecond),
+				httpretry.WithMaxRetry(1),
+			),
+		)
+
+		// when
+		resp, err := retryClient.Get(testServer.URL)
+
+		// then
+		assert.NoError(t, err, "헤더는 timeout 이전에 수신되어야 합니다.")
+		defer resp.Body.Close()
+		_, readErr := io.ReadAll(resp.Body)
+		assert.ErrorContains(t, readErr, "request timeout")
+	})
+}
+
+func TestRetriableTransport_Timeouts(t *testing.T) {
+	t.Run("attempt timeout 초과 시, 재시도 테스트", func(t *testing.T) {
+		// given
+		var reqCount atomic.Int32
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if reqCount.Add(1) == 1 {
+					time.Sleep(300 * time.Millisecond) // 첫 시도만 응답 지연
+				}
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+		defer testServer.Close()
+
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithAttemptTimeout(100*time.Millisecond),
+				httpretry.WithMaxRetry(3),
+			),
+		)
+
+		// when
+		resp, err := retryClient.Get(testServer.URL)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, int32(2), reqCount.Load())
+	})
+
+	t.Run("total timeout 안에 재시도할 수 없으면, 백오프 대기 없이 재시도 중단 테스트", func(t *testing.T) {
+		// given
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}),
+		)
+		defer testServer.Close()
+
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithAttemptTimeout(1*time.Second),
+				httpretry.WithTotalTimeout(300*time.Millisecond),
+				httpretry.WithMaxRetry(5),
+				httpretry.WithBackoffPolicy(func(attempt int) time.Duration {
+					return 200 * time.Millisecond
+				}),
+			),
+		)
+
+		// when
+		start := time.Now()
+		resp, err := retryClient.Get(testServer.URL)
+
+		// then
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, httpretry.ErrInsufficientRetryBudget)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 300*time.Millisecond, "total timeout을 넘는 백오프는 대기하지 않아야 합니다.")
+	})
+}
+
+func TestNewTransport(t *testing.T) {
+	t.Run("재시도 Transport를 다른 Transport로 감싸 조합하는 테스트", func(t *testing.T) {
+		// given
+		reqCount := 0
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reqCount++
+				if r.Header.Get("Authorization") != "Bearer token" || reqCount < 2 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+		defer testServer.Close()
+
+		retry := httpretry.NewTransport(
+			httpretry.NewHTTPSettings(httpretry.WithBackoffPolicy(noBackoff)),
+		)
+		outerCount := 0
+		client := &http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				outerCount++
+				req = req.Clone(req.Context())
+				req.Header.Set("Authorization", "Bearer token")
+				return retry.RoundTrip(req)
+			}),
+		}
+
+		// when
+		resp, err := client.Get(testServer.URL)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 2, reqCount)
+		assert.Equal(t, 1, outerCount, "바깥 Transport는 요청마다 한 번 호출되어야 합니다.")
+	})
+}
+
+func TestNewClientFrom(t *testing.T) {
+	t.Run("기존 클라이언트의 Jar, CheckRedirect, Transport를 유지하며 재시도하는 테스트", func(t *testing.T) {
+		// given
+		reqCount := 0
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reqCount++
+				if reqCount < 2 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+		defer testServer.Close()
+
+		jar, _ := cookiejar.New(nil)
+		checkRedirect := func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+		baseCount := 0
+		existing := &http.Client{
+			Jar:           jar,
+			CheckRedirect: checkRedirect,
+			Transport:     countingMiddleware(&baseCount)(http.DefaultTransport),
+		}
+
+		// when
+		client := httpretry.NewClientFrom(
+			existing,
+			httpretry.NewHTTPSettings(httpretry.WithBackoffPolicy(noBackoff)),
+		)
+		resp, err := client.Get(testServer.URL)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Same(t, jar, client.Jar)
+		assert.NotNil(t, client.CheckRedirect)
+		assert.Equal(t, 2, baseCount, "기존 Transport로 시도마다 요청해야 합니다.")
+		assert.NotSame(t, existing, client)
+	})
+}
+
+func TestDefaultRetryStatusCodes(t *testing.T) {
+	t.Run("408, 425, 429 응답을 기본으로 재시도하고 Retry-After를 따르는 테스트", func(t *testing.T) {
+		for _, status := range []int{http.StatusRequestTimeout, http.StatusTooEarly, http.StatusTooManyRequests} {
+			// given
+			var count int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				count++
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(status)
+			}))
+			client := httpretry.NewClient(httpretry.NewHTTPSettings(httpretry.WithMaxRetry(2)))
+
+			// when
+			_, err := client.Get(server.URL)
+			server.Close()
+
+			// then
+			assert.Error(t, err, status)
+			assert.Equal(t, 2, count, status)
+		}
+	})
+
+	t.Run("WithoutStatusCodes로 제외한 상태 코드는 재시도하지 않는 테스트", func(t *testing.T) {
+		// given
+		var count int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			count++
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(3),
+			httpretry.WithoutStatusCodes(http.StatusTooManyRequests),
+		), http.StatusTooManyRequests)
+
+		// when
+		resp, err := client.Get(server.URL)
+
+		// then
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, 1, count, "제외한 상태 코드는 추가한 재시도 상태 코드보다 우선합니다.")
+	})
+}
@@ -0,0 +1,46 @@
+package httpretry
+
+import (
+	"errors"
+	"net/http"
+)
+
+// ErrNotRetryClient httpretry로 생성하지 않은 클라이언트임을 나타내는 에러
+var ErrNotRetryClient = errors.New("client transport is not created by httpretry")
+
+// errConnectionSettings 커넥션 풀을 공유하므로 커넥션 설정을 변경할 수 없음을 나타내는 에러
+var errConnectionSettings = errors.New(
+	"connection settings (Insecure, TLSConfig, MaxIdleConns, *Timeout of transport, Disable*, buffer sizes, TransportTweaks) cannot be changed while sharing the connection pool",
+)
+
+// CloneWith 클라이언트의 설정을 복사하고 일부 Option만 변경한 클라이언트를 생성
+//
+// 복제한 클라이언트는 원본과 커넥션 풀을 공유하므로, 같은 호스트를 서로 다른 타임아웃/재시도 정책으로 호출할 때 사용합니다.
+// 커넥션 풀에 적용되는 설정(Insecure, TLSConfig, MaxIdleConns, IdleConnTimeout, TLSHandshakeTimeout,
+// ExpectContinueTimeout, ResponseHeaderTimeout, DisableKeepAlives, DisableCompression, ReadBufferSize, WriteBufferSize,
+// MaxResponseHeaderBytes, TransportTweaks)은 변경할 수 없으며, 변경 시 에러를 반환합니다.
+// 서킷 브레이커 상태는 공유하지 않습니다.
+//
+//	slow, err := httpretry.CloneWith(client, httpretry.WithAttemptTimeout(time.Minute))
+//
+// Parameters:
+//   - client: (*http.Client) NewClient로 생성한 클라이언트
+//   - opts: (...HTTPOption) 변경할 Option
+func CloneWith(client *http.Client, opts ...HTTPOption) (*http.Client, error) {
+	rt, ok := client.Transport.(*retriableTransport)
+	if !ok {
+		return nil, ErrNotRetryClient
+	}
+
+	settings := rt.settings.clone()
+	for _, opt := range opts {
+		opt(settings)
+	}
+	if !settings.sameConnection(rt.settings) {
+		return nil, errConnectionSettings
+	}
+
+	cloned := *client
+	cloned.Transport = newRetriableTransportWith(rt.transport, settings, rt.statusCodes...)
+	return &cloned, nil
+}
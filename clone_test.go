@@ -0,0 +1,119 @@
+package httpretry_test
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloneWith(t *testing.T) {
+	t.Run("변경한 Option만 적용하고 커넥션 풀을 공유하는 테스트", func(t *testing.T) {
+		// given
+		var connCount atomic.Int32
+		testServer := httptest.NewUnstartedServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/slow" {
+					time.Sleep(100 * time.Millisecond)
+				}
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+		testServer.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+			if state == http.StateNew {
+				connCount.Add(1)
+			}
+		}
+		testServer.Start()
+		defer testServer.Close()
+
+		fastClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(1),
+				httpretry.WithAttemptTimeout(50*time.Millisecond),
+			),
+		)
+
+		// when
+		slowClient, err := httpretry.CloneWith(fastClient, httpretry.WithAttemptTimeout(time.Second))
+		resp, fastErr := fastClient.Get(testServer.URL)
+		if fastErr == nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		slowResp, slowErr := slowClient.Get(testServer.URL + "/slow")
+		if slowErr == nil {
+			io.Copy(io.Discard, slowResp.Body)
+			slowResp.Body.Close()
+		}
+		sharedConns := connCount.Load()
+		_, timeoutErr := fastClient.Get(testServer.URL + "/slow")
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, fastErr)
+		assert.NoError(t, slowErr)
+		assert.Error(t, timeoutErr, "원본 클라이언트의 설정은 변경되지 않아야 합니다.")
+		assert.Equal(t, int32(1), sharedConns, "복제한 클라이언트는 원본의 커넥션을 재사용해야 합니다.")
+	})
+
+	t.Run("커넥션 설정을 변경하거나 httpretry 클라이언트가 아닌 경우, 에러 반환 테스트", func(t *testing.T) {
+		// given
+		client := httpretry.NewClient(httpretry.NewHTTPSettings())
+
+		// when
+		_, connErr := httpretry.CloneWith(client, httpretry.WithMaxIdleConns(100))
+		_, plainErr := httpretry.CloneWith(http.DefaultClient)
+
+		// then
+		assert.Error(t, connErr)
+		assert.ErrorIs(t, plainErr, httpretry.ErrNotRetryClient)
+	})
+}
+
+func TestSettings_With(t *testing.T) {
+	t.Run("원본 설정을 변경하지 않고 Option을 적용한 설정을 파생하는 테스트", func(t *testing.T) {
+		// given
+		base := httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(3),
+			httpretry.WithHostPolicy("api.example.com", httpretry.Policy{MaxRetry: 2, RetryStatusCodes: []int{503}}),
+			httpretry.WithLoadShedding(httpretry.LoadShedding{Threshold: time.Second}),
+		)
+
+		// when
+		derived := base.With(httpretry.WithMaxRetry(10))
+		derived.HostPolicies["api.example.com"].RetryStatusCodes[0] = 502
+		derived.LoadShedding.Threshold = time.Minute
+
+		// then
+		assert.Equal(t, 10, derived.MaxRetry)
+		assert.Equal(t, 3, base.MaxRetry)
+		assert.Equal(t, []int{503}, base.HostPolicies["api.example.com"].RetryStatusCodes)
+		assert.Equal(t, time.Second, base.LoadShedding.Threshold)
+	})
+
+	t.Run("클라이언트 생성 이후 설정을 변경해도 클라이언트에 적용되지 않는 테스트", func(t *testing.T) {
+		// given
+		var received atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		settings := httpretry.NewHTTPSettings(httpretry.WithMaxRetry(2), httpretry.WithBackoffPolicy(noBackoff))
+		client := httpretry.NewClient(settings)
+
+		// when
+		settings.MaxRetry = 5
+		client.Get(server.URL)
+
+		// then
+		assert.Equal(t, int32(2), received.Load())
+	})
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dings-things/httpretry"
+)
+
+type (
+	// loadOptions load 명령의 부하 설정 플래그
+	loadOptions struct {
+		workers  int
+		duration time.Duration
+	}
+
+	// loadStats 부하 중 요청과 시도의 통계
+	//
+	// 시도와 재시도는 Observer로, 요청 결과는 worker에서 기록합니다.
+	loadStats struct {
+		attempts atomic.Int64
+		retries  atomic.Int64
+
+		mu        sync.Mutex
+		latencies []time.Duration
+		failures  int
+		statuses  map[int]int
+		reasons   map[httpretry.Reason]int
+	}
+)
+
+// runLoad 여러 worker로 일정 시간 동안 요청을 반복하고 통계를 출력
+func runLoad(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	opts := requestOptions{headers: headerFlags{}}
+	var load loadOptions
+	flags := flag.NewFlagSet("httpretry load", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	opts.register(flags)
+	flags.IntVar(&load.workers, "workers", 4, "number of concurrent workers")
+	flags.DurationVar(&load.duration, "duration", 10*time.Second, "how long to generate load")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: httpretry load [flags] URL")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return exitUsage
+	}
+	if load.workers < 1 || load.duration <= 0 {
+		fmt.Fprintln(stderr, "httpretry: -workers and -duration must be positive")
+		return exitUsage
+	}
+
+	stats := &loadStats{statuses: make(map[int]int), reasons: make(map[httpretry.Reason]int)}
+	settings, err := opts.settings(stderr, httpretry.WithObserver(stats))
+	if err != nil {
+		fmt.Fprintln(stderr, "httpretry:", err)
+		return exitUsage
+	}
+	statusCodes, err := parseStatusCodes(opts.statusCodes)
+	if err != nil {
+		fmt.Fprintln(stderr, "httpretry:", err)
+		return exitUsage
+	}
+	body, err := opts.body(stdin)
+	if err != nil {
+		fmt.Fprintln(stderr, "httpretry:", err)
+		return exitUsage
+	}
+	if _, err := opts.request(flags.Arg(0), body); err != nil {
+		fmt.Fprintln(stderr, "httpretry:", err)
+		return exitUsage
+	}
+
+	// 부하 시간이 끝나면 새 요청을 시작하지 않고, 진행 중인 요청은 재시도까지 마치도록 기다림
+	client := httpretry.NewClient(settings, statusCodes...)
+	start := time.Now()
+	deadline := start.Add(load.duration)
+	var wg sync.WaitGroup
+	for range load.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for time.Now().Before(deadline) {
+				req, _ := opts.request(flags.Arg(0), body)
+				stats.send(client, req)
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats.report(stdout, time.Since(start))
+	return exitOK
+}
+
+// send 요청을 보내고 결과를 기록
+func (s *loadStats) send(client *http.Client, req *http.Request) {
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err == nil {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+	latency := time.Since(start)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latencies = append(s.latencies, latency)
+	if err != nil {
+		s.failures++
+		var retryErr *httpretry.RetryError
+		if errors.As(err, &retryErr) {
+			s.reasons[retryErr.Reason]++
+		}
+		return
+	}
+	s.statuses[resp.StatusCode]++
+}
+
+// Observe 시도와 재시도 수를 기록
+func (s *loadStats) Observe(_ *http.Request, event httpretry.RetryEvent) {
+	switch event.Type {
+	case httpretry.EventAttemptStart:
+		s.attempts.Add(1)
+	case httpretry.EventRetryScheduled:
+		s.retries.Add(1)
+	}
+}
+
+// report 요청 수, 시도 수, 재시도 비율, 지연 시간 분포, 상태 코드와 포기 이유별 수를 출력
+func (s *loadStats) report(out io.Writer, elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requests := len(s.latencies)
+	attempts, retries := s.attempts.Load(), s.retries.Load()
+	fmt.Fprintf(out, "requests:  %d (%d ok, %d failed) in %s, %.1f req/s\n",
+		requests, requests-s.failures, s.failures, elapsed.Round(time.Millisecond), float64(requests)/elapsed.Seconds())
+	fmt.Fprintf(out, "attempts:  %d (%.2f per request)\n", attempts, ratio(attempts, int64(requests)))
+	fmt.Fprintf(out, "retries:   %d (retry rate %.1f%%)\n", retries, 100*ratio(retries, attempts))
+	if requests > 0 {
+		latencies := slices.Clone(s.latencies)
+		slices.Sort(latencies)
+		fmt.Fprintf(out, "latency:   p50 %s  p90 %s  p99 %s  max %s\n",
+			percentile(latencies, 0.50), percentile(latencies, 0.90), percentile(latencies, 0.99), latencies[len(latencies)-1].Round(time.Millisecond))
+	}
+	if len(s.statuses) > 0 {
+		fmt.Fprintf(out, "status:    %s\n", countList(s.statuses))
+	}
+	if len(s.reasons) > 0 {
+		fmt.Fprintf(out, "gave up:   %s\n", countList(s.reasons))
+	}
+}
+
+// ratio 0으로 나누지 않는 비율
+func ratio(n, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
+
+// percentile 정렬된 지연 시간의 백분위수
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	return sorted[min(int(float64(len(sorted))*p), len(sorted)-1)].Round(time.Millisecond)
+}
+
+// countList "key=count" 목록을 key 순서로 반환
+func countList[K int | httpretry.Reason](counts map[K]int) string {
+	var fields []string
+	for _, key := range slices.Sorted(maps.Keys(counts)) {
+		fields = append(fields, fmt.Sprintf("%v=%d", key, counts[key]))
+	}
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunLoad(t *testing.T) {
+	t.Run("일정 시간 동안 요청을 반복하고 시도, 재시도, 지연 시간 통계를 출력하는 테스트", func(t *testing.T) {
+		// given
+		var received atomic.Int64
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// 요청마다 첫 시도는 실패하도록 홀수 번째 요청에 503을 응답
+			if received.Add(1)%2 == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		var stdout, stderr bytes.Buffer
+
+		// when
+		code := run([]string{"load", "-workers", "1", "-duration", "100ms", "-backoff", "1ms", server.URL}, nil, &stdout, &stderr)
+
+		// then
+		assert.Equal(t, exitOK, code, stderr.String())
+		report := stdout.String()
+		requests := regexp.MustCompile(`requests:\s+(\d+) \((\d+) ok, 0 failed\)`).FindStringSubmatch(report)
+		assert.Len(t, requests, 3, report)
+		assert.Equal(t, requests[1], requests[2])
+		assert.Contains(t, report, "retry rate 50.0%")
+		assert.Contains(t, report, "attempts:  ")
+		assert.Contains(t, report, "latency:   p50 ")
+		assert.Contains(t, report, "status:    200="+requests[1])
+		n, _ := strconv.Atoi(requests[1])
+		assert.Positive(t, n)
+	})
+
+	t.Run("잘못된 부하 설정은 사용법 종료 코드를 반환하는 테스트", func(t *testing.T) {
+		// given
+		var stdout, stderr bytes.Buffer
+
+		// when
+		code := run([]string{"load", "-workers", "0", "http://localhost"}, nil, &stdout, &stderr)
+
+		// then
+		assert.Equal(t, exitUsage, code)
+	})
+}
@@ -0,0 +1,265 @@
+// Command httpretry httpretry 클라이언트로 요청을 보내는 CLI
+//
+// 실제 엔드포인트를 대상으로 재시도, 백오프 설정을 Go 코드 없이 재현하고 조정할 때 사용합니다.
+// 응답 바디는 표준 출력으로, -v 옵션의 시도별 정보는 표준 에러로 출력합니다.
+//
+//	httpretry -X POST -H "Content-Type: application/json" -d @body.json -retries 5 -backoff 200ms -v https://api.example.com/items
+//
+// load 명령은 여러 worker로 일정 시간 동안 요청을 반복하고 시도 수, 지연 시간, 재시도 비율을 출력합니다.
+//
+//	httpretry load -workers 16 -duration 30s -retries 3 -backoff 100ms https://staging.example.com/health
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dings-things/httpretry"
+)
+
+// 종료 코드
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+type (
+	// headerFlags 반복해서 지정하는 "Key: Value" 형식의 요청 헤더
+	headerFlags http.Header
+
+	// requestOptions 요청과 재시도 설정 플래그
+	requestOptions struct {
+		method         string
+		headers        headerFlags
+		data           string
+		retries        int
+		backoff        time.Duration
+		maxBackoff     time.Duration
+		attemptTimeout time.Duration
+		totalTimeout   time.Duration
+		statusCodes    string
+		insecure       bool
+		verbose        bool
+	}
+
+	// attemptPrinter 시도별 이벤트를 출력하는 Observer
+	attemptPrinter struct {
+		out io.Writer
+	}
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run 인자를 해석하여 요청을 보내고 종료 코드를 반환
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) > 0 && args[0] == "load" {
+		return runLoad(args[1:], stdin, stdout, stderr)
+	}
+	opts := requestOptions{headers: headerFlags{}}
+	flags := flag.NewFlagSet("httpretry", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	opts.register(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: httpretry [flags] URL")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return exitUsage
+	}
+
+	settings, err := opts.settings(stderr)
+	if err != nil {
+		fmt.Fprintln(stderr, "httpretry:", err)
+		return exitUsage
+	}
+	statusCodes, err := parseStatusCodes(opts.statusCodes)
+	if err != nil {
+		fmt.Fprintln(stderr, "httpretry:", err)
+		return exitUsage
+	}
+	body, err := opts.body(stdin)
+	if err != nil {
+		fmt.Fprintln(stderr, "httpretry:", err)
+		return exitUsage
+	}
+	req, err := opts.request(flags.Arg(0), body)
+	if err != nil {
+		fmt.Fprintln(stderr, "httpretry:", err)
+		return exitUsage
+	}
+
+	client := httpretry.NewClient(settings, statusCodes...)
+	resp, err := client.Do(req)
+	if err != nil {
+		fmt.Fprintln(stderr, "httpretry:", err)
+		return exitFailure
+	}
+	defer resp.Body.Close()
+
+	if opts.verbose {
+		fmt.Fprintf(stderr, "< %s %s\n", resp.Proto, resp.Status)
+		for key, values := range resp.Header {
+			for _, value := range values {
+				fmt.Fprintf(stderr, "< %s: %s\n", key, value)
+			}
+		}
+	}
+	if _, err := io.Copy(stdout, resp.Body); err != nil {
+		fmt.Fprintln(stderr, "httpretry: reading response body:", err)
+		return exitFailure
+	}
+	return exitOK
+}
+
+// register 요청과 재시도 플래그를 등록
+func (o *requestOptions) register(flags *flag.FlagSet) {
+	flags.StringVar(&o.method, "X", "", "request method (default GET, or POST with -d)")
+	flags.Var(o.headers, "H", `request header "Key: Value" (repeatable)`)
+	flags.StringVar(&o.data, "d", "", "request body. @file reads a file, @- reads stdin")
+	flags.IntVar(&o.retries, "retries", 3, "maximum number of attempts")
+	flags.DurationVar(&o.backoff, "backoff", time.Second, "backoff before the first retry, doubled on every retry")
+	flags.DurationVar(&o.maxBackoff, "max-backoff", 30*time.Second, "maximum backoff between attempts")
+	flags.DurationVar(&o.attemptTimeout, "attempt-timeout", 10*time.Second, "timeout of a single attempt")
+	flags.DurationVar(&o.totalTimeout, "timeout", 0, "timeout of all attempts and backoffs (0 for none)")
+	flags.StringVar(&o.statusCodes, "status", "", "additional retryable status codes, comma separated")
+	flags.BoolVar(&o.insecure, "insecure", false, "skip TLS certificate verification")
+	flags.BoolVar(&o.verbose, "v", false, "print every attempt and the response headers to stderr")
+}
+
+// settings 플래그와 추가 Option으로 재시도 설정을 생성
+func (o *requestOptions) settings(stderr io.Writer, extra ...httpretry.HTTPOption) (*httpretry.Settings, error) {
+	if o.retries < 1 {
+		return nil, errors.New("-retries must be at least 1")
+	}
+	backoff, maxBackoff := o.backoff, o.maxBackoff
+	opts := []httpretry.HTTPOption{
+		httpretry.WithMaxRetry(o.retries),
+		httpretry.WithAttemptTimeout(o.attemptTimeout),
+		httpretry.WithTotalTimeout(o.totalTimeout),
+		httpretry.WithInsecure(o.insecure),
+		httpretry.WithBackoffPolicy(func(attempt int) time.Duration {
+			delay := backoff << max(attempt-1, 0)
+			if delay <= 0 || delay > maxBackoff {
+				return maxBackoff
+			}
+			return delay
+		}),
+	}
+	if o.verbose {
+		opts = append(opts, httpretry.WithObserver(attemptPrinter{out: stderr}))
+	}
+	settings := httpretry.NewHTTPSettings(append(opts, extra...)...)
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// request 플래그와 요청 바디로 요청을 생성
+func (o *requestOptions) request(url string, body []byte) (*http.Request, error) {
+	method := o.method
+	if method == "" {
+		method = http.MethodGet
+		if body != nil {
+			method = http.MethodPost
+		}
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(strings.ToUpper(method), url, reader)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range o.headers {
+		req.Header[key] = values
+	}
+	return req, nil
+}
+
+// body -d 플래그의 요청 바디. 지정하지 않으면 nil
+func (o *requestOptions) body(stdin io.Reader) ([]byte, error) {
+	switch {
+	case o.data == "":
+		return nil, nil
+	case o.data == "@-":
+		return io.ReadAll(stdin)
+	case strings.HasPrefix(o.data, "@"):
+		return os.ReadFile(o.data[1:])
+	default:
+		return []byte(o.data), nil
+	}
+}
+
+// parseStatusCodes 쉼표로 구분한 상태 코드를 해석
+func parseStatusCodes(value string) ([]int, error) {
+	var codes []int
+	for _, field := range strings.Split(value, ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		code, err := strconv.Atoi(field)
+		if err != nil || code < 100 || code > 599 {
+			return nil, fmt.Errorf("invalid status code %q", field)
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// String 지정한 헤더를 반환
+func (h headerFlags) String() string {
+	var fields []string
+	for key, values := range h {
+		for _, value := range values {
+			fields = append(fields, key+": "+value)
+		}
+	}
+	return strings.Join(fields, ", ")
+}
+
+// Set "Key: Value" 형식의 헤더를 추가
+func (h headerFlags) Set(value string) error {
+	key, val, ok := strings.Cut(value, ":")
+	if !ok || strings.TrimSpace(key) == "" {
+		return fmt.Errorf("header must be \"Key: Value\", got %q", value)
+	}
+	http.Header(h).Add(strings.TrimSpace(key), strings.TrimSpace(val))
+	return nil
+}
+
+// Observe 시도 종료, 재시도 예정, 재시도 포기 이벤트를 출력
+func (p attemptPrinter) Observe(_ *http.Request, event httpretry.RetryEvent) {
+	switch event.Type {
+	case httpretry.EventAttemptFinish:
+		result := "no response"
+		if event.StatusCode > 0 {
+			result = strconv.Itoa(event.StatusCode)
+		}
+		if event.Err != nil {
+			result += " (" + event.Err.Error() + ")"
+		}
+		fmt.Fprintf(p.out, "* attempt %d %s %s -> %s in %s\n",
+			event.Attempt, event.Method, event.URL, result, event.Duration.Round(time.Millisecond))
+	case httpretry.EventRetryScheduled:
+		fmt.Fprintf(p.out, "* retrying in %s\n", event.Backoff)
+	case httpretry.EventGiveUp:
+		fmt.Fprintf(p.out, "* giving up after %d attempts: %s\n", event.Attempt, event.Reason)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	t.Run("재시도 후 받은 응답 바디를 출력하고 시도별 정보를 표준 에러로 출력하는 테스트", func(t *testing.T) {
+		// given
+		var received atomic.Int32
+		var gotMethod, gotHeader, gotBody string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if received.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			gotMethod, gotHeader, gotBody = r.Method, r.Header.Get("X-Token"), string(body)
+			w.Write([]byte("ok"))
+		}))
+		defer server.Close()
+		var stdout, stderr bytes.Buffer
+
+		// when
+		code := run([]string{"-H", "X-Token: secret", "-d", "@-", "-backoff", "1ms", "-v", server.URL},
+			strings.NewReader("payload"), &stdout, &stderr)
+
+		// then
+		assert.Equal(t, exitOK, code)
+		assert.Equal(t, "ok", stdout.String())
+		assert.Equal(t, http.MethodPost, gotMethod)
+		assert.Equal(t, "secret", gotHeader)
+		assert.Equal(t, "payload", gotBody)
+		assert.Contains(t, stderr.String(), "* attempt 1 POST "+server.URL+" -> 503")
+		assert.Contains(t, stderr.String(), "* retrying in 1ms")
+		assert.Contains(t, stderr.String(), "< HTTP/1.1 200 OK")
+	})
+
+	t.Run("재시도를 포기하면 실패 종료 코드를 반환하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}))
+		defer server.Close()
+		var stdout, stderr bytes.Buffer
+
+		// when
+		code := run([]string{"-retries", "2", "-backoff", "1ms", "-status", "418", server.URL}, nil, &stdout, &stderr)
+
+		// then
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, stderr.String(), "max retries reached")
+	})
+
+	t.Run("잘못된 인자는 사용법 종료 코드를 반환하는 테스트", func(t *testing.T) {
+		// given
+		var stdout, stderr bytes.Buffer
+
+		// when
+		noURL := run(nil, nil, &stdout, &stderr)
+		badHeader := run([]string{"-H", "invalid", "http://localhost"}, nil, &stdout, &stderr)
+		badStatus := run([]string{"-status", "abc", "http://localhost"}, nil, &stdout, &stderr)
+
+		// then
+		assert.Equal(t, exitUsage, noURL)
+		assert.Equal(t, exitUsage, badHeader)
+		assert.Equal(t, exitUsage, badStatus)
+	})
+}
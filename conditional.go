@@ -0,0 +1,112 @@
+package httpretry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+var (
+	// ErrMissingETag 리소스 조회 응답에 ETag 헤더가 없는 경우
+	ErrMissingETag = errors.New("resource has no ETag")
+	// ErrPreconditionFailed 최대 시도 횟수 동안 계속 412 Precondition Failed 응답을 받은 경우
+	ErrPreconditionFailed = errors.New("precondition failed")
+)
+
+// MutateFunc 현재 리소스 바디를 받아 저장할 바디를 반환하는 함수
+//
+// 다른 클라이언트와 충돌하면 최신 리소스로 다시 호출되므로, 같은 입력에 같은 결과를 반환해야 합니다.
+type MutateFunc func(current []byte) ([]byte, error)
+
+// UpdateIfMatch 리소스를 조회하고 변경한 뒤 If-Match 헤더로 조건부 저장 (optimistic concurrency)
+//
+// GET으로 리소스와 ETag를 조회하고, mutate로 변경한 바디를 If-Match: <ETag> 헤더와 함께 PUT 합니다.
+// 그 사이 다른 클라이언트가 리소스를 변경하여 412 Precondition Failed 응답을 받으면,
+// 다시 조회하여 최신 리소스에 mutate를 적용하고 저장합니다.
+// 개별 요청의 재시도는 client의 재시도 정책을 따릅니다.
+//
+// 저장 응답(412 제외)은 상태 코드와 관계없이 반환하며, 호출자가 바디를 닫아야 합니다.
+//
+//	resp, err := httpretry.UpdateIfMatch(ctx, client, url, 5, func(current []byte) ([]byte, error) {
+//		var item Item
+//		if err := json.Unmarshal(current, &item); err != nil {
+//			return nil, err
+//		}
+//		item.Count++
+//		return json.Marshal(item)
+//	})
+//
+// Parameters:
+//   - ctx: (context.Context) 요청 context
+//   - client: (Doer) 요청에 사용할 클라이언트. 보통 NewClient로 생성한 재시도 클라이언트
+//   - url: (string) 리소스 URL
+//   - maxAttempts: (int) 조회-변경-저장 최대 시도 횟수. 0 이하인 경우 1회
+//   - mutate: (MutateFunc) 리소스 변경 함수
+func UpdateIfMatch(
+	ctx context.Context,
+	client Doer,
+	url string,
+	maxAttempts int,
+	mutate MutateFunc,
+) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		current, etag, contentType, err := getWithETag(ctx, client, url)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := mutate(current)
+		if err != nil {
+			return nil, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(updated))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("If-Match", etag)
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusPreconditionFailed {
+			return resp, nil
+		}
+		resp.Body.Close()
+
+		if attempt >= maxAttempts {
+			return nil, fmt.Errorf("%s after %d attempts: %w", url, attempt, ErrPreconditionFailed)
+		}
+	}
+}
+
+// getWithETag 리소스의 바디, ETag, Content-Type을 조회
+func getWithETag(ctx context.Context, client Doer, url string) ([]byte, string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, "", "", fmt.Errorf("unexpected status code %d from GET %s", resp.StatusCode, url)
+	}
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		return nil, "", "", fmt.Errorf("%s: %w", url, ErrMissingETag)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return body, etag, resp.Header.Get("Content-Type"), nil
+}
@@ -0,0 +1,103 @@
+package httpretry_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+// versionedServer ETag로 버전을 관리하는 테스트 서버. conflicts 횟수만큼 저장 직전에 다른 클라이언트의 변경을 흉내냄
+func versionedServer(t *testing.T, conflicts int) (*httptest.Server, func() string) {
+	var (
+		mu      sync.Mutex
+		version = 1
+		value   = "0"
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		etag := strconv.Quote(strconv.Itoa(version))
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("ETag", etag)
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte(value))
+		case http.MethodPut:
+			if conflicts > 0 {
+				conflicts--
+				version++
+				value += "0"
+			}
+			if r.Header.Get("If-Match") != strconv.Quote(strconv.Itoa(version)) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			version++
+			value = string(body)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		return value
+	}
+}
+
+func TestUpdateIfMatch(t *testing.T) {
+	appendOne := func(current []byte) ([]byte, error) {
+		return append(current, '1'), nil
+	}
+
+	t.Run("412 응답을 받으면 다시 조회하여 최신 리소스에 변경을 적용하는 테스트", func(t *testing.T) {
+		// given
+		server, current := versionedServer(t, 2)
+		client := httpretry.NewClient(httpretry.NewHTTPSettings())
+
+		// when
+		resp, err := httpretry.UpdateIfMatch(context.Background(), client, server.URL, 3, appendOne)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		resp.Body.Close()
+		assert.Equal(t, "0001", current(), "다른 클라이언트의 변경 위에 적용되어야 합니다.")
+	})
+
+	t.Run("최대 시도 횟수 동안 충돌하면 ErrPreconditionFailed를 반환하는 테스트", func(t *testing.T) {
+		// given
+		server, current := versionedServer(t, 5)
+		client := httpretry.NewClient(httpretry.NewHTTPSettings())
+
+		// when
+		_, err := httpretry.UpdateIfMatch(context.Background(), client, server.URL, 2, appendOne)
+
+		// then
+		assert.True(t, errors.Is(err, httpretry.ErrPreconditionFailed))
+		assert.Equal(t, "000", current())
+	})
+
+	t.Run("ETag가 없는 리소스는 ErrMissingETag를 반환하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("0"))
+		}))
+		defer server.Close()
+
+		// when
+		_, err := httpretry.UpdateIfMatch(context.Background(), http.DefaultClient, server.URL, 3, appendOne)
+
+		// then
+		assert.True(t, errors.Is(err, httpretry.ErrMissingETag))
+	})
+}
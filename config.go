@@ -0,0 +1,212 @@
+package httpretry
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"time"
+)
+
+type (
+	// Config 여러 서비스의 클라이언트 프로필을 한 파일에 담은 설정
+	//
+	//	{
+	//	  "defaults": {"max_retry": 3, "attempt_timeout": "2s", "backoff": "200ms", "max_backoff": "5s"},
+	//	  "profiles": {
+	//	    "payments": {"max_retry": 5, "total_timeout": "20s", "retry_status_codes": [409]},
+	//	    "orders": {"endpoint_service": "http://orders", "endpoints": ["http://10.0.0.1:8080", "http://10.0.0.2:8080"]}
+	//	  }
+	//	}
+	Config struct {
+		// Defaults 모든 프로필에 공통으로 적용할 설정
+		Defaults Profile `json:"defaults"`
+		// Profiles 서비스 이름별 프로필. Defaults 위에 덮어씀
+		Profiles map[string]Profile `json:"profiles"`
+	}
+
+	// Profile 서비스 1개의 클라이언트 설정
+	//
+	// 지정하지 않은 항목은 Defaults, NewHTTPSettings의 기본값 순서로 사용합니다.
+	Profile struct {
+		MaxRetry              *int      `json:"max_retry,omitempty"`
+		AttemptTimeout        *Duration `json:"attempt_timeout,omitempty"`
+		TotalTimeout          *Duration `json:"total_timeout,omitempty"`
+		ResponseHeaderTimeout *Duration `json:"response_header_timeout,omitempty"`
+		TLSHandshakeTimeout   *Duration `json:"tls_handshake_timeout,omitempty"`
+		IdleConnTimeout       *Duration `json:"idle_conn_timeout,omitempty"`
+		MaxIdleConns          *int      `json:"max_idle_conns,omitempty"`
+		Insecure              *bool     `json:"insecure,omitempty"`
+		// Backoff, MaxBackoff 첫 재시도 전 대기 시간과 최대 대기 시간. 대기 시간은 재시도마다 두 배로 늘어나며, MaxBackoff의 기본값은 30초
+		Backoff    *Duration `json:"backoff,omitempty"`
+		MaxBackoff *Duration `json:"max_backoff,omitempty"`
+		// RetryStatusCodes 기본 재시도 상태 코드에 추가할 상태 코드. Defaults의 상태 코드에 더함
+		RetryStatusCodes []int `json:"retry_status_codes,omitempty"`
+		// NoRetryStatusCodes 기본 재시도 상태 코드에서 제외할 상태 코드. Defaults의 상태 코드에 더함
+		NoRetryStatusCodes []int `json:"no_retry_status_codes,omitempty"`
+		// RetryMethods 재시도를 허용하는 메서드. 지정하면 Defaults의 메서드를 대체
+		RetryMethods []string `json:"retry_methods,omitempty"`
+		// EndpointService, Endpoints 서비스 요청을 분배할 엔드포인트. 지정하면 Defaults의 엔드포인트를 대체
+		EndpointService string   `json:"endpoint_service,omitempty"`
+		Endpoints       []string `json:"endpoints,omitempty"`
+	}
+
+	// Duration 설정 파일에서 "1.5s", "200ms" 형식의 문자열로 지정하는 시간
+	Duration time.Duration
+)
+
+// LoadConfig JSON 설정 파일을 읽어 Config를 생성
+//
+// 알 수 없는 항목이 있으면 오타로 보고 에러를 반환합니다.
+//
+// Parameters:
+//   - path: (string) 설정 파일 경로
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+	return ParseConfig(data)
+}
+
+// ParseConfig JSON 설정을 해석하여 Config를 생성
+//
+// Parameters:
+//   - data: ([]byte) JSON 설정
+func ParseConfig(data []byte) (*Config, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var config Config
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("parsing config: %w", err)
+	}
+	return &config, nil
+}
+
+// NewClients 설정의 모든 프로필로 클라이언트를 생성하여 서비스 이름별로 반환
+//
+// 프로필 설정은 Settings.Validate로 검사하며, 유효하지 않은 프로필이 있으면 클라이언트를 생성하지 않고
+// 모든 프로필의 에러를 프로필 이름과 함께 반환합니다.
+//
+//	config, err := httpretry.LoadConfig("clients.json")
+//	clients, err := httpretry.NewClients(config)
+//	payments := clients["payments"]
+//
+// Parameters:
+//   - config: (*Config) 프로필 설정
+func NewClients(config *Config) (map[string]*http.Client, error) {
+	var errs []error
+	settings := make(map[string]*Settings, len(config.Profiles))
+	for _, name := range slices.Sorted(maps.Keys(config.Profiles)) {
+		profileSettings := config.Settings(name)
+		if err := profileSettings.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("profile %q: %w", name, err))
+			continue
+		}
+		settings[name] = profileSettings
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	clients := make(map[string]*http.Client, len(settings))
+	for name, profileSettings := range settings {
+		clients[name] = NewClient(profileSettings, config.retryStatusCodes(name)...)
+	}
+	return clients, nil
+}
+
+// Settings Defaults와 프로필을 차례로 적용한 설정을 반환. 프로필이 없으면 Defaults만 적용
+//
+// 프로필의 RetryStatusCodes는 클라이언트 생성 시 추가하는 상태 코드이므로 포함하지 않습니다.
+//
+// Parameters:
+//   - name: (string) 프로필 이름
+func (c *Config) Settings(name string) *Settings {
+	settings := NewHTTPSettings()
+	c.Defaults.apply(settings)
+	if profile, ok := c.Profiles[name]; ok {
+		profile.apply(settings)
+	}
+	return settings
+}
+
+// retryStatusCodes Defaults와 프로필에서 추가한 재시도 상태 코드
+func (c *Config) retryStatusCodes(name string) []int {
+	return append(slices.Clone(c.Defaults.RetryStatusCodes), c.Profiles[name].RetryStatusCodes...)
+}
+
+// apply 지정한 항목만 설정에 덮어씀
+func (p Profile) apply(s *Settings) {
+	setIf(&s.MaxRetry, p.MaxRetry)
+	setDurationIf(&s.AttemptTimeout, p.AttemptTimeout)
+	setDurationIf(&s.TotalTimeout, p.TotalTimeout)
+	setDurationIf(&s.ResponseHeaderTimeout, p.ResponseHeaderTimeout)
+	setDurationIf(&s.TLSHandshakeTimeout, p.TLSHandshakeTimeout)
+	setDurationIf(&s.IdleConnTimeout, p.IdleConnTimeout)
+	setIf(&s.MaxIdleConns, p.MaxIdleConns)
+	setIf(&s.Insecure, p.Insecure)
+	if p.Backoff != nil {
+		maxBackoff := 30 * time.Second
+		if p.MaxBackoff != nil {
+			maxBackoff = time.Duration(*p.MaxBackoff)
+		}
+		s.BackoffPolicy = doublingBackoff(time.Duration(*p.Backoff), maxBackoff)
+	}
+	s.NoRetryStatusCodes = append(s.NoRetryStatusCodes, p.NoRetryStatusCodes...)
+	if len(p.RetryMethods) > 0 {
+		s.RetryMethods = slices.Clone(p.RetryMethods)
+	}
+	if p.EndpointService != "" || len(p.Endpoints) > 0 {
+		s.EndpointService = p.EndpointService
+		s.Endpoints = slices.Clone(p.Endpoints)
+	}
+}
+
+// doublingBackoff 재시도마다 두 배로 늘어나고 maxBackoff를 넘지 않는 백오프 정책
+func doublingBackoff(backoff, maxBackoff time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		delay := backoff << max(attempt-1, 0)
+		if delay < 0 || delay > maxBackoff {
+			return maxBackoff
+		}
+		return delay
+	}
+}
+
+// setIf 값이 지정된 경우에만 덮어씀
+func setIf[T any](target *T, value *T) {
+	if value != nil {
+		*target = *value
+	}
+}
+
+// setDurationIf 시간이 지정된 경우에만 덮어씀
+func setDurationIf(target *time.Duration, value *Duration) {
+	if value != nil {
+		*target = time.Duration(*value)
+	}
+}
+
+// UnmarshalJSON "1.5s" 형식의 문자열을 시간으로 해석
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("duration must be a string such as \"1.5s\", got %s", data)
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("parsing duration: %w", err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalJSON 시간을 "1.5s" 형식의 문자열로 변환
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
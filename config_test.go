@@ -0,0 +1,84 @@
+package httpretry_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClients(t *testing.T) {
+	t.Run("설정 파일의 프로필마다 Defaults를 덮어쓴 클라이언트를 생성하는 테스트", func(t *testing.T) {
+		// given
+		var payments, orders atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/payments" {
+				payments.Add(1)
+			} else {
+				orders.Add(1)
+			}
+			w.WriteHeader(http.StatusConflict)
+		}))
+		defer server.Close()
+		path := filepath.Join(t.TempDir(), "clients.json")
+		os.WriteFile(path, []byte(`{
+			"defaults": {"max_retry": 2, "attempt_timeout": "2s", "backoff": "1ms", "max_backoff": "2ms"},
+			"profiles": {
+				"payments": {"max_retry": 3, "total_timeout": "20s", "retry_status_codes": [409]},
+				"orders": {}
+			}
+		}`), 0o600)
+		config, err := httpretry.LoadConfig(path)
+		assert.NoError(t, err)
+
+		// when
+		clients, err := httpretry.NewClients(config)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, clients, 2)
+		settings := config.Settings("payments")
+		assert.Equal(t, 3, settings.MaxRetry)
+		assert.Equal(t, 2*time.Second, settings.AttemptTimeout)
+		assert.Equal(t, 20*time.Second, settings.TotalTimeout)
+		assert.Equal(t, 2, config.Settings("orders").MaxRetry)
+		assert.Equal(t, 2*time.Millisecond, settings.BackoffPolicy(5))
+
+		clients["payments"].Get(server.URL + "/payments")
+		resp, err := clients["orders"].Get(server.URL + "/orders")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+		assert.Equal(t, int32(3), payments.Load(), "payments 프로필은 409를 3번 시도")
+		assert.Equal(t, int32(1), orders.Load(), "orders 프로필은 409를 재시도하지 않음")
+	})
+
+	t.Run("유효하지 않은 프로필은 이름과 함께 에러를 반환하는 테스트", func(t *testing.T) {
+		// given
+		config, err := httpretry.ParseConfig([]byte(`{"profiles": {"search": {"max_retry": 0}}}`))
+		assert.NoError(t, err)
+
+		// when
+		clients, err := httpretry.NewClients(config)
+
+		// then
+		assert.Nil(t, clients)
+		assert.ErrorIs(t, err, httpretry.ErrInvalidSettings)
+		assert.ErrorContains(t, err, `profile "search": invalid settings: MaxRetry`)
+	})
+
+	t.Run("알 수 없는 항목과 잘못된 시간 형식은 해석하지 않는 테스트", func(t *testing.T) {
+		// when
+		_, unknownErr := httpretry.ParseConfig([]byte(`{"profiles": {"search": {"max_retries": 3}}}`))
+		_, durationErr := httpretry.ParseConfig([]byte(`{"defaults": {"attempt_timeout": 5}}`))
+
+		// then
+		assert.ErrorContains(t, unknownErr, "max_retries")
+		assert.ErrorContains(t, durationErr, `duration must be a string such as "1.5s"`)
+	})
+}
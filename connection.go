@@ -0,0 +1,128 @@
+package httpretry
+
+import (
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// RetryConnectionPolicy 재시도가 커넥션 풀의 유휴 커넥션을 재사용할지 정하는 정책
+type RetryConnectionPolicy string
+
+const (
+	// RetryConnectionReuse 재시도도 유휴 커넥션을 재사용
+	RetryConnectionReuse RetryConnectionPolicy = "reuse"
+	// RetryConnectionFresh 실패한 시도의 커넥션을 닫아 모든 재시도를 새 커넥션으로 연결
+	RetryConnectionFresh RetryConnectionPolicy = "fresh"
+	// RetryConnectionFreshAfterNetworkError 커넥션이 끊겨 실패한 시도의 재시도를 Connection: close로 전송 (기본값)
+	RetryConnectionFreshAfterNetworkError RetryConnectionPolicy = "fresh-after-network-error"
+)
+
+// connectionErrnos 커넥션이 끊겨 같은 커넥션을 다시 사용할 수 없음을 나타내는 syscall 에러
+var connectionErrnos = []syscall.Errno{
+	syscall.ECONNRESET,
+	syscall.ECONNABORTED,
+	syscall.EPIPE,
+}
+
+// connectionMessages net/http가 내부 에러 타입 없이 반환하는 커넥션 끊김 메시지
+var connectionMessages = []string{
+	"server closed idle connection",
+	"transport connection broken",
+	"connection reset by peer",
+	"broken pipe",
+}
+
+// isConnectionError 리셋, 끊긴 keep-alive처럼 커넥션 자체가 끊긴 에러인지 판단
+//
+// 타임아웃, DNS, TLS 에러는 커넥션이 살아 있거나 연결 전에 실패한 것이므로 해당하지 않습니다.
+func isConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	for _, errno := range connectionErrnos {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	message := err.Error()
+	for _, broken := range connectionMessages {
+		if strings.Contains(message, broken) {
+			return true
+		}
+	}
+	return false
+}
+
+// attemptConnection 시도가 사용한 커넥션
+type attemptConnection struct {
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// trackConnection 시도가 사용한 커넥션을 기록하도록 요청에 httptrace를 연결
+//
+// Parameters:
+//   - attemptReq: (*http.Request) 시도 요청
+func trackConnection(attemptReq *http.Request) (*http.Request, *attemptConnection) {
+	used := &attemptConnection{}
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			used.mu.Lock()
+			defer used.mu.Unlock()
+			used.conn = info.Conn
+		},
+	}
+	return attemptReq.WithContext(httptrace.WithClientTrace(attemptReq.Context(), trace)), used
+}
+
+// get 기록된 커넥션을 반환. 기록하지 않았으면 nil
+func (c *attemptConnection) get() net.Conn {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn
+}
+
+// prepareRetryConnection 재시도 정책에 따라 재시도 요청의 커넥션 사용을 정함
+//
+// 공유하는 커넥션 풀(다른 호스트, CloneWith로 만든 클라이언트, NewClientFrom의 Transport)은 정리하지 않습니다.
+// 끊긴 커넥션은 net/http가 풀에서 제거하므로, 재시도는 Connection: close로 보내 장애 중에 사용한 커넥션을 풀에 남기지 않습니다.
+// RetryConnectionFresh는 직전 시도가 사용한 커넥션만 닫아 재시도가 같은 커넥션을 재사용하지 않도록 합니다.
+// 다른 요청과 공유하는 HTTP/2 커넥션은 닫지 않습니다.
+//
+// Parameters:
+//   - attemptReq: (*http.Request) 재시도 요청
+//   - lastErr: (error) 직전 시도의 전송 에러
+//   - lastConn: (net.Conn) 직전 시도가 사용한 커넥션. 기록하지 않았으면 nil
+func (rt *retriableTransport) prepareRetryConnection(attemptReq *http.Request, lastErr error, lastConn net.Conn) {
+	switch rt.connectionPolicy {
+	case RetryConnectionReuse:
+		return
+	case RetryConnectionFresh:
+		if lastConn != nil && !isHTTP2Conn(lastConn) {
+			lastConn.Close()
+		}
+	default:
+		if isConnectionError(lastErr) {
+			attemptReq.Close = true
+		}
+	}
+}
+
+// isHTTP2Conn 여러 요청이 공유하는 HTTP/2 커넥션인지 판단
+func isHTTP2Conn(conn net.Conn) bool {
+	tlsConn, ok := conn.(*tls.Conn)
+	return ok && tlsConn.ConnectionState().NegotiatedProtocol == "h2"
+}
@@ -0,0 +1,200 @@
+package httpretry_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+// connectionRecorder 시도마다 요청이 도착한 커넥션(RemoteAddr)을 기록하는 서버
+type connectionRecorder struct {
+	mu     sync.Mutex
+	warmed map[string]bool
+	addrs  []string
+	closes []bool
+}
+
+// newBrokenConnectionServer 유휴 커넥션 두 개를 만든 뒤 첫 시도의 커넥션을 끊는 서버를 생성
+func newBrokenConnectionServer(t *testing.T, client *http.Client) (*httptest.Server, *connectionRecorder) {
+	recorder := &connectionRecorder{warmed: map[string]bool{}}
+	var warmup sync.WaitGroup
+	var attempts atomic.Int32
+	warmup.Add(2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorder.mu.Lock()
+		if r.URL.Path == "/warmup" {
+			recorder.warmed[r.RemoteAddr] = true
+		} else {
+			recorder.addrs = append(recorder.addrs, r.RemoteAddr)
+			recorder.closes = append(recorder.closes, r.Close)
+		}
+		recorder.mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/warmup":
+			// 두 요청이 동시에 도착해야 서로 다른 커넥션 두 개가 풀에 남음
+			warmup.Done()
+			warmup.Wait()
+		case attempts.Add(1) == 1:
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL + "/warmup")
+			if assert.NoError(t, err) {
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+	return server, recorder
+}
+
+func TestFreshConnectionAfterConnectionError(t *testing.T) {
+	t.Run("커넥션이 끊기면 공유 풀을 정리하지 않고 재시도를 Connection: close로 보내는 테스트", func(t *testing.T) {
+		// given
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(noBackoff),
+		))
+		server, recorder := newBrokenConnectionServer(t, client)
+
+		// when
+		resp, err := client.Post(server.URL+"/items", "text/plain", strings.NewReader("item"))
+
+		// then
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Len(t, recorder.warmed, 2)
+		assert.Len(t, recorder.addrs, 2)
+		assert.Contains(t, recorder.warmed, recorder.addrs[1], "남은 유휴 커넥션을 재사용")
+		assert.Equal(t, []bool{false, true}, recorder.closes)
+	})
+}
+
+func TestWithRetryConnectionPolicy(t *testing.T) {
+	t.Run("reuse 정책은 커넥션이 끊겨도 유휴 커넥션을 재사용하는 테스트", func(t *testing.T) {
+		// given
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithRetryConnectionPolicy(httpretry.RetryConnectionReuse),
+		))
+		server, recorder := newBrokenConnectionServer(t, client)
+
+		// when
+		resp, err := client.Post(server.URL+"/items", "text/plain", strings.NewReader("item"))
+
+		// then
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Len(t, recorder.addrs, 2)
+		assert.Contains(t, recorder.warmed, recorder.addrs[1])
+		assert.Equal(t, []bool{false, false}, recorder.closes)
+	})
+
+	tests := []struct {
+		name           string
+		policy         httpretry.RetryConnectionPolicy
+		sameConnection bool
+	}{
+		{name: "fresh 정책은 상태 코드로 재시도해도 새 커넥션을 사용하는 테스트", policy: httpretry.RetryConnectionFresh},
+		{
+			name:           "기본 정책은 상태 코드로 재시도하면 같은 커넥션을 재사용하는 테스트",
+			policy:         httpretry.RetryConnectionFreshAfterNetworkError,
+			sameConnection: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			var (
+				mu    sync.Mutex
+				addrs []string
+			)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				addrs = append(addrs, r.RemoteAddr)
+				first := len(addrs) == 1
+				mu.Unlock()
+				if first {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			}))
+			defer server.Close()
+			client := httpretry.NewClient(httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(2),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithRetryConnectionPolicy(tt.policy),
+			))
+
+			// when
+			resp, err := client.Get(server.URL)
+
+			// then
+			assert.NoError(t, err)
+			resp.Body.Close()
+			assert.Len(t, addrs, 2)
+			assert.Equal(t, tt.sameConnection, addrs[0] == addrs[1])
+		})
+	}
+}
+
+func TestFreshConnectionScope(t *testing.T) {
+	t.Run("fresh 정책은 실패한 커넥션만 닫고 다른 호스트의 유휴 커넥션은 유지하는 테스트", func(t *testing.T) {
+		// given
+		var (
+			mu         sync.Mutex
+			otherAddrs []string
+		)
+		other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			otherAddrs = append(otherAddrs, r.RemoteAddr)
+		}))
+		defer other.Close()
+		var attempts atomic.Int32
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if attempts.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer failing.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithRetryConnectionPolicy(httpretry.RetryConnectionFresh),
+		))
+		warmup, err := client.Get(other.URL)
+		assert.NoError(t, err)
+		warmup.Body.Close()
+
+		// when
+		resp, err := client.Get(failing.URL)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		again, err := client.Get(other.URL)
+
+		// then
+		assert.NoError(t, err)
+		again.Body.Close()
+		assert.Equal(t, int32(2), attempts.Load())
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Len(t, otherAddrs, 2)
+		assert.Equal(t, otherAddrs[0], otherAddrs[1], "다른 호스트의 유휴 커넥션을 재사용")
+	})
+}
@@ -0,0 +1,46 @@
+package httpretry
+
+import "context"
+
+// noRetryKey 재시도 비활성화 여부를 저장하는 context key
+type noRetryKey struct{}
+
+// NoRetry 요청을 재시도하지 않도록 표시한 context를 반환
+//
+// 공유 클라이언트를 사용하면서 중복 처리에 민감한 요청(결제 생성 등)만 정확히 한 번 전송할 때 사용합니다.
+// 실패 응답은 그대로, 전송 에러는 에러로 반환하며, 서버가 처리하지 않은 요청도 재시도하지 않습니다.
+//
+//	req, _ := http.NewRequestWithContext(httpretry.NoRetry(ctx), http.MethodPost, url, body)
+//
+// Parameters:
+//   - ctx: (context.Context) 부모 context
+func NoRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey{}, true)
+}
+
+// noRetryFromContext context에 재시도 비활성화가 표시되었는지 확인
+func noRetryFromContext(ctx context.Context) bool {
+	noRetry, _ := ctx.Value(noRetryKey{}).(bool)
+	return noRetry
+}
+
+// noCacheKey 캐시 우회 여부를 저장하는 context key
+type noCacheKey struct{}
+
+// NoCache 요청이 캐시를 사용하지 않도록 표시한 context를 반환
+//
+// 캐시된 응답을 반환하지 않고, 받은 응답도 캐시에 저장하지 않습니다.
+//
+//	req, _ := http.NewRequestWithContext(httpretry.NoCache(ctx), http.MethodGet, url, nil)
+//
+// Parameters:
+//   - ctx: (context.Context) 부모 context
+func NoCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+// noCacheFromContext context에 캐시 우회가 표시되었는지 확인
+func noCacheFromContext(ctx context.Context) bool {
+	noCache, _ := ctx.Value(noCacheKey{}).(bool)
+	return noCache
+}
@@ -0,0 +1,48 @@
+package httpretry_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoRetry(t *testing.T) {
+	t.Run("NoRetry context로 요청 시 한 번만 시도하고 응답을 반환하는 테스트", func(t *testing.T) {
+		// given
+		reqCount := 0
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reqCount++
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}),
+		)
+		defer testServer.Close()
+
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(3),
+				httpretry.WithBackoffPolicy(noBackoff),
+			),
+		)
+		req, _ := http.NewRequestWithContext(
+			httpretry.NoRetry(context.Background()),
+			http.MethodPost,
+			testServer.URL,
+			nil,
+		)
+
+		// when
+		resp, err := retryClient.Do(req)
+		_, retriedErr := retryClient.Get(testServer.URL)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Error(t, retriedErr)
+		assert.Equal(t, 1+3, reqCount, "NoRetry가 없는 요청은 설정된 정책으로 재시도해야 합니다.")
+	})
+}
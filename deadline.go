@@ -0,0 +1,51 @@
+package httpretry
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DeadlinePolicy 백오프 대기가 남은 기한을 넘는 경우의 처리 방식
+type DeadlinePolicy string
+
+const (
+	// DeadlineFailFast 대기하지 않고 ErrInsufficientRetryBudget으로 즉시 실패 (기본값)
+	DeadlineFailFast DeadlinePolicy = "fail"
+	// DeadlineTruncate 마지막 시도에 AttemptTimeout만큼 남도록 대기 시간을 줄여 한 번 더 시도
+	DeadlineTruncate DeadlinePolicy = "truncate"
+)
+
+// ErrInsufficientRetryBudget 남은 기한 안에 백오프 대기 후 재시도할 수 없어 재시도를 포기한 경우
+//
+// 타임아웃 에러이므로 errors.Is(err, context.DeadlineExceeded)로도 확인할 수 있습니다.
+var ErrInsufficientRetryBudget error = &timeoutError{msg: "budget insufficient for retry"}
+
+// requestDeadline 요청 context의 deadline과 전체 타임아웃 중 빠른 시각. 기한이 없으면 zero time
+func (rt *retriableTransport) requestDeadline(ctx context.Context, start time.Time) time.Time {
+	deadline, ok := ctx.Deadline()
+	if rt.totalTimeout > 0 {
+		if total := start.Add(rt.totalTimeout); !ok || total.Before(deadline) {
+			return total
+		}
+	}
+	if !ok {
+		return time.Time{}
+	}
+	return deadline
+}
+
+// fitDeadline 백오프 대기가 남은 기한을 넘으면 정책에 따라 대기 시간을 줄이거나 ErrInsufficientRetryBudget을 반환
+func (rt *retriableTransport) fitDeadline(deadline time.Time, delay, attemptTimeout time.Duration) (time.Duration, error) {
+	if deadline.IsZero() {
+		return delay, nil
+	}
+	remaining := time.Until(deadline)
+	if delay < remaining {
+		return delay, nil
+	}
+	if rt.deadlinePolicy == DeadlineTruncate && remaining > 0 {
+		return max(remaining-attemptTimeout, 0), nil
+	}
+	return 0, fmt.Errorf("backoff %s, remaining %s: %w", delay, max(remaining, 0), ErrInsufficientRetryBudget)
+}
@@ -0,0 +1,96 @@
+package httpretry_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithDeadlinePolicy(t *testing.T) {
+	t.Run("백오프가 context deadline을 넘으면 대기하지 않고 실패하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithBackoffPolicy(func(int) time.Duration { return time.Second }),
+		))
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+		// when
+		start := time.Now()
+		_, err := client.Do(req)
+
+		// then
+		assert.Less(t, time.Since(start), 100*time.Millisecond)
+		assert.ErrorIs(t, err, httpretry.ErrInsufficientRetryBudget)
+		var retryErr *httpretry.RetryError
+		assert.True(t, errors.As(err, &retryErr))
+		assert.Equal(t, httpretry.ReasonTimeout, retryErr.Reason)
+		assert.Equal(t, 1, retryErr.Attempts)
+	})
+
+	t.Run("DeadlineTruncate는 대기 시간을 줄여 마지막으로 한 번 더 시도하는 테스트", func(t *testing.T) {
+		// given
+		var received atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if received.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithAttemptTimeout(100*time.Millisecond),
+			httpretry.WithBackoffPolicy(func(int) time.Duration { return time.Second }),
+			httpretry.WithDeadlinePolicy(httpretry.DeadlineTruncate),
+		))
+		ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+		// when
+		start := time.Now()
+		resp, err := client.Do(req)
+
+		// then
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Less(t, time.Since(start), 300*time.Millisecond)
+	})
+
+	t.Run("마지막 시도 이후에는 백오프 대기하지 않는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(func(int) time.Duration { return 200 * time.Millisecond }),
+		))
+
+		// when
+		start := time.Now()
+		_, err := client.Get(server.URL)
+
+		// then
+		assert.Less(t, time.Since(start), 350*time.Millisecond)
+		var retryErr *httpretry.RetryError
+		assert.True(t, errors.As(err, &retryErr))
+		assert.Equal(t, httpretry.ReasonBudgetExhausted, retryErr.Reason)
+		assert.Equal(t, 2, retryErr.Attempts)
+	})
+}
@@ -0,0 +1,119 @@
+// Package decompress httpretry 클라이언트에 br, zstd 응답 디코딩을 추가합니다
+//
+// 코어 패키지의 의존성을 늘리지 않기 위해 별도 패키지로 분리되어 있으며, 필요한 경우에만 import 합니다.
+package decompress
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/dings-things/httpretry"
+	"github.com/klauspost/compress/zstd"
+)
+
+// AcceptEncoding 요청 시 광고하는 Accept-Encoding 값
+const AcceptEncoding = "br, zstd, gzip"
+
+type transport struct {
+	next http.RoundTripper
+}
+
+// NewTransport br, zstd, gzip 응답을 투명하게 디코딩하는 RoundTripper를 생성
+//
+// 요청에 Accept-Encoding 헤더가 이미 지정된 경우, 호출자가 원본 바디를 원하는 것으로 보고 디코딩하지 않습니다.
+// 표준 라이브러리의 gzip 처리와 동일하게 Range 요청도 그대로 전달합니다.
+//
+// Parameters:
+//   - next: (http.RoundTripper) 실제 요청을 수행하는 RoundTripper
+func NewTransport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &transport{next: next}
+}
+
+// WithDecompression httpretry 클라이언트에 디코딩 Transport를 추가하는 Option
+func WithDecompression() httpretry.HTTPOption {
+	return httpretry.WithTransportMiddleware(NewTransport)
+}
+
+// RoundTrip Accept-Encoding을 광고하고, 응답 바디를 Content-Encoding에 맞게 디코딩
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Accept-Encoding") != "" || req.Header.Get("Range") != "" {
+		return t.next.RoundTrip(req)
+	}
+
+	// RoundTripper는 요청을 변경하면 안되므로 헤더를 복사
+	req = req.Clone(req.Context())
+	req.Header.Set("Accept-Encoding", AcceptEncoding)
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.Body == nil || resp.Body == http.NoBody {
+		return resp, err
+	}
+
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	var open func(io.Reader) (io.ReadCloser, error)
+	switch encoding {
+	case "br":
+		open = func(r io.Reader) (io.ReadCloser, error) {
+			return io.NopCloser(brotli.NewReader(r)), nil
+		}
+	case "zstd":
+		open = func(r io.Reader) (io.ReadCloser, error) {
+			decoder, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+			if err != nil {
+				return nil, err
+			}
+			return decoder.IOReadCloser(), nil
+		}
+	case "gzip":
+		open = func(r io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(r)
+		}
+	default:
+		return resp, nil
+	}
+
+	resp.Body = &decodingBody{body: resp.Body, open: open}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// decodingBody 첫 Read 시점에 디코더를 생성하는 바디
+//
+// gzip 등 일부 디코더는 생성 시 헤더를 읽으므로, RoundTrip이 바디 수신을 기다리지 않도록 지연 생성합니다.
+type decodingBody struct {
+	body    io.ReadCloser
+	open    func(io.Reader) (io.ReadCloser, error)
+	decoder io.ReadCloser
+	err     error
+}
+
+// Read 디코딩된 바디를 읽음
+func (b *decodingBody) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	if b.decoder == nil {
+		b.decoder, b.err = b.open(b.body)
+		if b.err != nil {
+			return 0, b.err
+		}
+	}
+	return b.decoder.Read(p)
+}
+
+// Close 디코더와 원본 바디를 닫음
+func (b *decodingBody) Close() error {
+	if b.decoder != nil {
+		b.decoder.Close()
+	}
+	return b.body.Close()
+}
@@ -0,0 +1,96 @@
+package decompress_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/dings-things/httpretry"
+	"github.com/dings-things/httpretry/decompress"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecompression(t *testing.T) {
+	const payload = "compressed response payload"
+
+	encoders := map[string]func(w io.Writer) io.WriteCloser{
+		"br": func(w io.Writer) io.WriteCloser { return brotli.NewWriter(w) },
+		"zstd": func(w io.Writer) io.WriteCloser {
+			encoder, _ := zstd.NewWriter(w)
+			return encoder
+		},
+		"gzip": func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) },
+	}
+
+	for encoding, newEncoder := range encoders {
+		t.Run(encoding+" 응답 디코딩 테스트", func(t *testing.T) {
+			// given
+			var acceptEncoding string
+			testServer := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					acceptEncoding = r.Header.Get("Accept-Encoding")
+					var buf bytes.Buffer
+					encoder := newEncoder(&buf)
+					encoder.Write([]byte(payload))
+					encoder.Close()
+					w.Header().Set("Content-Encoding", encoding)
+					w.WriteHeader(http.StatusOK)
+					w.Write(buf.Bytes())
+				}),
+			)
+			defer testServer.Close()
+
+			retryClient := httpretry.NewClient(
+				httpretry.NewHTTPSettings(
+					httpretry.WithRequestTimeout(1*time.Second),
+					decompress.WithDecompression(),
+				),
+			)
+
+			// when
+			resp, err := retryClient.Get(testServer.URL)
+
+			// then
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+			body, readErr := io.ReadAll(resp.Body)
+			assert.NoError(t, readErr)
+			assert.Equal(t, payload, string(body))
+			assert.Equal(t, decompress.AcceptEncoding, acceptEncoding)
+			assert.Empty(t, resp.Header.Get("Content-Encoding"))
+			assert.True(t, resp.Uncompressed)
+		})
+	}
+
+	t.Run("Accept-Encoding 지정 시, 원본 바디 반환 테스트", func(t *testing.T) {
+		// given
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Encoding", "br")
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("raw"))
+			}),
+		)
+		defer testServer.Close()
+
+		client := &http.Client{Transport: decompress.NewTransport(nil)}
+		req, _ := http.NewRequest(http.MethodGet, testServer.URL, nil)
+		req.Header.Set("Accept-Encoding", "br")
+
+		// when
+		resp, err := client.Do(req)
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "raw", string(body))
+		assert.Equal(t, "br", resp.Header.Get("Content-Encoding"))
+	})
+}
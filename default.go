@@ -0,0 +1,52 @@
+package httpretry
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+)
+
+// defaultClient 패키지 수준 함수가 사용하는 기본 클라이언트
+var defaultClient atomic.Pointer[http.Client]
+
+// Default 패키지 수준 기본 클라이언트를 반환
+//
+// SetDefault로 지정하지 않은 경우, 처음 호출될 때 NewHTTPSettings의 기본 설정으로 생성합니다.
+func Default() *http.Client {
+	if client := defaultClient.Load(); client != nil {
+		return client
+	}
+	defaultClient.CompareAndSwap(nil, NewClient(NewHTTPSettings()))
+	return defaultClient.Load()
+}
+
+// SetDefault 패키지 수준 기본 클라이언트를 변경
+//
+// Parameters:
+//   - client: (*http.Client) 기본 클라이언트. nil인 경우 다음 Default 호출 시 기본 설정으로 다시 생성
+func SetDefault(client *http.Client) {
+	defaultClient.Store(client)
+}
+
+// Get 기본 클라이언트로 GET 요청. http.Get과 동일하게 동작
+func Get(url string) (*http.Response, error) {
+	return Default().Get(url)
+}
+
+// Head 기본 클라이언트로 HEAD 요청. http.Head와 동일하게 동작
+func Head(url string) (*http.Response, error) {
+	return Default().Head(url)
+}
+
+// Post 기본 클라이언트로 POST 요청. http.Post와 동일하게 동작
+//
+// 재시도 시 바디를 다시 전송하기 위해, GetBody를 지원하지 않는 바디는 메모리에 버퍼링됩니다.
+func Post(url, contentType string, body io.Reader) (*http.Response, error) {
+	return Default().Post(url, contentType, body)
+}
+
+// PostForm 기본 클라이언트로 form 데이터를 POST 요청. http.PostForm과 동일하게 동작
+func PostForm(url string, data url.Values) (*http.Response, error) {
+	return Default().PostForm(url, data)
+}
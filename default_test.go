@@ -0,0 +1,55 @@
+package httpretry_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefault(t *testing.T) {
+	t.Run("기본 클라이언트를 지정하지 않은 경우, 재시도 클라이언트를 생성하는 테스트", func(t *testing.T) {
+		// given
+		httpretry.SetDefault(nil)
+
+		// when
+		first := httpretry.Default()
+		second := httpretry.Default()
+
+		// then
+		assert.NotNil(t, first)
+		assert.Same(t, first, second)
+		assert.NotSame(t, http.DefaultClient, first)
+	})
+
+	t.Run("SetDefault로 지정한 클라이언트로 패키지 수준 요청 테스트", func(t *testing.T) {
+		// given
+		reqCount := 0
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reqCount++
+				if reqCount < 2 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+		defer testServer.Close()
+
+		httpretry.SetDefault(httpretry.NewClient(
+			httpretry.NewHTTPSettings(httpretry.WithBackoffPolicy(noBackoff)),
+		))
+		defer httpretry.SetDefault(nil)
+
+		// when
+		resp, err := httpretry.Get(testServer.URL)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 2, reqCount)
+	})
+}
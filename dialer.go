@@ -0,0 +1,57 @@
+package httpretry
+
+import (
+	"context"
+	"net"
+	"syscall"
+	"time"
+)
+
+// DialControl 소켓 생성 직후, 연결 전에 호출되는 함수. net.Dialer.Control과 동일
+type DialControl func(network, address string, c syscall.RawConn) error
+
+// IPPreference 연결에 사용할 IP 주소 체계
+type IPPreference string
+
+const (
+	// DualStack IPv4와 IPv6를 모두 사용 (기본값)
+	DualStack IPPreference = "dual"
+	// IPv4Only IPv4 주소로만 연결
+	IPv4Only IPPreference = "ipv4"
+	// IPv6Only IPv6 주소로만 연결
+	IPv6Only IPPreference = "ipv6"
+)
+
+// newDialer 설정으로 Transport가 사용할 Dialer를 생성
+//
+// http.DefaultTransport와 동일한 연결 타임아웃과 keep-alive를 사용합니다.
+func newDialer(settings *Settings) *net.Dialer {
+	return &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   settings.DialControl,
+		Resolver:  settings.Resolver,
+	}
+}
+
+// newDialContext 설정으로 Transport의 DialContext 함수를 생성
+//
+// IP 주소 체계가 지정된 경우, "tcp" 연결을 "tcp4" 또는 "tcp6"으로 제한합니다.
+func newDialContext(settings *Settings) func(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer := newDialer(settings)
+	var suffix string
+	switch settings.IPPreference {
+	case IPv4Only:
+		suffix = "4"
+	case IPv6Only:
+		suffix = "6"
+	default:
+		return dialer.DialContext
+	}
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		if network == "tcp" {
+			network += suffix
+		}
+		return dialer.DialContext(ctx, network, address)
+	}
+}
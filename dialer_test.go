@@ -0,0 +1,127 @@
+package httpretry_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithDialControl(t *testing.T) {
+	t.Run("연결 시 소켓 옵션 설정 함수를 호출하는 테스트", func(t *testing.T) {
+		// given
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+		defer testServer.Close()
+
+		var dialed []string
+		client := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithDialControl(func(network, address string, c syscall.RawConn) error {
+					dialed = append(dialed, address)
+					return nil
+				}),
+			),
+		)
+
+		// when
+		resp, err := client.Get(testServer.URL)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []string{strings.TrimPrefix(testServer.URL, "http://")}, dialed)
+	})
+
+	t.Run("소켓 옵션 설정 실패 시, 요청 에러 반환 테스트", func(t *testing.T) {
+		// given
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+		defer testServer.Close()
+
+		errControl := errors.New("control failed")
+		client := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(1),
+				httpretry.WithDialControl(func(string, string, syscall.RawConn) error {
+					return errControl
+				}),
+			),
+		)
+
+		// when
+		_, err := client.Get(testServer.URL)
+
+		// then
+		assert.ErrorIs(t, err, errControl)
+	})
+}
+
+func TestWithIPPreference(t *testing.T) {
+	t.Run("IP 주소 체계를 제한하면 다른 주소 체계로 연결하지 않는 테스트", func(t *testing.T) {
+		// given
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+		defer testServer.Close()
+
+		newClient := func(preference httpretry.IPPreference) *http.Client {
+			return httpretry.NewClient(
+				httpretry.NewHTTPSettings(
+					httpretry.WithMaxRetry(1),
+					httpretry.WithIPPreference(preference),
+				),
+			)
+		}
+
+		// when
+		_, ipv4Err := newClient(httpretry.IPv4Only).Get(testServer.URL)
+		_, ipv6Err := newClient(httpretry.IPv6Only).Get(testServer.URL)
+
+		// then
+		assert.NoError(t, ipv4Err)
+		assert.Error(t, ipv6Err, "IPv4 주소의 서버에 IPv6로 연결할 수 없어야 합니다.")
+	})
+}
+
+func TestWithResolver(t *testing.T) {
+	t.Run("지정한 Resolver로 호스트 이름을 조회하는 테스트", func(t *testing.T) {
+		// given
+		resolverCalls := 0
+		resolver := &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+				resolverCalls++
+				return nil, errors.New("dns unavailable")
+			},
+		}
+		client := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(1),
+				httpretry.WithResolver(resolver),
+			),
+		)
+
+		// when
+		_, err := client.Get("http://service.httpretry.test/")
+
+		// then
+		assert.Error(t, err)
+		assert.Positive(t, resolverCalls)
+	})
+}
@@ -0,0 +1,38 @@
+package httpretry
+
+import "net/http"
+
+// Doer 요청을 전송하는 클라이언트 인터페이스
+//
+// *http.Client가 구현하며, Paginator, DoBatch 등 보조 기능은 Doer를 인자로 받으므로 테스트에서 재시도 클라이언트를 대체할 수 있습니다.
+// oapi-codegen의 HttpRequestDoer, openapi-generator의 HTTPClient 등 생성된 클라이언트의 인터페이스와 같은 형태이므로
+// NewClient로 만든 클라이언트를 그대로 넘길 수 있습니다.
+//
+// 재시도 클라이언트의 Do는 다음을 보장하며, 이후 버전에서도 유지합니다.
+//   - 요청 context가 취소되거나 만료되면 진행 중인 시도와 백오프 대기를 중단하고, errors.Is로 context 에러를 확인할 수 있는 에러를 반환
+//   - context의 기한은 모든 시도와 백오프 대기를 포함한 전체 요청 시간을 제한
+//   - 요청 바디는 GetBody로 시도마다 다시 읽으며, 전달한 요청은 변경하지 않음
+//   - 에러를 반환하면 응답은 nil이며, 응답을 반환하면 호출자가 응답 바디를 닫아야 함
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc 함수를 Doer로 사용하는 어댑터
+//
+// 생성된 클라이언트에 넘기기 전에 요청을 기록하거나, 테스트에서 응답을 고정할 때 사용합니다.
+//
+//	doer := httpretry.DoerFunc(func(req *http.Request) (*http.Response, error) {
+//		log.Printf("%s %s", req.Method, req.URL)
+//		return client.Do(req)
+//	})
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do 함수를 호출
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+var (
+	_ Doer = (*http.Client)(nil)
+	_ Doer = DoerFunc(nil)
+)
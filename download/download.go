@@ -0,0 +1,423 @@
+// Package download httpretry 클라이언트로 큰 파일을 범위(Range)별로 나누어 병렬로 다운로드합니다
+//
+// 각 청크는 재시도 클라이언트로 요청하며, 응답 바디를 읽는 중에 연결이 끊기면
+// 해당 청크만 이미 받은 위치부터 다시 요청합니다. WithHeadPreflight를 사용하면 다시 요청하기 전에
+// HEAD로 서버 상태와 파일 변경 여부를 확인합니다.
+// 서버가 Range 요청을 지원하지 않거나 크기를 알 수 없으면 한 번의 요청으로 다운로드합니다.
+package download
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dings-things/httpretry"
+)
+
+const (
+	// DefaultChunkSize 기본 청크 크기 (8MiB)
+	DefaultChunkSize = 8 << 20
+	// DefaultConcurrency 기본 동시 다운로드 청크 수
+	DefaultConcurrency = 4
+	// DefaultChunkRetries 청크별 기본 재요청 횟수 (바디 수신 실패)
+	DefaultChunkRetries = 3
+)
+
+var (
+	// ErrChecksumMismatch 다운로드한 파일의 체크섬이 기대값과 다른 경우
+	ErrChecksumMismatch = errors.New("download: checksum mismatch")
+	// ErrResourceChanged 다운로드 중에 서버의 파일이 변경된 경우
+	ErrResourceChanged = errors.New("download: resource changed during download")
+)
+
+type (
+	// Option Downloader 설정 함수
+	Option func(*Downloader)
+
+	// Downloader 청크 단위 병렬 다운로드를 수행
+	Downloader struct {
+		client       httpretry.Doer
+		chunkSize    int64
+		concurrency  int
+		chunkRetries int
+		preflight    bool
+		newHash      func() hash.Hash
+		checksum     []byte
+	}
+
+	// resource 다운로드 대상 파일 정보
+	resource struct {
+		size int64
+		// validator If-Range 헤더에 사용하는 strong ETag 또는 Last-Modified
+		validator string
+		ranges    bool
+	}
+)
+
+// WithChunkSize 청크 크기를 설정하는 Option
+//
+// Parameters:
+//   - size: (int64) 청크 크기 (bytes)
+func WithChunkSize(size int64) Option {
+	return func(d *Downloader) {
+		d.chunkSize = size
+	}
+}
+
+// WithConcurrency 동시에 다운로드할 청크 수를 설정하는 Option
+//
+// Parameters:
+//   - concurrency: (int) 최대 동시 다운로드 수
+func WithConcurrency(concurrency int) Option {
+	return func(d *Downloader) {
+		d.concurrency = concurrency
+	}
+}
+
+// WithChunkRetries 청크 바디 수신에 실패한 경우 다시 요청할 횟수를 설정하는 Option
+//
+// 응답을 받기 전의 실패(연결 실패, 5xx 등)는 클라이언트의 재시도 정책으로 처리됩니다.
+//
+// Parameters:
+//   - retries: (int) 청크별 재요청 횟수
+func WithChunkRetries(retries int) Option {
+	return func(d *Downloader) {
+		d.chunkRetries = retries
+	}
+}
+
+// WithHeadPreflight 바디 수신에 실패한 뒤 다시 요청하기 전에 HEAD 요청으로 서버를 확인하는 Option
+//
+// 서버가 불안정한 동안 큰 파일(Range를 지원하지 않는 경우 전체 파일)을 반복해서 다시 받지 않도록,
+// HEAD 요청이 성공하고 ETag(또는 Last-Modified)와 크기가 처음과 같은 경우에만 다시 요청합니다.
+// HEAD 요청은 클라이언트의 재시도 정책을 따르며, 실패하면 다시 요청하지 않고 에러를 반환합니다.
+// 파일이 변경된 경우 ErrResourceChanged를 반환합니다.
+func WithHeadPreflight() Option {
+	return func(d *Downloader) {
+		d.preflight = true
+	}
+}
+
+// WithChecksum 다운로드가 끝난 뒤 파일 전체의 체크섬을 검증하는 Option
+//
+// 다운로드 대상이 io.ReaderAt을 구현해야 합니다. (*os.File 등)
+//
+//	download.WithChecksum(sha256.New, expected)
+//
+// Parameters:
+//   - newHash: (func() hash.Hash) 해시 생성 함수
+//   - checksum: ([]byte) 기대하는 체크섬
+func WithChecksum(newHash func() hash.Hash, checksum []byte) Option {
+	return func(d *Downloader) {
+		d.newHash = newHash
+		d.checksum = checksum
+	}
+}
+
+// New Downloader를 생성
+//
+// Parameters:
+//   - client: (httpretry.Doer) 요청에 사용할 클라이언트. 보통 httpretry.NewClient로 생성한 클라이언트
+//   - opts: (...Option) 다운로드 설정
+func New(client httpretry.Doer, opts ...Option) *Downloader {
+	d := &Downloader{
+		client:       client,
+		chunkSize:    DefaultChunkSize,
+		concurrency:  DefaultConcurrency,
+		chunkRetries: DefaultChunkRetries,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	if d.chunkSize <= 0 {
+		d.chunkSize = DefaultChunkSize
+	}
+	if d.concurrency <= 0 {
+		d.concurrency = 1
+	}
+	return d
+}
+
+// DownloadFile url의 파일을 path에 다운로드
+//
+// path.part 파일에 다운로드한 뒤 검증이 끝나면 path로 이름을 변경하므로, 실패 시 path에 불완전한 파일이 남지 않습니다.
+func (d *Downloader) DownloadFile(ctx context.Context, url, path string) error {
+	part := path + ".part"
+	file, err := os.Create(part)
+	if err != nil {
+		return fmt.Errorf("download: creating file: %w", err)
+	}
+
+	_, err = d.Download(ctx, url, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(part)
+		return err
+	}
+	return os.Rename(part, path)
+}
+
+// Download url의 파일을 dst에 다운로드하고 받은 크기를 반환
+//
+// 청크는 순서와 관계없이 각자의 위치에 기록됩니다.
+// 다운로드 중 서버의 파일이 변경되면(If-Range 불일치) ErrResourceChanged를 반환합니다.
+func (d *Downloader) Download(ctx context.Context, url string, dst io.WriterAt) (int64, error) {
+	res, err := d.probe(ctx, url)
+	if err != nil {
+		return 0, err
+	}
+
+	var size int64
+	if res.ranges && res.size > 0 {
+		size, err = res.size, d.downloadChunks(ctx, url, res, dst)
+	} else {
+		size, err = d.downloadWhole(ctx, url, res, dst)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return size, d.verify(dst, size)
+}
+
+// probe HEAD 요청으로 파일 크기와 Range 지원 여부를 확인
+func (d *Downloader) probe(ctx context.Context, url string) (resource, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return resource{}, fmt.Errorf("download: creating request: %w", err)
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return resource{}, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resource{}, fmt.Errorf("download: unexpected status %d", resp.StatusCode)
+	}
+
+	validator := resp.Header.Get("ETag")
+	if validator == "" || strings.HasPrefix(validator, "W/") {
+		validator = resp.Header.Get("Last-Modified")
+	}
+	return resource{
+		size:      resp.ContentLength,
+		validator: validator,
+		ranges:    resp.Header.Get("Accept-Ranges") == "bytes",
+	}, nil
+}
+
+// downloadChunks 청크를 병렬로 다운로드. 한 청크라도 실패하면 나머지를 취소하고 첫 에러를 반환
+func (d *Downloader) downloadChunks(ctx context.Context, url string, res resource, dst io.WriterAt) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	semaphore := make(chan struct{}, d.concurrency)
+	for start := int64(0); start < res.size; start += d.chunkSize {
+		select {
+		case <-ctx.Done():
+		case semaphore <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		end := min(start+d.chunkSize, res.size) - 1
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			if err := d.fetchChunk(ctx, url, res, dst, start, end); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// fetchChunk start~end(포함) 범위를 다운로드. 바디 수신에 실패하면 받은 위치부터 다시 요청
+func (d *Downloader) fetchChunk(ctx context.Context, url string, res resource, dst io.WriterAt, start, end int64) error {
+	offset := start
+	var lastErr error
+	for attempt := 0; attempt <= d.chunkRetries; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if attempt > 0 {
+			if err := d.checkPreflight(ctx, url, res); err != nil {
+				return err
+			}
+		}
+		n, err := d.fetchRange(ctx, url, res, dst, offset, end)
+		offset += n
+		if err == nil {
+			return nil
+		}
+		if errors.Is(err, ErrResourceChanged) {
+			return err
+		}
+		lastErr = err
+	}
+	return fmt.Errorf("download: chunk %d-%d: %w", start, end, lastErr)
+}
+
+// fetchRange offset~end(포함) 범위를 요청하여 dst에 기록하고, 기록한 크기를 반환
+func (d *Downloader) fetchRange(ctx context.Context, url string, res resource, dst io.WriterAt, offset, end int64) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, end))
+	if res.validator != "" {
+		req.Header.Set("If-Range", res.validator)
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		// If-Range가 일치하지 않으면 서버는 전체 파일을 200으로 응답
+		return 0, ErrResourceChanged
+	case resp.StatusCode != http.StatusPartialContent:
+		return 0, fmt.Errorf("download: unexpected status %d", resp.StatusCode)
+	}
+	if start, total, ok := parseContentRange(resp.Header.Get("Content-Range")); !ok || start != offset {
+		return 0, fmt.Errorf("download: unexpected Content-Range %q", resp.Header.Get("Content-Range"))
+	} else if total >= 0 && total != res.size {
+		return 0, ErrResourceChanged
+	}
+
+	want := end - offset + 1
+	n, err := io.Copy(io.NewOffsetWriter(dst, offset), io.LimitReader(resp.Body, want))
+	if err == nil && n < want {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+// downloadWhole Range를 지원하지 않는 서버에서 한 번의 요청으로 다운로드. 실패하면 처음부터 다시 요청
+func (d *Downloader) downloadWhole(ctx context.Context, url string, res resource, dst io.WriterAt) (int64, error) {
+	var lastErr error
+	for attempt := 0; attempt <= d.chunkRetries; attempt++ {
+		if attempt > 0 {
+			if err := d.checkPreflight(ctx, url, res); err != nil {
+				return 0, err
+			}
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return 0, err
+		}
+		resp, err := d.client.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			resp.Body.Close()
+			return 0, fmt.Errorf("download: unexpected status %d", resp.StatusCode)
+		}
+
+		n, err := io.Copy(io.NewOffsetWriter(dst, 0), resp.Body)
+		resp.Body.Close()
+		if err == nil && resp.ContentLength >= 0 && n != resp.ContentLength {
+			err = io.ErrUnexpectedEOF
+		}
+		if err == nil {
+			return n, nil
+		}
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+		lastErr = err
+	}
+	return 0, fmt.Errorf("download: %w", lastErr)
+}
+
+// checkPreflight WithHeadPreflight가 설정된 경우 HEAD 요청으로 서버 상태와 파일 변경 여부를 확인
+func (d *Downloader) checkPreflight(ctx context.Context, url string, res resource) error {
+	if !d.preflight {
+		return nil
+	}
+	current, err := d.probe(ctx, url)
+	if err != nil {
+		return fmt.Errorf("download: preflight before retrying: %w", err)
+	}
+	if current.validator != res.validator || (current.size >= 0 && res.size >= 0 && current.size != res.size) {
+		return ErrResourceChanged
+	}
+	return nil
+}
+
+// verify 체크섬이 설정된 경우 기록된 파일을 다시 읽어 검증
+func (d *Downloader) verify(dst io.WriterAt, size int64) error {
+	if d.newHash == nil {
+		return nil
+	}
+	reader, ok := dst.(io.ReaderAt)
+	if !ok {
+		return errors.New("download: checksum verification requires an io.ReaderAt destination")
+	}
+
+	h := d.newHash()
+	if _, err := io.Copy(h, io.NewSectionReader(reader, 0, size)); err != nil {
+		return fmt.Errorf("download: reading for verification: %w", err)
+	}
+	if sum := h.Sum(nil); !bytes.Equal(sum, d.checksum) {
+		return fmt.Errorf("%w: got %x, want %x", ErrChecksumMismatch, sum, d.checksum)
+	}
+	return nil
+}
+
+// parseContentRange "bytes start-end/total" 형식의 Content-Range에서 시작 위치와 전체 크기를 반환. 전체 크기가 *인 경우 -1
+func parseContentRange(value string) (int64, int64, bool) {
+	spec, ok := strings.CutPrefix(value, "bytes ")
+	if !ok {
+		return 0, 0, false
+	}
+	byteRange, totalValue, ok := strings.Cut(spec, "/")
+	if !ok {
+		return 0, 0, false
+	}
+	startValue, _, ok := strings.Cut(byteRange, "-")
+	if !ok {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(startValue, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if totalValue == "*" {
+		return start, -1, true
+	}
+	total, err := strconv.ParseInt(totalValue, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, total, true
+}
@@ -0,0 +1,230 @@
+package download_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/dings-things/httpretry/download"
+	"github.com/stretchr/testify/assert"
+)
+
+// abortingWriter limit 바이트까지만 쓰고 연결을 끊는 ResponseWriter
+type abortingWriter struct {
+	http.ResponseWriter
+	limit int
+}
+
+func (w *abortingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.limit {
+		w.ResponseWriter.Write(p[:w.limit])
+		w.ResponseWriter.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}
+	w.limit -= len(p)
+	return w.ResponseWriter.Write(p)
+}
+
+func TestDownloader(t *testing.T) {
+	content := make([]byte, 100<<10)
+	rand.Read(content)
+	checksum := sha256.Sum256(content)
+	modified := time.Now()
+
+	t.Run("청크를 병렬로 받고, 중간에 끊긴 청크만 받은 위치부터 다시 요청하는 테스트", func(t *testing.T) {
+		// given
+		var (
+			mu      sync.Mutex
+			aborted bool
+			ranges  []string
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			ranges = append(ranges, r.Header.Get("Range"))
+			abort := !aborted && strings.HasPrefix(r.Header.Get("Range"), "bytes=16384-")
+			aborted = aborted || abort
+			mu.Unlock()
+			w.Header().Set("ETag", `"v1"`)
+			if abort {
+				w = &abortingWriter{ResponseWriter: w, limit: 100}
+			}
+			http.ServeContent(w, r, "file", modified, bytes.NewReader(content))
+		}))
+		defer server.Close()
+		path := filepath.Join(t.TempDir(), "file")
+		downloader := download.New(
+			httpretry.NewClient(httpretry.NewHTTPSettings()),
+			download.WithChunkSize(16<<10),
+			download.WithConcurrency(3),
+			download.WithChecksum(sha256.New, checksum[:]),
+		)
+
+		// when
+		err := downloader.DownloadFile(context.Background(), server.URL, path)
+
+		// then
+		assert.NoError(t, err)
+		downloaded, _ := os.ReadFile(path)
+		assert.Equal(t, content, downloaded)
+		assert.NoFileExists(t, path+".part")
+		assert.Contains(t, ranges, "bytes=16484-32767", "끊긴 청크는 받은 위치부터 다시 요청해야 합니다.")
+	})
+
+	t.Run("체크섬이 다르면 ErrChecksumMismatch를 반환하고 파일을 남기지 않는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.ServeContent(w, r, "file", modified, bytes.NewReader(content))
+		}))
+		defer server.Close()
+		path := filepath.Join(t.TempDir(), "file")
+		downloader := download.New(http.DefaultClient, download.WithChecksum(sha256.New, make([]byte, sha256.Size)))
+
+		// when
+		err := downloader.DownloadFile(context.Background(), server.URL, path)
+
+		// then
+		assert.True(t, errors.Is(err, download.ErrChecksumMismatch))
+		assert.NoFileExists(t, path)
+		assert.NoFileExists(t, path+".part")
+	})
+
+	t.Run("다운로드 중 파일이 변경되면 ErrResourceChanged를 반환하는 테스트", func(t *testing.T) {
+		// given
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&requests, 1) > 2 {
+				w.Header().Set("ETag", `"v2"`)
+			} else {
+				w.Header().Set("ETag", `"v1"`)
+			}
+			http.ServeContent(w, r, "file", modified, bytes.NewReader(content))
+		}))
+		defer server.Close()
+		downloader := download.New(http.DefaultClient, download.WithChunkSize(16<<10), download.WithConcurrency(1))
+
+		// when
+		err := downloader.DownloadFile(context.Background(), server.URL, filepath.Join(t.TempDir(), "file"))
+
+		// then
+		assert.True(t, errors.Is(err, download.ErrResourceChanged))
+	})
+
+	t.Run("Range를 지원하지 않는 서버는 한 번의 요청으로 받는 테스트", func(t *testing.T) {
+		// given
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			w.Write(content)
+		}))
+		defer server.Close()
+		path := filepath.Join(t.TempDir(), "file")
+		downloader := download.New(http.DefaultClient, download.WithChunkSize(16<<10))
+
+		// when
+		err := downloader.DownloadFile(context.Background(), server.URL, path)
+
+		// then
+		assert.NoError(t, err)
+		downloaded, _ := os.ReadFile(path)
+		assert.Equal(t, content, downloaded)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requests), "HEAD 요청과 GET 요청 한 번씩 전송해야 합니다.")
+	})
+	t.Run("HEAD로 서버와 파일을 확인한 뒤 다시 요청하는 테스트", func(t *testing.T) {
+		// given
+		var (
+			mu      sync.Mutex
+			methods []string
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			methods = append(methods, r.Method)
+			abort := len(methods) == 2
+			mu.Unlock()
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			if r.Method == http.MethodHead {
+				return
+			}
+			if abort {
+				w = &abortingWriter{ResponseWriter: w, limit: 100}
+			}
+			w.Write(content)
+		}))
+		defer server.Close()
+		path := filepath.Join(t.TempDir(), "file")
+		downloader := download.New(http.DefaultClient, download.WithHeadPreflight())
+
+		// when
+		err := downloader.DownloadFile(context.Background(), server.URL, path)
+
+		// then
+		assert.NoError(t, err)
+		downloaded, _ := os.ReadFile(path)
+		assert.Equal(t, content, downloaded)
+		assert.Equal(t, []string{http.MethodHead, http.MethodGet, http.MethodHead, http.MethodGet}, methods)
+	})
+
+	// failingPreflight 처음 HEAD와 달리 두 번째 HEAD부터 head로 응답하고, GET은 바디 중간에 끊는 서버
+	failingPreflight := func(head func(w http.ResponseWriter), gets *atomic.Int32) *httptest.Server {
+		var heads atomic.Int32
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodHead {
+				if heads.Add(1) > 1 {
+					head(w)
+					return
+				}
+				w.Header().Set("ETag", `"v1"`)
+				return
+			}
+			gets.Add(1)
+			w.Header().Set("ETag", `"v1"`)
+			(&abortingWriter{ResponseWriter: w, limit: 100}).Write(content)
+		}))
+	}
+
+	t.Run("HEAD 확인에 실패하면 다시 요청하지 않는 테스트", func(t *testing.T) {
+		// given
+		var gets atomic.Int32
+		server := failingPreflight(func(w http.ResponseWriter) { w.WriteHeader(http.StatusServiceUnavailable) }, &gets)
+		defer server.Close()
+		downloader := download.New(
+			httpretry.NewClient(httpretry.NewHTTPSettings(httpretry.WithMaxRetry(1))),
+			download.WithHeadPreflight(),
+		)
+
+		// when
+		err := downloader.DownloadFile(context.Background(), server.URL, filepath.Join(t.TempDir(), "file"))
+
+		// then
+		assert.ErrorContains(t, err, "download: preflight before retrying")
+		assert.Equal(t, int32(1), gets.Load())
+	})
+
+	t.Run("HEAD 확인에서 파일이 변경되면 ErrResourceChanged를 반환하는 테스트", func(t *testing.T) {
+		// given
+		var gets atomic.Int32
+		server := failingPreflight(func(w http.ResponseWriter) { w.Header().Set("ETag", `"v2"`) }, &gets)
+		defer server.Close()
+		downloader := download.New(http.DefaultClient, download.WithHeadPreflight())
+
+		// when
+		err := downloader.DownloadFile(context.Background(), server.URL, filepath.Join(t.TempDir(), "file"))
+
+		// then
+		assert.ErrorIs(t, err, download.ErrResourceChanged)
+		assert.Equal(t, int32(1), gets.Load())
+	})
+}
@@ -0,0 +1,151 @@
+package httpretry
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+)
+
+type (
+	// AffinityKey 요청의 세션 키를 반환하는 함수. 같은 키의 요청은 같은 엔드포인트로 전송
+	//
+	// 빈 문자열을 반환하면 세션 고정 없이 분배합니다.
+	AffinityKey func(req *http.Request) string
+
+	// endpointGroup 서비스로 향하는 요청을 여러 엔드포인트로 분배
+	endpointGroup struct {
+		service   *url.URL
+		endpoints []*url.URL
+		affinity  AffinityKey
+		cursor    atomic.Uint64
+		outliers  *outlierDetector
+	}
+
+	// endpointRoute 요청에 선택된 엔드포인트 순서
+	endpointRoute struct {
+		group  *endpointGroup
+		start  int
+		sticky bool
+		index  int // 마지막 시도에 선택된 엔드포인트
+	}
+
+	// endpointIndexKey 시도가 전송된 엔드포인트의 인덱스를 보관하는 context key
+	endpointIndexKey struct{}
+)
+
+// AffinityCookie 쿠키 값을 세션 키로 사용
+//
+// Parameters:
+//   - name: (string) 쿠키 이름
+func AffinityCookie(name string) AffinityKey {
+	return func(req *http.Request) string {
+		cookie, err := req.Cookie(name)
+		if err != nil {
+			return ""
+		}
+		return cookie.Value
+	}
+}
+
+// AffinityHeader 헤더 값을 세션 키로 사용
+//
+// Parameters:
+//   - name: (string) 헤더 이름
+func AffinityHeader(name string) AffinityKey {
+	return func(req *http.Request) string {
+		return req.Header.Get(name)
+	}
+}
+
+// AffinityContext 요청 context의 값을 세션 키로 사용
+//
+// Parameters:
+//   - key: (any) context key. 값은 fmt.Sprint로 문자열로 변환
+func AffinityContext(key any) AffinityKey {
+	return func(req *http.Request) string {
+		value := req.Context().Value(key)
+		if value == nil {
+			return ""
+		}
+		return fmt.Sprint(value)
+	}
+}
+
+// newEndpointGroup 엔드포인트 분배 설정을 생성. 분배하지 않는 경우 nil을 반환
+func newEndpointGroup(settings *Settings) *endpointGroup {
+	if settings.EndpointService == "" || len(settings.Endpoints) == 0 {
+		return nil
+	}
+	service, err := url.Parse(settings.EndpointService)
+	if err != nil {
+		newLogger(settings).Debug("endpoint routing disabled. invalid service url", "url", settings.EndpointService, "error", err)
+		return nil
+	}
+	group := &endpointGroup{service: service, affinity: settings.Affinity}
+	for _, endpoint := range settings.Endpoints {
+		endpointURL, err := url.Parse(endpoint)
+		if err != nil {
+			newLogger(settings).Debug("endpoint routing disabled. invalid endpoint", "url", endpoint, "error", err)
+			return nil
+		}
+		group.endpoints = append(group.endpoints, endpointURL)
+	}
+	group.outliers = newOutlierDetector(settings.OutlierDetection, group)
+	return group
+}
+
+// route 요청의 엔드포인트 순서를 결정. 서비스로 향하는 요청이 아니면 nil을 반환
+//
+// 세션 키가 있으면 키의 해시로 엔드포인트를 고정하고, 없으면 요청마다 다음 엔드포인트부터 시작합니다.
+func (g *endpointGroup) route(req *http.Request) *endpointRoute {
+	if g == nil || req.URL.Scheme != g.service.Scheme || req.URL.Host != g.service.Host {
+		return nil
+	}
+	if g.affinity != nil {
+		if key := g.affinity(req); key != "" {
+			hash := fnv.New32a()
+			hash.Write([]byte(key))
+			return &endpointRoute{group: g, start: int(hash.Sum32() % uint32(len(g.endpoints))), sticky: true}
+		}
+	}
+	return &endpointRoute{group: g, start: int((g.cursor.Add(1) - 1) % uint64(len(g.endpoints)))}
+}
+
+// target 시도에 사용할 엔드포인트로 scheme과 host를 변경한 URL을 반환
+//
+// 세션이 고정된 요청은 재시도도 같은 엔드포인트로 보내고, 그 외에는 시도마다 다음 엔드포인트를 사용합니다.
+// 장애로 제외된 엔드포인트는 건너뛰며, 세션이 고정된 엔드포인트도 제외되면 다음 엔드포인트를 사용합니다.
+func (r *endpointRoute) target(u *url.URL, attempt int) *url.URL {
+	index := r.start
+	if !r.sticky {
+		index = (r.start + attempt - 1) % len(r.group.endpoints)
+	}
+	for i := range len(r.group.endpoints) {
+		if candidate := (index + i) % len(r.group.endpoints); r.group.outliers.available(candidate) {
+			index = candidate
+			break
+		}
+	}
+	r.index = index
+	endpoint := r.group.endpoints[index]
+	target := *u
+	target.Scheme = endpoint.Scheme
+	target.Host = endpoint.Host
+	return &target
+}
+
+// withEndpoint 마지막 시도에 선택된 엔드포인트를 시도 context에 기록
+//
+// 같은 host의 엔드포인트(scheme, port, 경로가 다른 경우)를 구분하여 장애를 추적하기 위해 인덱스를 사용합니다.
+func (r *endpointRoute) withEndpoint(ctx context.Context) context.Context {
+	return context.WithValue(ctx, endpointIndexKey{}, r.index)
+}
+
+// endpointIndex 시도 context에 기록된 엔드포인트 인덱스를 반환
+func endpointIndex(ctx context.Context) (int, bool) {
+	index, ok := ctx.Value(endpointIndexKey{}).(int)
+	return index, ok
+}
@@ -0,0 +1,87 @@
+package httpretry_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithEndpoints(t *testing.T) {
+	newServer := func(name string, status int) *httptest.Server {
+		return httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(status)
+				w.Write([]byte(name))
+			}),
+		)
+	}
+	get := func(t *testing.T, client *http.Client, req *http.Request) string {
+		resp, err := client.Do(req)
+		if !assert.NoError(t, err) {
+			return ""
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	t.Run("요청을 엔드포인트로 분배하고, 실패한 시도는 다음 엔드포인트로 재시도하는 테스트", func(t *testing.T) {
+		// given
+		failing := newServer("a", http.StatusServiceUnavailable)
+		defer failing.Close()
+		healthy := newServer("b", http.StatusOK)
+		defer healthy.Close()
+
+		client := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithEndpoints("http://orders", failing.URL, healthy.URL),
+			),
+		)
+
+		// when
+		var bodies []string
+		for range 4 {
+			req, _ := http.NewRequest(http.MethodGet, "http://orders/v1/orders", nil)
+			bodies = append(bodies, get(t, client, req))
+		}
+
+		// then
+		assert.Equal(t, []string{"b", "b", "b", "b"}, bodies)
+	})
+
+	t.Run("세션 키가 같은 요청은 같은 엔드포인트로 보내는 테스트", func(t *testing.T) {
+		// given
+		a := newServer("a", http.StatusOK)
+		defer a.Close()
+		b := newServer("b", http.StatusOK)
+		defer b.Close()
+
+		client := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithEndpoints("http://orders", a.URL, b.URL),
+				httpretry.WithAffinity(httpretry.AffinityHeader("X-User-Id")),
+			),
+		)
+
+		// when
+		seen := make(map[string]map[string]struct{})
+		for _, user := range []string{"alice", "bob", "carol"} {
+			seen[user] = make(map[string]struct{})
+			for range 3 {
+				req, _ := http.NewRequest(http.MethodGet, "http://orders/v1/orders", nil)
+				req.Header.Set("X-User-Id", user)
+				seen[user][get(t, client, req)] = struct{}{}
+			}
+		}
+
+		// then
+		for user, backends := range seen {
+			assert.Len(t, backends, 1, "%s의 요청은 같은 엔드포인트로 보내야 합니다.", user)
+		}
+	})
+}
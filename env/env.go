@@ -0,0 +1,193 @@
+// Package env 환경 변수, 설정 파일, 플래그 등에서 httpretry.Settings를 읽는 공급자
+//
+// 값은 httpretry.Settings의 env 태그 이름을 키로 사용하며, 이 모듈만 Netflix/go-env에 의존하므로
+// 코드로 설정을 구성하는 애플리케이션은 httpretry 모듈만 사용할 수 있습니다.
+//
+//	settings := env.NewSettings() // 환경 변수만 사용
+//	client := httpretry.NewClient(settings)
+package env
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/Netflix/go-env"
+	"github.com/dings-things/httpretry"
+)
+
+type (
+	// Provider 설정 값을 읽어 오는 공급자
+	//
+	// 값은 httpretry.Settings의 env 태그 이름(MAX_REQUEST_RETRY, HEADER_TIMEOUT 등)을 키로, 환경 변수와 같은 문자열 형식으로 반환합니다.
+	// 원격 KV 저장소 등은 ProviderFunc로 구현합니다.
+	Provider interface {
+		Load(ctx context.Context) (map[string]string, error)
+	}
+
+	// ProviderFunc 함수를 Provider로 사용
+	ProviderFunc func(ctx context.Context) (map[string]string, error)
+)
+
+// Load 함수를 호출
+func (f ProviderFunc) Load(ctx context.Context) (map[string]string, error) {
+	return f(ctx)
+}
+
+// NewSettings 환경 변수로 설정을 생성
+//
+// 환경 변수로 만든 설정이 유효하지 않으면(Settings.Validate) 시작 시점에 종료합니다.
+// 파일, 플래그 등 다른 공급자와 함께 읽으려면 LoadSettings를 사용합니다.
+func NewSettings() *httpretry.Settings {
+	settings, err := LoadSettings(context.Background(), EnvProvider())
+	if err != nil {
+		log.Fatal(err)
+	}
+	return settings
+}
+
+// LoadSettings 여러 공급자의 값을 순서대로 덮어써 설정을 생성
+//
+// 뒤에 지정한 공급자의 값이 우선하며, 어느 공급자도 지정하지 않은 값은 env 태그의 기본값을 사용합니다.
+// 만든 설정은 httpretry.Settings.Validate로 검사합니다.
+//
+//	settings, err := env.LoadSettings(ctx,
+//		env.EnvProvider(),                  // 가장 낮은 우선순위
+//		env.FileProvider("httpretry.env"),
+//		env.FlagProvider(flag.CommandLine), // 가장 높은 우선순위
+//	)
+//
+// Parameters:
+//   - ctx: (context.Context) 공급자의 읽기를 제한하는 context
+//   - providers: (...Provider) 우선순위가 낮은 것부터 나열한 공급자
+func LoadSettings(ctx context.Context, providers ...Provider) (*httpretry.Settings, error) {
+	values := make(env.EnvSet)
+	for i, provider := range providers {
+		loaded, err := provider.Load(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("loading settings from provider %d: %w", i, err)
+		}
+		maps.Copy(values, loaded)
+	}
+
+	splitLists(values)
+
+	var settings httpretry.Settings
+	if err := env.Unmarshal(values, &settings); err != nil {
+		return nil, fmt.Errorf("parsing settings: %w", err)
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// listKeys separator=, 옵션을 지정한 목록 설정의 키 (RETRY_METHODS, PROXIES 등)
+var listKeys = func() []string {
+	var keys []string
+	settings := reflect.TypeFor[httpretry.Settings]()
+	for i := range settings.NumField() {
+		tag := settings.Field(i).Tag.Get("env")
+		if strings.HasSuffix(tag, ",separator=,") {
+			keys = append(keys, strings.Split(tag, ",")[0])
+		}
+	}
+	return keys
+}()
+
+// splitLists 쉼표로 구분한 목록 설정 값을 go-env의 구분자('|')로 변환
+//
+// go-env는 태그 옵션을 ','로 나누므로 separator=, 옵션을 해석하지 못하고 기본 구분자 '|'로 값을 나눕니다.
+// "GET, HEAD"처럼 항목 앞뒤의 공백은 제거하며, '|'로 구분한 값도 그대로 사용할 수 있습니다.
+func splitLists(values env.EnvSet) {
+	for _, key := range listKeys {
+		value, ok := values[key]
+		if !ok {
+			continue
+		}
+		items := strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '|' })
+		for i, item := range items {
+			items[i] = strings.TrimSpace(item)
+		}
+		values[key] = strings.Join(items, "|")
+	}
+}
+
+// EnvProvider 환경 변수를 읽는 공급자
+func EnvProvider() Provider {
+	return ProviderFunc(func(context.Context) (map[string]string, error) {
+		return env.EnvironToEnvSet(os.Environ())
+	})
+}
+
+// FileProvider "KEY=VALUE" 형식의 파일(.env)을 읽는 공급자
+//
+// 빈 줄과 #으로 시작하는 줄은 무시하며, 값을 감싼 따옴표는 제거합니다.
+//
+// Parameters:
+//   - path: (string) 설정 파일 경로
+func FileProvider(path string) Provider {
+	return ProviderFunc(func(context.Context) (map[string]string, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading settings file: %w", err)
+		}
+		return parseEnvFile(data)
+	})
+}
+
+// FlagProvider 명령행에서 지정한 플래그를 읽는 공급자
+//
+// 플래그 이름은 대문자로 바꾸고 '-'를 '_'로 바꾸어 키로 사용합니다 (-max-request-retry → MAX_REQUEST_RETRY).
+// 명령행에서 지정하지 않은 플래그는 다른 공급자의 값을 덮어쓰지 않습니다.
+//
+// Parameters:
+//   - flags: (*flag.FlagSet) 해석을 마친 플래그
+func FlagProvider(flags *flag.FlagSet) Provider {
+	return ProviderFunc(func(context.Context) (map[string]string, error) {
+		values := make(map[string]string)
+		flags.Visit(func(f *flag.Flag) {
+			values[strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))] = f.Value.String()
+		})
+		return values, nil
+	})
+}
+
+// MapProvider 고정된 값을 반환하는 공급자. 코드에서 지정하는 기본값 등에 사용
+//
+// Parameters:
+//   - values: (map[string]string) env 태그 이름을 키로 한 설정 값
+func MapProvider(values map[string]string) Provider {
+	return ProviderFunc(func(context.Context) (map[string]string, error) {
+		return maps.Clone(values), nil
+	})
+}
+
+// parseEnvFile "KEY=VALUE" 형식의 줄을 해석
+func parseEnvFile(data []byte) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(text, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("settings file line %d: must be KEY=VALUE, got %q", line, text)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[strings.TrimSpace(key)] = value
+	}
+	return values, scanner.Err()
+}
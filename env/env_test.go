@@ -0,0 +1,148 @@
+package env_test
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/dings-things/httpretry/env"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSettings(t *testing.T) {
+	t.Run("뒤에 지정한 공급자의 값이 앞의 값을 덮어쓰는 테스트", func(t *testing.T) {
+		// given
+		path := filepath.Join(t.TempDir(), "httpretry.env")
+		os.WriteFile(path, []byte("# 서비스 공통 설정\nMAX_REQUEST_RETRY=4\nHEADER_TIMEOUT=\"3s\"\n\nINSECURE='true'\n"), 0o600)
+		flags := flag.NewFlagSet("test", flag.ContinueOnError)
+		flags.Int("max-request-retry", 1, "")
+		flags.Duration("header-timeout", time.Second, "")
+		flags.Parse([]string{"-max-request-retry=5"})
+
+		// when
+		settings, err := env.LoadSettings(context.Background(),
+			env.MapProvider(map[string]string{"MAX_REQUEST_RETRY": "2", "TLS_TIMEOUT": "7s"}),
+			env.FileProvider(path),
+			env.FlagProvider(flags),
+		)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, 5, settings.MaxRetry, "플래그가 파일보다 우선")
+		assert.Equal(t, 3*time.Second, settings.ResponseHeaderTimeout, "지정하지 않은 플래그는 파일 값을 유지")
+		assert.True(t, settings.Insecure)
+		assert.Equal(t, 7*time.Second, settings.TLSHandshakeTimeout)
+		assert.Equal(t, 15, settings.MaxIdleConns, "어느 공급자도 지정하지 않은 값은 기본값")
+	})
+
+	t.Run("환경 변수 공급자로 설정을 읽는 테스트", func(t *testing.T) {
+		// given
+		t.Setenv("MAX_REQUEST_RETRY", "6")
+
+		// when
+		settings, err := env.LoadSettings(context.Background(), env.EnvProvider())
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, 6, settings.MaxRetry)
+		assert.Equal(t, 6, env.NewSettings().MaxRetry)
+	})
+
+	t.Run("유효하지 않은 설정과 공급자 에러를 반환하는 테스트", func(t *testing.T) {
+		// given
+		errUnavailable := errors.New("kv unavailable")
+		remote := env.ProviderFunc(func(context.Context) (map[string]string, error) {
+			return nil, errUnavailable
+		})
+		tests := []struct {
+			name      string
+			providers []env.Provider
+			target    error
+		}{
+			{"유효하지 않은 값", []env.Provider{env.MapProvider(map[string]string{"MAX_REQUEST_RETRY": "0"})}, httpretry.ErrInvalidSettings},
+			{"원격 공급자 실패", []env.Provider{env.EnvProvider(), remote}, errUnavailable},
+			{"없는 설정 파일", []env.Provider{env.FileProvider(filepath.Join(t.TempDir(), "missing.env"))}, os.ErrNotExist},
+		}
+
+		for _, tt := range tests {
+			// when
+			settings, err := env.LoadSettings(context.Background(), tt.providers...)
+
+			// then
+			assert.Nil(t, settings, tt.name)
+			assert.ErrorIs(t, err, tt.target, tt.name)
+		}
+	})
+
+	t.Run("KEY=VALUE 형식이 아닌 줄이 있으면 에러를 반환하는 테스트", func(t *testing.T) {
+		// given
+		path := filepath.Join(t.TempDir(), "httpretry.env")
+		os.WriteFile(path, []byte("MAX_REQUEST_RETRY\n"), 0o600)
+
+		// when
+		_, err := env.LoadSettings(context.Background(), env.FileProvider(path))
+
+		// then
+		assert.ErrorContains(t, err, "settings file line 1: must be KEY=VALUE")
+	})
+
+	t.Run("쉼표로 구분한 목록 설정을 읽는 테스트", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			values map[string]string
+			assert func(t *testing.T, settings *httpretry.Settings)
+		}{
+			{
+				name:   "재시도 메서드",
+				values: map[string]string{"RETRY_METHODS": "GET, HEAD", "NO_RETRY_METHODS": "POST|PATCH"},
+				assert: func(t *testing.T, settings *httpretry.Settings) {
+					assert.Equal(t, []string{"GET", "HEAD"}, settings.RetryMethods)
+					assert.Equal(t, []string{"POST", "PATCH"}, settings.NoRetryMethods)
+				},
+			},
+			{
+				name:   "제외할 재시도 상태 코드",
+				values: map[string]string{"NO_RETRY_STATUS_CODES": "429,425"},
+				assert: func(t *testing.T, settings *httpretry.Settings) {
+					assert.Equal(t, []int{429, 425}, settings.NoRetryStatusCodes)
+				},
+			},
+			{
+				name:   "예열할 호스트",
+				values: map[string]string{"WARMUP_HOSTS": "api.example.com, auth.example.com"},
+				assert: func(t *testing.T, settings *httpretry.Settings) {
+					assert.Equal(t, []string{"api.example.com", "auth.example.com"}, settings.WarmupHosts)
+				},
+			},
+			{
+				name:   "프록시",
+				values: map[string]string{"PROXIES": "http://proxy-a:3128,http://proxy-b:3128"},
+				assert: func(t *testing.T, settings *httpretry.Settings) {
+					assert.Equal(t, []string{"http://proxy-a:3128", "http://proxy-b:3128"}, settings.Proxies)
+				},
+			},
+			{
+				name:   "분배할 엔드포인트",
+				values: map[string]string{"ENDPOINT_SERVICE": "http://orders", "ENDPOINTS": "http://10.0.0.1:8080,http://10.0.0.2:8080"},
+				assert: func(t *testing.T, settings *httpretry.Settings) {
+					assert.Equal(t, []string{"http://10.0.0.1:8080", "http://10.0.0.2:8080"}, settings.Endpoints)
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			// when
+			settings, err := env.LoadSettings(context.Background(), env.MapProvider(tt.values))
+
+			// then
+			if assert.NoError(t, err, tt.name) {
+				tt.assert(t, settings)
+			}
+		}
+	})
+}
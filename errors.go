@@ -0,0 +1,298 @@
+package httpretry
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// timeoutError 시도별 타임아웃, 전체 타임아웃 에러
+//
+// errors.Is(err, context.DeadlineExceeded)로 확인할 수 있으며, net.Error와 같이 Timeout()이 true를 반환합니다.
+type timeoutError struct {
+	msg string
+}
+
+// Error 타임아웃 메시지를 반환
+func (e *timeoutError) Error() string {
+	return e.msg
+}
+
+// Timeout 타임아웃 에러임을 나타냄
+func (e *timeoutError) Timeout() bool {
+	return true
+}
+
+// Is context.DeadlineExceeded와 같은 에러로 취급
+func (e *timeoutError) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+// AttemptError 시도 1회의 실패 정보
+//
+// 클라이언트가 반환하는 에러에 시도별로 포함되며, errors.As로 꺼낼 수 있습니다.
+// 에러 메시지만으로 재시도 과정을 알 수 있도록 요청 메서드, URL, 소요 시간, 대기 시간을 포함합니다.
+type AttemptError struct {
+	// Attempt 시도 번호 (1부터 시작)
+	Attempt int
+	// Method 요청 메서드
+	Method string
+	// URL 시도에서 요청한 URL. 비밀번호와 쿼리 값은 가려짐
+	URL string
+	// StatusCode 응답 상태 코드. 응답을 받지 못한 경우 -1
+	StatusCode int
+	// Start 시도를 시작한 시각
+	Start time.Time
+	// Duration 시도에 걸린 시간
+	Duration time.Duration
+	// Backoff 다음 시도 전 대기 시간. 바로 재시도하거나 재시도하지 않는 경우 0
+	Backoff time.Duration
+	// RetryAfter 응답의 Retry-After 헤더로 정한 대기 시간. 헤더가 없는 경우 0
+	RetryAfter time.Duration
+	// Timings 구간별 소요 시간. Settings.AttemptTimings가 꺼져 있으면 0
+	Timings AttemptTimings
+	// Response 실패한 응답의 사본. Settings.CaptureAttempts가 0이거나 응답을 받지 못한 경우 nil
+	Response *CapturedResponse
+	// Err 시도가 실패한 원인
+	Err error
+}
+
+// Error 시도 번호, 요청, 실패 원인, 소요 시간을 반환
+func (e *AttemptError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "attempt(%d)", e.Attempt)
+	if e.Method != "" {
+		fmt.Fprintf(&b, " %s %s", e.Method, e.URL)
+	}
+	fmt.Fprintf(&b, ": %v (took %s", e.Err, e.Duration.Round(time.Millisecond))
+	if e.Backoff > 0 {
+		fmt.Fprintf(&b, ", backoff %s", e.Backoff)
+	}
+	if e.RetryAfter > 0 {
+		fmt.Fprintf(&b, ", retry after %s", e.RetryAfter)
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+// Unwrap 실패 원인을 반환
+func (e *AttemptError) Unwrap() error {
+	return e.Err
+}
+
+// redactURL 에러 메시지에 남길 URL. 비밀번호와 쿼리 값은 토큰일 수 있으므로 가림
+func redactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	redacted := *u
+	if redacted.RawQuery != "" {
+		query := redacted.Query()
+		for key := range query {
+			query[key] = []string{"xxxxx"}
+		}
+		redacted.RawQuery = query.Encode()
+	}
+	return redacted.Redacted()
+}
+
+// Reason 재시도를 포기한 이유
+type Reason string
+
+const (
+	// ReasonBudgetExhausted 최대 재시도 횟수를 모두 사용
+	ReasonBudgetExhausted Reason = "budget_exhausted"
+	// ReasonTimeout 시도 타임아웃 후 재시도할 수 없거나, 전체 타임아웃을 초과
+	ReasonTimeout Reason = "timeout"
+	// ReasonRetryableStatus 재시도할 상태 코드를 받았지만 재시도할 수 없음 (Retry-After가 너무 긴 경우 등)
+	ReasonRetryableStatus Reason = "retryable_status"
+	// ReasonNetworkError 재시도해도 결과가 같은 전송 에러
+	ReasonNetworkError Reason = "network_error"
+	// ReasonContextCancelled 요청 context가 취소되거나 만료됨
+	ReasonContextCancelled Reason = "context_cancelled"
+	// ReasonCircuitOpen 서킷 브레이커가 열려 요청하지 않음
+	ReasonCircuitOpen Reason = "circuit_open"
+	// ReasonClientClosed 클라이언트가 종료되어 재시도하지 않음
+	ReasonClientClosed Reason = "client_closed"
+	// ReasonRetryLimited 프로세스 전체의 재시도 제한에 도달하여 재시도하지 않음
+	ReasonRetryLimited Reason = "retry_limited"
+	// ReasonAborted BeforeAttempt hook이 에러를 반환하여 재시도를 중단
+	ReasonAborted Reason = "aborted"
+)
+
+// RetryError 재시도를 포기한 요청의 에러
+//
+// 클라이언트가 반환하는 에러(*url.Error)에 포함되며, errors.As로 꺼내 포기한 이유에 따라 분기할 수 있습니다.
+//
+//	var retryErr *httpretry.RetryError
+//	if errors.As(err, &retryErr) && retryErr.Reason == httpretry.ReasonBudgetExhausted {
+//		// ...
+//	}
+type RetryError struct {
+	// Reason 재시도를 포기한 이유
+	Reason Reason
+	// Attempts 실제로 전송한 시도 수
+	Attempts int
+	// errs 모든 시도에서 발생한 에러. ErrorAggregationLast인 경우 마지막 시도 이후의 에러
+	errs []error
+	// aggregation 에러 메시지 구성 방식
+	aggregation ErrorAggregation
+}
+
+// newRetryError 에러 구성 방식에 따라 재시도를 포기한 요청의 에러를 생성
+func newRetryError(reason Reason, attempts int, errs []error, aggregation ErrorAggregation) *RetryError {
+	if aggregation == ErrorAggregationLast {
+		errs = lastAttemptErrors(errs)
+	}
+	return &RetryError{Reason: reason, Attempts: attempts, errs: errs, aggregation: aggregation}
+}
+
+// Error 시도에서 발생한 에러를 반환. ErrorAggregationSummary인 경우 요약 메시지를 반환
+func (e *RetryError) Error() string {
+	if e.aggregation == ErrorAggregationSummary {
+		return summarizeErrors(e.Attempts, e.errs)
+	}
+	return joinMessages(e.errs)
+}
+
+// Unwrap 마지막 시도의 실패 원인과 모든 시도에서 발생한 에러를 반환
+//
+// errors.Is, errors.As는 각 시도의 에러를 확인하며, 마지막 시도의 원인을 가장 먼저 확인합니다.
+// 첫 시도는 타임아웃, 마지막 시도는 커넥션 거부로 실패한 경우 errors.As는 커넥션 거부 에러를 꺼냅니다.
+func (e *RetryError) Unwrap() []error {
+	if cause := e.Cause(); cause != nil {
+		return append([]error{cause}, e.errs...)
+	}
+	return e.errs
+}
+
+// Cause 마지막 시도가 실패한 원인을 반환
+//
+// 시도하지 않고 포기한 경우(서킷 브레이커 등) 마지막 에러를 반환합니다.
+// github.com/pkg/errors를 사용하는 경우 errors.Cause도 이 원인을 반환합니다.
+func (e *RetryError) Cause() error {
+	for i := len(e.errs) - 1; i >= 0; i-- {
+		if attemptErr, ok := e.errs[i].(*AttemptError); ok {
+			return attemptErr.Err
+		}
+	}
+	if len(e.errs) == 0 {
+		return nil
+	}
+	return e.errs[len(e.errs)-1]
+}
+
+type (
+	// RetryReport 재시도 과정을 구조화한 보고서. JSON으로 직렬화하여 로그 파이프라인으로 전송할 수 있음
+	RetryReport struct {
+		Reason       Reason          `json:"reason"`
+		AttemptCount int             `json:"attempt_count"`
+		Attempts     []AttemptReport `json:"attempts"`
+		// Errors 시도와 관련 없는 에러 (최대 재시도 횟수 초과, 서킷 브레이커 등)
+		Errors []string `json:"errors,omitempty"`
+	}
+
+	// AttemptReport 시도 1회의 보고서
+	AttemptReport struct {
+		Attempt      int    `json:"attempt"`
+		Method       string `json:"method"`
+		URL          string `json:"url"`
+		StatusCode   int    `json:"status_code"`
+		DurationMS   int64  `json:"duration_ms"`
+		BackoffMS    int64  `json:"backoff_ms,omitempty"`
+		RetryAfterMS int64  `json:"retry_after_ms,omitempty"`
+		DNSMS        int64  `json:"dns_ms,omitempty"`
+		ConnectMS    int64  `json:"connect_ms,omitempty"`
+		TLSMS        int64  `json:"tls_ms,omitempty"`
+		TTFBMS       int64  `json:"ttfb_ms,omitempty"`
+		Error        string `json:"error"`
+	}
+)
+
+// Report 재시도 과정을 구조화한 보고서를 반환
+//
+//	var retryErr *httpretry.RetryError
+//	if errors.As(err, &retryErr) {
+//		logger.Error("request failed", "report", retryErr.Report())
+//	}
+func (e *RetryError) Report() RetryReport {
+	report := RetryReport{
+		Reason:       e.Reason,
+		AttemptCount: e.Attempts,
+		Attempts:     []AttemptReport{},
+	}
+	for _, err := range e.errs {
+		attemptErr, ok := err.(*AttemptError)
+		if !ok {
+			report.Errors = append(report.Errors, err.Error())
+			continue
+		}
+		report.Attempts = append(report.Attempts, AttemptReport{
+			Attempt:      attemptErr.Attempt,
+			Method:       attemptErr.Method,
+			URL:          attemptErr.URL,
+			StatusCode:   attemptErr.StatusCode,
+			DurationMS:   attemptErr.Duration.Milliseconds(),
+			BackoffMS:    attemptErr.Backoff.Milliseconds(),
+			RetryAfterMS: attemptErr.RetryAfter.Milliseconds(),
+			DNSMS:        attemptErr.Timings.DNS.Milliseconds(),
+			ConnectMS:    attemptErr.Timings.Connect.Milliseconds(),
+			TLSMS:        attemptErr.Timings.TLS.Milliseconds(),
+			TTFBMS:       attemptErr.Timings.TTFB.Milliseconds(),
+			Error:        attemptErr.Err.Error(),
+		})
+	}
+	return report
+}
+
+// RetryAttempt 시도 1회의 기록
+//
+// 메시지를 해석하지 않고 시도별 지연 시간, 상태 코드 분포 등을 집계할 때 사용합니다.
+type RetryAttempt struct {
+	// Attempt 시도 번호 (1부터 시작)
+	Attempt int
+	// Start 시도를 시작한 시각
+	Start time.Time
+	// Duration 시도에 걸린 시간
+	Duration time.Duration
+	// StatusCode 응답 상태 코드. 응답을 받지 못한 경우 -1
+	StatusCode int
+	// Err 시도가 실패한 원인
+	Err error
+	// Backoff 다음 시도 전에 적용한 대기 시간. 바로 재시도하거나 재시도하지 않은 경우 0
+	Backoff time.Duration
+	// RetryAfter 응답의 Retry-After 헤더로 정한 대기 시간. 헤더가 없는 경우 0
+	RetryAfter time.Duration
+}
+
+// RetryAttempts 시도별 기록을 시도 순서대로 반환
+//
+// ErrorAggregationLast인 경우 마지막 시도의 기록만 포함합니다.
+//
+//	var retryErr *httpretry.RetryError
+//	if errors.As(err, &retryErr) {
+//		for _, attempt := range retryErr.RetryAttempts() {
+//			attemptLatency.Observe(attempt.Duration.Seconds())
+//		}
+//	}
+func (e *RetryError) RetryAttempts() []RetryAttempt {
+	var attempts []RetryAttempt
+	for _, err := range e.errs {
+		attemptErr, ok := err.(*AttemptError)
+		if !ok {
+			continue
+		}
+		attempts = append(attempts, RetryAttempt{
+			Attempt:    attemptErr.Attempt,
+			Start:      attemptErr.Start,
+			Duration:   attemptErr.Duration,
+			StatusCode: attemptErr.StatusCode,
+			Err:        attemptErr.Err,
+			Backoff:    attemptErr.Backoff,
+			RetryAfter: attemptErr.RetryAfter,
+		})
+	}
+	return attempts
+}
@@ -0,0 +1,273 @@
+package httpretry_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryError(t *testing.T) {
+	unavailable := func(t *testing.T) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	t.Run("최대 재시도 횟수를 모두 사용하면 ReasonBudgetExhausted를 반환하는 테스트", func(t *testing.T) {
+		// given
+		server := unavailable(t)
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(3),
+			httpretry.WithBackoffPolicy(noBackoff),
+		))
+
+		// when
+		_, err := client.Get(server.URL)
+
+		// then
+		var retryErr *httpretry.RetryError
+		assert.True(t, errors.As(err, &retryErr))
+		assert.Equal(t, httpretry.ReasonBudgetExhausted, retryErr.Reason)
+		assert.Equal(t, 3, retryErr.Attempts)
+	})
+
+	t.Run("요청 context가 취소되면 ReasonContextCancelled를 반환하는 테스트", func(t *testing.T) {
+		// given
+		server := unavailable(t)
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithBackoffPolicy(func(int) time.Duration { return time.Second }),
+		))
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		time.AfterFunc(100*time.Millisecond, cancel)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+		// when
+		_, err := client.Do(req)
+
+		// then
+		var retryErr *httpretry.RetryError
+		assert.True(t, errors.As(err, &retryErr))
+		assert.Equal(t, httpretry.ReasonContextCancelled, retryErr.Reason)
+		assert.Equal(t, 1, retryErr.Attempts)
+	})
+
+	t.Run("재시도하지 않는 전송 에러는 ReasonNetworkError를 반환하는 테스트", func(t *testing.T) {
+		// given
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithErrorClassifier(func(error) bool { return false }),
+		))
+
+		// when
+		_, err := client.Get("http://127.0.0.1:1")
+
+		// then
+		var retryErr *httpretry.RetryError
+		assert.True(t, errors.As(err, &retryErr))
+		assert.Equal(t, httpretry.ReasonNetworkError, retryErr.Reason)
+		assert.Equal(t, 1, retryErr.Attempts)
+	})
+}
+
+func TestRetryErrorChain(t *testing.T) {
+	t.Run("부모 context 만료 원인을 errors.Is로 확인할 수 있는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithAttemptTimeout(time.Second),
+		))
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+		// when
+		_, err := client.Do(req)
+
+		// then
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.ErrorContains(t, err, "cancelled from parent context")
+	})
+
+	t.Run("시도 타임아웃은 context.DeadlineExceeded로 확인할 수 있는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(1),
+			httpretry.WithAttemptTimeout(50*time.Millisecond),
+		))
+
+		// when
+		_, err := client.Get(server.URL)
+
+		// then
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+
+	t.Run("전송 에러를 errors.As로 꺼낼 수 있는 테스트", func(t *testing.T) {
+		// given
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(1),
+		))
+
+		// when
+		_, err := client.Get("http://127.0.0.1:1")
+
+		// then
+		var opErr *net.OpError
+		assert.True(t, errors.As(err, &opErr))
+		assert.Equal(t, "dial", opErr.Op)
+	})
+}
+
+func TestAttemptError(t *testing.T) {
+	t.Run("시도 에러에 요청 메서드, 가려진 URL, 대기 시간이 포함되는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(func(int) time.Duration { return 10 * time.Millisecond }),
+		))
+
+		// when
+		_, err := client.Get(server.URL + "/items?token=secret")
+
+		// then
+		var attemptErr *httpretry.AttemptError
+		assert.True(t, errors.As(err, &attemptErr))
+		assert.Equal(t, 1, attemptErr.Attempt)
+		assert.Equal(t, http.MethodGet, attemptErr.Method)
+		assert.Equal(t, server.URL+"/items?token=xxxxx", attemptErr.URL)
+		assert.Equal(t, http.StatusServiceUnavailable, attemptErr.StatusCode)
+		assert.Equal(t, 10*time.Millisecond, attemptErr.Backoff)
+		assert.Positive(t, attemptErr.Duration)
+		assert.ErrorContains(t, err, "attempt(1) GET "+server.URL+"/items?token=xxxxx")
+		assert.ErrorContains(t, err, "backoff 10ms")
+		assert.NotContains(t, attemptErr.Error(), "secret")
+	})
+}
+
+func TestRetryErrorReport(t *testing.T) {
+	t.Run("재시도 과정을 JSON으로 직렬화할 수 있는 보고서로 반환하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(func(int) time.Duration { return 5 * time.Millisecond }),
+		))
+		_, err := client.Get(server.URL)
+		var retryErr *httpretry.RetryError
+		assert.True(t, errors.As(err, &retryErr))
+
+		// when
+		report := retryErr.Report()
+		data, marshalErr := json.Marshal(report)
+
+		// then
+		assert.NoError(t, marshalErr)
+		assert.Equal(t, httpretry.ReasonBudgetExhausted, report.Reason)
+		assert.Equal(t, 2, report.AttemptCount)
+		assert.Len(t, report.Attempts, 2)
+		assert.Equal(t, http.StatusBadGateway, report.Attempts[1].StatusCode)
+		assert.Equal(t, int64(5), report.Attempts[0].BackoffMS)
+		assert.Equal(t, []string{"max retries reached"}, report.Errors)
+		assert.Contains(t, string(data), `"reason":"budget_exhausted"`)
+		assert.Contains(t, string(data), `"status_code":502`)
+	})
+}
+
+func TestRetryErrorRetryAttempts(t *testing.T) {
+	t.Run("시도별 시작 시각, 소요 시간, 상태 코드, 대기 시간을 기록으로 반환하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(3),
+			httpretry.WithBackoffPolicy(func(int) time.Duration { return 5 * time.Millisecond }),
+		))
+		start := time.Now()
+		_, err := client.Get(server.URL)
+		var retryErr *httpretry.RetryError
+		assert.True(t, errors.As(err, &retryErr))
+
+		// when
+		attempts := retryErr.RetryAttempts()
+
+		// then
+		assert.Len(t, attempts, 3)
+		for i, attempt := range attempts {
+			assert.Equal(t, i+1, attempt.Attempt)
+			assert.Equal(t, http.StatusServiceUnavailable, attempt.StatusCode)
+			assert.Positive(t, attempt.Duration)
+			assert.Error(t, attempt.Err)
+			assert.False(t, attempt.Start.Before(start))
+			if i > 0 {
+				assert.False(t, attempt.Start.Before(attempts[i-1].Start.Add(attempts[i-1].Backoff)), "대기 시간 이후에 시도해야 합니다.")
+			}
+		}
+		assert.Equal(t, 5*time.Millisecond, attempts[0].Backoff)
+		assert.Zero(t, attempts[2].Backoff)
+	})
+}
+
+func TestRetryErrorCause(t *testing.T) {
+	t.Run("마지막 시도의 실패 원인을 Cause와 %w로 확인할 수 있는 테스트", func(t *testing.T) {
+		// given
+		var server *httptest.Server
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// 첫 시도는 응답 없이 커넥션을 끊고, 이후 시도는 연결을 거부
+			server.Listener.Close()
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(noBackoff),
+		))
+		_, err := client.Get(server.URL)
+		var retryErr *httpretry.RetryError
+		assert.True(t, errors.As(err, &retryErr))
+
+		// when
+		cause := retryErr.Cause()
+		wrapped := fmt.Errorf("calling inventory: %w", err)
+
+		// then
+		assert.ErrorIs(t, cause, syscall.ECONNREFUSED)
+		assert.NotErrorIs(t, cause, io.EOF)
+		assert.Equal(t, cause, retryErr.Unwrap()[0], "Unwrap은 마지막 시도의 원인을 먼저 반환")
+		assert.ErrorIs(t, wrapped, syscall.ECONNREFUSED)
+		assert.ErrorIs(t, wrapped, io.EOF, "이전 시도의 에러도 확인할 수 있어야 합니다.")
+		var opErr *net.OpError
+		assert.True(t, errors.As(wrapped, &opErr))
+		assert.Equal(t, "dial", opErr.Op)
+	})
+}
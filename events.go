@@ -0,0 +1,163 @@
+package httpretry
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EventType 재시도 이벤트 종류
+type EventType string
+
+const (
+	// EventAttemptStart 시도 시작
+	EventAttemptStart EventType = "attempt_start"
+	// EventAttemptFinish 시도 종료 (응답 수신 또는 실패)
+	EventAttemptFinish EventType = "attempt_finish"
+	// EventRetryScheduled 재시도 예정. Backoff만큼 대기한 뒤 다음 시도를 시작
+	EventRetryScheduled EventType = "retry_scheduled"
+	// EventGiveUp 재시도 포기. Reason에 포기한 이유가 포함됨
+	EventGiveUp EventType = "give_up"
+)
+
+// DefaultEventBufferSize 이벤트 채널의 기본 버퍼 크기
+const DefaultEventBufferSize = 256
+
+type (
+	// RetryEvent 재시도 과정에서 발생한 이벤트
+	RetryEvent struct {
+		Type EventType
+		Time time.Time
+		// Method 요청 메서드
+		Method string
+		// URL 요청 URL. 비밀번호와 쿼리 값은 가려짐
+		URL string
+		// Attempt 시도 번호 (1부터 시작). EventGiveUp은 전송한 시도 수
+		Attempt int
+		// StatusCode 응답 상태 코드. 응답을 받지 못했거나 해당하지 않는 경우 -1
+		StatusCode int
+		// Duration 시도에 걸린 시간 (EventAttemptFinish)
+		Duration time.Duration
+		// Timings 구간별 소요 시간 (EventAttemptFinish). Settings.AttemptTimings가 꺼져 있으면 0
+		Timings AttemptTimings
+		// Backoff 다음 시도 전 대기 시간 (EventRetryScheduled)
+		Backoff time.Duration
+		// Reason 재시도를 포기한 이유 (EventGiveUp)
+		Reason Reason
+		// Err 시도 또는 요청의 에러
+		Err error
+	}
+
+	// Observer 재시도 이벤트를 요청과 함께 받는 hook
+	//
+	// 요청을 처리하는 goroutine에서 동기적으로 호출되므로 빠르게 반환해야 합니다.
+	// 메트릭은 metricsprom 패키지를 사용합니다.
+	Observer interface {
+		Observe(req *http.Request, event RetryEvent)
+	}
+
+	// eventStream 가득 차면 가장 오래된 이벤트를 버리는 이벤트 채널
+	//
+	// Events를 호출하기 전에는 이벤트를 만들지 않으므로 비용이 들지 않습니다.
+	eventStream struct {
+		mu      sync.Mutex
+		ch      chan RetryEvent
+		enabled atomic.Bool
+	}
+)
+
+// newEventStream 버퍼 크기만큼 이벤트를 보관하는 이벤트 채널을 생성
+func newEventStream(size int) *eventStream {
+	if size <= 0 {
+		size = DefaultEventBufferSize
+	}
+	return &eventStream{ch: make(chan RetryEvent, size)}
+}
+
+// subscribe 이벤트 발행을 시작하고 채널을 반환
+func (s *eventStream) subscribe() <-chan RetryEvent {
+	s.enabled.Store(true)
+	return s.ch
+}
+
+// active 구독 중인지 확인. 구독 전에는 이벤트를 만들지 않음
+func (s *eventStream) active() bool {
+	return s.enabled.Load()
+}
+
+// emit 이벤트를 발행. 채널이 가득 차면 가장 오래된 이벤트를 버리며, 호출자를 막지 않음
+func (s *eventStream) emit(event RetryEvent) {
+	if !s.active() {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for {
+		select {
+		case s.ch <- event:
+			return
+		default:
+		}
+		select {
+		case <-s.ch:
+		default:
+		}
+	}
+}
+
+// observing 이벤트를 받는 곳이 있는지 확인. 없으면 이벤트를 만들지 않음
+func (rt *retriableTransport) observing() bool {
+	return rt.observer != nil || rt.retryRate != nil || rt.shedder != nil ||
+		(rt.endpoints != nil && rt.endpoints.outliers != nil) || rt.events.active()
+}
+
+// publish 이벤트를 Observer와 이벤트 채널로 발행
+func (rt *retriableTransport) publish(req *http.Request, event RetryEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	if rt.observer != nil {
+		rt.observer.Observe(req, event)
+	}
+	if rt.retryRate != nil {
+		rt.retryRate.observe(event)
+	}
+	if rt.shedder != nil {
+		rt.shedder.observe(req.URL.Host, event)
+	}
+	if rt.endpoints != nil && rt.endpoints.outliers != nil {
+		rt.endpoints.outliers.observe(req, event)
+	}
+	rt.events.emit(event)
+}
+
+// requestEvent 요청 정보를 채운 이벤트를 생성
+func requestEvent(eventType EventType, req *http.Request, attempt int) RetryEvent {
+	return RetryEvent{
+		Type:       eventType,
+		Method:     req.Method,
+		URL:        redactURL(req.URL),
+		Attempt:    attempt,
+		StatusCode: -1,
+	}
+}
+
+// Events 재시도 이벤트를 받는 채널을 반환
+//
+// 처음 호출한 시점부터 이벤트를 발행합니다. 채널은 Settings.EventBufferSize만큼 이벤트를 보관하며,
+// 가득 차면 가장 오래된 이벤트를 버리므로 소비가 늦어도 요청이 막히지 않습니다.
+// 채널은 클라이언트를 종료해도 닫히지 않습니다.
+//
+//	go func() {
+//		for event := range client.Events() {
+//			metrics.Observe(event)
+//		}
+//	}()
+func (c *Client) Events() <-chan RetryEvent {
+	return c.transport.events.subscribe()
+}
@@ -0,0 +1,110 @@
+package httpretry_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+// drainEvents 채널에 쌓인 이벤트를 모두 꺼냄
+func drainEvents(events <-chan httpretry.RetryEvent) []httpretry.RetryEvent {
+	var drained []httpretry.RetryEvent
+	for {
+		select {
+		case event := <-events:
+			drained = append(drained, event)
+		default:
+			return drained
+		}
+	}
+}
+
+func TestClient_Events(t *testing.T) {
+	t.Run("시도 시작, 종료, 재시도 예정 이벤트를 순서대로 발행하는 테스트", func(t *testing.T) {
+		// given
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&requests, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		client := httpretry.New(httpretry.NewHTTPSettings(httpretry.WithBackoffPolicy(noBackoff)))
+		events := client.Events()
+
+		// when
+		resp, err := client.Get(server.URL)
+
+		// then
+		assert.NoError(t, err)
+		resp.Body.Close()
+		received := drainEvents(events)
+		types := make([]httpretry.EventType, 0, len(received))
+		for _, event := range received {
+			types = append(types, event.Type)
+		}
+		assert.Equal(t, []httpretry.EventType{
+			httpretry.EventAttemptStart,
+			httpretry.EventAttemptFinish,
+			httpretry.EventRetryScheduled,
+			httpretry.EventAttemptStart,
+			httpretry.EventAttemptFinish,
+		}, types)
+		assert.Equal(t, http.StatusServiceUnavailable, received[1].StatusCode)
+		assert.Equal(t, 2, received[4].Attempt)
+		assert.Equal(t, http.StatusOK, received[4].StatusCode)
+	})
+
+	t.Run("재시도를 포기하면 이유와 함께 give up 이벤트를 발행하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+		client := httpretry.New(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(1),
+			httpretry.WithBackoffPolicy(noBackoff),
+		))
+		events := client.Events()
+
+		// when
+		_, err := client.Get(server.URL)
+
+		// then
+		assert.Error(t, err)
+		received := drainEvents(events)
+		last := received[len(received)-1]
+		assert.Equal(t, httpretry.EventGiveUp, last.Type)
+		assert.Equal(t, httpretry.ReasonBudgetExhausted, last.Reason)
+		assert.Equal(t, 1, last.Attempt)
+	})
+
+	t.Run("버퍼가 가득 차면 가장 오래된 이벤트를 버리는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+		settings := httpretry.NewHTTPSettings()
+		settings.EventBufferSize = 2
+		client := httpretry.New(settings)
+		events := client.Events()
+
+		// when
+		for range 3 {
+			resp, err := client.Get(server.URL)
+			assert.NoError(t, err)
+			resp.Body.Close()
+		}
+
+		// then
+		received := drainEvents(events)
+		assert.Len(t, received, 2)
+		assert.Equal(t, httpretry.EventAttemptStart, received[0].Type)
+		assert.Equal(t, httpretry.EventAttemptFinish, received[1].Type)
+	})
+}
@@ -0,0 +1,45 @@
+package httpretry
+
+import (
+	"io"
+	"net/http"
+	"strings"
+)
+
+// expectsContinue Expect: 100-continue로 바디 전송 전에 서버의 확인을 기다리는 요청인지 확인
+func expectsContinue(req *http.Request) bool {
+	return strings.EqualFold(req.Header.Get("Expect"), "100-continue")
+}
+
+// withoutExpect Expect 헤더를 제거한 요청 사본
+func withoutExpect(req *http.Request) *http.Request {
+	cloned := req.Clone(req.Context())
+	cloned.Header.Del("Expect")
+	return cloned
+}
+
+// roundTripExpect 시도 요청을 전송하고, 서버가 Expect를 거절(417)하면 같은 시도에서 Expect 없이 다시 전송
+//
+// 100-continue를 기다리는 요청은 서버가 먼저 응답(거절)하면 바디를 보내지 않으므로, 다음 시도는 GetBody로 바디를 온전히 다시 보냅니다.
+// 417 Expectation Failed는 서버가 Expect를 지원하지 않는다는 의미이므로(RFC 9110) 시도로 세지 않고 즉시 다시 전송하며,
+// expectRejected가 true이면 이후 시도에서도 Expect 헤더를 제거해야 합니다.
+func (rt *retriableTransport) roundTripExpect(
+	attemptReq *http.Request,
+	getBody func() (io.ReadCloser, error),
+) (response *http.Response, expectRejected bool, err error) {
+	response, err = rt.RoundTripper.RoundTrip(attemptReq)
+	if err != nil || response.StatusCode != http.StatusExpectationFailed || getBody == nil || !expectsContinue(attemptReq) {
+		return response, false, err
+	}
+
+	body, err := getBody()
+	if err != nil {
+		// 바디를 다시 만들 수 없으면 거절 응답을 그대로 반환
+		return response, false, nil
+	}
+	closeBody(response)
+	retry := withoutExpect(attemptReq)
+	retry.Body = body
+	response, err = rt.RoundTripper.RoundTrip(retry)
+	return response, true, err
+}
@@ -0,0 +1,96 @@
+package httpretry_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpectContinue(t *testing.T) {
+	payload := strings.Repeat("payload", 64<<10)
+
+	t.Run("바디를 받기 전에 거절한 요청을 바디와 함께 다시 전송하는 테스트", func(t *testing.T) {
+		// given
+		var (
+			mu      sync.Mutex
+			bodies  []string
+			expects []string
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			expects = append(expects, r.Header.Get("Expect"))
+			if len(expects) == 1 {
+				// 바디를 읽지 않고 거절하여 100 Continue를 보내지 않음
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithExpectContinueTimeout(time.Minute),
+		))
+		req, _ := http.NewRequest(http.MethodPut, server.URL, io.NopCloser(strings.NewReader(payload)))
+		req.Header.Set("Expect", "100-continue")
+
+		// when
+		resp, err := client.Do(req)
+
+		// then
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, []string{"100-continue", "100-continue"}, expects)
+		assert.Equal(t, []string{payload}, bodies)
+	})
+
+	t.Run("417 응답은 같은 시도에서 Expect 없이 다시 전송하는 테스트", func(t *testing.T) {
+		// given
+		var (
+			mu      sync.Mutex
+			bodies  []string
+			expects []string
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			expects = append(expects, r.Header.Get("Expect"))
+			if r.Header.Get("Expect") != "" {
+				w.WriteHeader(http.StatusExpectationFailed)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			if len(bodies) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(noBackoff),
+		))
+		req, _ := http.NewRequest(http.MethodPut, server.URL, io.NopCloser(strings.NewReader(payload)))
+		req.Header.Set("Expect", "100-continue")
+
+		// when
+		resp, err := client.Do(req)
+
+		// then
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []string{"100-continue", "", ""}, expects, "거절된 이후의 시도는 Expect를 보내지 않아야 합니다.")
+		assert.Equal(t, []string{payload, payload}, bodies)
+		assert.Equal(t, "100-continue", req.Header.Get("Expect"), "호출자의 요청은 변경하지 않아야 합니다.")
+	})
+}
@@ -0,0 +1,32 @@
+package httpretry
+
+import (
+	"errors"
+	"net/http"
+)
+
+// FallbackFunc 재시도를 포기한 요청의 대체 응답을 만드는 함수
+//
+// err는 *RetryError이며, Reason으로 포기한 이유를 확인할 수 있습니다.
+// 대체 응답을 만들 수 없으면 err를 그대로 반환합니다.
+type FallbackFunc func(req *http.Request, err error) (*http.Response, error)
+
+// withFallback 재시도를 포기한 경우 FallbackFunc로 대체 응답을 반환
+//
+// 요청 자체가 잘못된 경우(바디 준비 실패, 종료된 클라이언트 등)는 재시도를 포기한 것이 아니므로 호출하지 않습니다.
+func (rt *retriableTransport) withFallback(req *http.Request, resp *http.Response, err error) (*http.Response, error) {
+	var retryErr *RetryError
+	if err == nil || rt.fallback == nil || !errors.As(err, &retryErr) {
+		return resp, err
+	}
+	resp, err = rt.fallback(req, err)
+	if resp != nil {
+		if resp.Body == nil {
+			resp.Body = http.NoBody
+		}
+		if resp.Request == nil {
+			resp.Request = req
+		}
+	}
+	return resp, err
+}
@@ -0,0 +1,97 @@
+package httpretry_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithFallback(t *testing.T) {
+	t.Run("재시도를 포기하면 대체 응답을 반환하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		var fallbackErr error
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithFallback(func(req *http.Request, err error) (*http.Response, error) {
+				fallbackErr = err
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader(`{"items":[]}`)),
+				}, nil
+			}),
+		))
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+		// when
+		resp, err := client.Do(req)
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, `{"items":[]}`, string(body))
+		var retryErr *httpretry.RetryError
+		assert.True(t, errors.As(fallbackErr, &retryErr))
+		assert.Equal(t, 2, retryErr.Attempts)
+	})
+
+	t.Run("대체 응답을 만들 수 없으면 에러를 반환하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(1),
+			httpretry.WithFallback(func(req *http.Request, err error) (*http.Response, error) {
+				return nil, err
+			}),
+		))
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+		// when
+		_, err := client.Do(req)
+
+		// then
+		var retryErr *httpretry.RetryError
+		assert.True(t, errors.As(err, &retryErr))
+	})
+
+	t.Run("성공한 요청은 대체 응답을 사용하지 않는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		called := false
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithFallback(func(req *http.Request, err error) (*http.Response, error) {
+				called = true
+				return nil, err
+			}),
+		))
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+		// when
+		resp, err := client.Do(req)
+
+		// then
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.False(t, called)
+	})
+}
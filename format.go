@@ -0,0 +1,103 @@
+package httpretry
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Compact 포기한 이유와 시도별 실패 원인을 요약한 한 줄 메시지를 반환
+//
+// ErrorAggregation 설정과 관계없이 "budget_exhausted: 3 attempts: 2x 503, 1x timeout; max retries reached"처럼
+// 요약하므로 로그 한 줄에 남기기에 적합합니다.
+func (e *RetryError) Compact() string {
+	return fmt.Sprintf("%s: %s", e.Reason, summarizeErrors(e.Attempts, e.errs))
+}
+
+// Verbose 시도별 요청, 상태 코드, 실패 원인, 소요 시간, 대기 시간을 여러 줄로 나열한 메시지를 반환
+//
+// 디버깅 시 재시도 과정을 한눈에 확인할 때 사용합니다. 구간별 소요 시간과 캡처한 응답 바디는 기록된 경우에만 포함합니다.
+//
+//	budget_exhausted after 2 attempts
+//	  attempt(1) GET https://api.example.com/items
+//	    status:  503
+//	    error:   retrying on service unavailable
+//	    took:    12ms
+//	    backoff: 100ms
+//	  attempt(2) GET https://api.example.com/items
+//	    ...
+//	  max retries reached
+func (e *RetryError) Verbose() string {
+	var b strings.Builder
+	if e.Attempts == 1 {
+		fmt.Fprintf(&b, "%s after 1 attempt", e.Reason)
+	} else {
+		fmt.Fprintf(&b, "%s after %d attempts", e.Reason, e.Attempts)
+	}
+	for _, err := range e.errs {
+		attemptErr, ok := err.(*AttemptError)
+		if !ok {
+			fmt.Fprintf(&b, "\n  %v", err)
+			continue
+		}
+		writeAttempt(&b, attemptErr)
+	}
+	return b.String()
+}
+
+// Format fmt 동사에 따라 에러 메시지를 구성
+//
+//   - %s, %v: Error()와 같은 메시지 (ErrorAggregation 설정을 따름)
+//   - %+v: 시도별 상세 내역을 여러 줄로 나열한 Verbose()
+//   - %q: 따옴표로 감싼 Error()
+//
+// 클라이언트가 반환하는 *url.Error는 Format을 전달하지 않으므로, errors.As로 RetryError를 꺼내 사용합니다.
+//
+//	var retryErr *httpretry.RetryError
+//	if errors.As(err, &retryErr) {
+//		log.Printf("%+v", retryErr)
+//	}
+func (e *RetryError) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('+'):
+		io.WriteString(f, e.Verbose())
+	case verb == 'q':
+		fmt.Fprintf(f, "%q", e.Error())
+	default:
+		io.WriteString(f, e.Error())
+	}
+}
+
+// writeAttempt 시도 1회의 상세 내역을 기록
+func writeAttempt(b *strings.Builder, err *AttemptError) {
+	fmt.Fprintf(b, "\n  attempt(%d)", err.Attempt)
+	if err.Method != "" {
+		fmt.Fprintf(b, " %s %s", err.Method, err.URL)
+	}
+	if err.StatusCode > 0 {
+		fmt.Fprintf(b, "\n    status:  %d", err.StatusCode)
+	}
+	fmt.Fprintf(b, "\n    error:   %v", err.Err)
+	fmt.Fprintf(b, "\n    took:    %s", err.Duration.Round(time.Millisecond))
+	if timings := err.Timings; timings != (AttemptTimings{}) {
+		fmt.Fprintf(b, "\n    timings: dns %s, connect %s, tls %s, ttfb %s",
+			timings.DNS.Round(time.Millisecond), timings.Connect.Round(time.Millisecond),
+			timings.TLS.Round(time.Millisecond), timings.TTFB.Round(time.Millisecond))
+		if timings.ConnReused {
+			b.WriteString(", reused connection")
+		}
+	}
+	if err.Backoff > 0 {
+		fmt.Fprintf(b, "\n    backoff: %s", err.Backoff)
+	}
+	if err.RetryAfter > 0 {
+		fmt.Fprintf(b, "\n    retry after: %s", err.RetryAfter)
+	}
+	if err.Response != nil && len(err.Response.Body) > 0 {
+		fmt.Fprintf(b, "\n    body:    %q", err.Response.Body)
+		if err.Response.Truncated {
+			b.WriteString(" (truncated)")
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package httpretry_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryErrorFormat(t *testing.T) {
+	// failedRequest 503을 두 번 받고 재시도를 포기한 요청의 에러
+	failedRequest := func(t *testing.T) *httpretry.RetryError {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("upstream unavailable"))
+		}))
+		t.Cleanup(server.Close)
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(func(int) time.Duration { return 5 * time.Millisecond }),
+			httpretry.WithCaptureAttempts(8),
+		))
+		_, err := client.Get(server.URL + "/items")
+		var retryErr *httpretry.RetryError
+		assert.True(t, errors.As(err, &retryErr))
+		return retryErr
+	}
+
+	t.Run("Compact는 포기한 이유와 실패 원인을 한 줄로 요약하는 테스트", func(t *testing.T) {
+		// given
+		retryErr := failedRequest(t)
+
+		// when
+		compact := retryErr.Compact()
+
+		// then
+		assert.Equal(t, "budget_exhausted: 2 attempts: 2x 503; max retries reached", compact)
+	})
+
+	t.Run("%+v는 시도별 상세 내역을 여러 줄로 나열하는 테스트", func(t *testing.T) {
+		// given
+		retryErr := failedRequest(t)
+
+		// when
+		verbose := fmt.Sprintf("%+v", retryErr)
+
+		// then
+		lines := strings.Split(verbose, "\n")
+		assert.Equal(t, "budget_exhausted after 2 attempts", lines[0])
+		assert.Contains(t, verbose, "  attempt(1) GET http://")
+		assert.Contains(t, verbose, "    status:  503")
+		assert.Contains(t, verbose, "    backoff: 5ms")
+		assert.Contains(t, verbose, `    body:    "upstream" (truncated)`)
+		assert.Equal(t, "  max retries reached", lines[len(lines)-1])
+		assert.Equal(t, retryErr.Verbose(), verbose)
+	})
+
+	t.Run("%v와 %s는 Error와 같은 메시지를 반환하는 테스트", func(t *testing.T) {
+		// given
+		retryErr := failedRequest(t)
+
+		// when
+		formatted := []string{fmt.Sprintf("%v", retryErr), fmt.Sprintf("%s", retryErr)}
+
+		// then
+		assert.Equal(t, []string{retryErr.Error(), retryErr.Error()}, formatted)
+		assert.NotContains(t, retryErr.Error(), "\n")
+	})
+}
@@ -0,0 +1,45 @@
+module github.com/dings-things/httpretry/fx
+
+go 1.24
+
+replace github.com/dings-things/httpretry => ..
+
+replace github.com/dings-things/httpretry/env => ../env
+
+replace github.com/dings-things/httpretry/metricsprom => ../metricsprom
+
+replace github.com/dings-things/httpretry/traceotel => ../traceotel
+
+require (
+	github.com/dings-things/httpretry v0.0.0-00010101000000-000000000000
+	github.com/dings-things/httpretry/env v0.0.0-00010101000000-000000000000
+	github.com/dings-things/httpretry/metricsprom v0.0.0-00010101000000-000000000000
+	github.com/dings-things/httpretry/traceotel v0.0.0-00010101000000-000000000000
+	github.com/prometheus/client_golang v1.19.1
+	github.com/stretchr/testify v1.9.0
+	go.opentelemetry.io/otel/sdk v1.28.0
+	go.opentelemetry.io/otel/trace v1.28.0
+	go.uber.org/fx v1.23.0
+)
+
+require (
+	github.com/Netflix/go-env v0.1.2 // indirect
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/prometheus/client_model v0.5.0 // indirect
+	github.com/prometheus/common v0.48.0 // indirect
+	github.com/prometheus/procfs v0.12.0 // indirect
+	go.opentelemetry.io/otel v1.28.0 // indirect
+	go.opentelemetry.io/otel/metric v1.28.0 // indirect
+	go.uber.org/dig v1.18.0 // indirect
+	go.uber.org/multierr v1.11.0 // indirect
+	go.uber.org/zap v1.26.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	google.golang.org/protobuf v1.33.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,48 @@
+package fx
+
+import (
+	"github.com/dings-things/httpretry"
+	"github.com/dings-things/httpretry/metricsprom"
+	"github.com/dings-things/httpretry/traceotel"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+)
+
+// Instrumentation 그래프에 있는 TracerProvider와 Registerer로 추적과 메트릭을 자동으로 연결합니다
+//
+// trace.TracerProvider가 있으면 traceotel, prometheus.Registerer가 있으면 metricsprom을 설정에 적용하며,
+// 없는 종속성은 건너뜁니다. 설정에 이미 Tracer나 Observer가 있으면 유지합니다.
+//
+//	fx.New(
+//		httpretryfx.Module,
+//		httpretryfx.Instrumentation,
+//		fx.Supply(fx.Annotate(otel.GetTracerProvider(), fx.As(new(trace.TracerProvider)))),
+//		fx.Supply(fx.Annotate(prometheus.DefaultRegisterer, fx.As(new(prometheus.Registerer)))),
+//	)
+var Instrumentation = fx.Decorate(instrument)
+
+// instrumentParams 추적과 메트릭 연결에 사용하는 선택적 종속성
+type instrumentParams struct {
+	fx.In
+
+	Settings       *httpretry.Settings
+	TracerProvider trace.TracerProvider  `optional:"true"`
+	Registerer     prometheus.Registerer `optional:"true"`
+}
+
+// instrument 있는 종속성으로 Tracer와 Observer를 설정
+func instrument(p instrumentParams) (*httpretry.Settings, error) {
+	settings := p.Settings
+	if p.TracerProvider != nil && settings.Tracer == nil {
+		httpretry.WithTracer(traceotel.New(p.TracerProvider))(settings)
+	}
+	if p.Registerer != nil && settings.Observer == nil {
+		observer, err := metricsprom.New(p.Registerer)
+		if err != nil {
+			return nil, err
+		}
+		httpretry.WithObserver(observer)(settings)
+	}
+	return settings, nil
+}
@@ -0,0 +1,77 @@
+package fx_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpretryfx "github.com/dings-things/httpretry/fx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+func TestInstrumentation(t *testing.T) {
+	newServer := func() *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+	}
+
+	t.Run("그래프의 TracerProvider와 Registerer로 추적과 메트릭을 연결하는 테스트", func(t *testing.T) {
+		// given
+		server := newServer()
+		defer server.Close()
+		recorder := tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		registry := prometheus.NewRegistry()
+		var client struct {
+			fx.In
+			Client *http.Client `name:"httpclient"`
+		}
+		app := fxtest.New(t,
+			httpretryfx.Module,
+			httpretryfx.Instrumentation,
+			fx.Supply(fx.Annotate(provider, fx.As(new(trace.TracerProvider)))),
+			fx.Supply(fx.Annotate(registry, fx.As(new(prometheus.Registerer)))),
+			fx.Populate(&client),
+		)
+		defer app.RequireStart().RequireStop()
+
+		// when
+		resp, err := client.Client.Get(server.URL)
+
+		// then
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Len(t, recorder.Ended(), 1)
+		count, err := testutil.GatherAndCount(registry, "httpretry_attempts_total")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("종속성이 없으면 연결하지 않고 클라이언트를 제공하는 테스트", func(t *testing.T) {
+		// given
+		server := newServer()
+		defer server.Close()
+		var client struct {
+			fx.In
+			Client *http.Client `name:"httpclient"`
+		}
+		app := fxtest.New(t, httpretryfx.Module, httpretryfx.Instrumentation, fx.Populate(&client))
+		defer app.RequireStart().RequireStop()
+
+		// when
+		resp, err := client.Client.Get(server.URL)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+	})
+}
@@ -1,17 +1,34 @@
 package fx
 
 import (
+	"net/http"
+
 	"github.com/dings-things/httpretry"
+	"github.com/dings-things/httpretry/env"
 	"go.uber.org/fx"
 )
 
 // Module httpretry 클라이언트의 고정 종속성을 제공합니다
+//
+// `name:"httpclient"` 태그의 *http.Client와, 직접 http.Client를 구성하는 경우(Jar, CheckRedirect 등)에 사용할
+// `name:"httptransport"` 태그의 재시도 http.RoundTripper를 제공합니다. 클라이언트는 같은 RoundTripper를 사용합니다.
+//
+//	fx.Provide(fx.Annotate(func(transport http.RoundTripper, jar http.CookieJar) *http.Client {
+//		return &http.Client{Transport: transport, Jar: jar}
+//	}, fx.ParamTags(`name:"httptransport"`)))
 var Module = fx.Module(
 	"dings-things/httpretry",
 	fx.Provide(
 		// retry client
-		fx.Annotate(httpretry.NewClient, fx.ResultTags(`name:"httpclient"`)),
+		fx.Annotate(newClient, fx.ParamTags(`name:"httptransport"`), fx.ResultTags(`name:"httpclient"`)),
+		// retry transport
+		fx.Annotate(httpretry.NewTransport, fx.ResultTags(`name:"httptransport"`)),
 
-		httpretry.NewSettings,
+		env.NewSettings,
 	),
 )
+
+// newClient 재시도 RoundTripper를 사용하는 클라이언트를 생성
+func newClient(transport http.RoundTripper) *http.Client {
+	return &http.Client{Transport: transport}
+}
@@ -0,0 +1,60 @@
+package fx_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	httpretryfx "github.com/dings-things/httpretry/fx"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+func TestModule(t *testing.T) {
+	t.Run("재시도 RoundTripper로 직접 구성한 클라이언트도 재시도하는 테스트", func(t *testing.T) {
+		// given
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		var deps struct {
+			fx.In
+			Client    *http.Client      `name:"httpclient"`
+			Transport http.RoundTripper `name:"httptransport"`
+		}
+		app := fxtest.New(t,
+			httpretryfx.Module,
+			fx.Decorate(func(settings *httpretry.Settings) *httpretry.Settings {
+				httpretry.WithBackoffPolicy(func(int) time.Duration { return 0 })(settings)
+				return settings
+			}),
+			fx.Populate(&deps),
+		)
+		defer app.RequireStart().RequireStop()
+		custom := &http.Client{
+			Transport: deps.Transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+
+		// when
+		resp, err := custom.Get(server.URL)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Same(t, deps.Transport, deps.Client.Transport, "클라이언트는 제공한 RoundTripper를 사용")
+	})
+}
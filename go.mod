@@ -2,19 +2,13 @@ module github.com/dings-things/httpretry
 
 go 1.24
 
-require (
-	github.com/Netflix/go-env v0.1.2
-	github.com/pkg/errors v0.9.1
-	github.com/stretchr/testify v1.9.0
-	go.uber.org/fx v1.23.0
-	go.uber.org/multierr v1.11.0
-)
+require github.com/stretchr/testify v1.9.0
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/kr/pretty v0.3.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	go.uber.org/dig v1.18.0 // indirect
-	go.uber.org/zap v1.26.0 // indirect
-	golang.org/x/sys v0.0.0-20220412211240-33da011f77ad // indirect
+	github.com/rogpeppe/go-internal v1.10.0 // indirect
+	gopkg.in/check.v1 v1.0.0-20201130134442-10cb98267c6c // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,182 @@
+// Package graphql httpretry 클라이언트를 통해 GraphQL 쿼리를 전송합니다
+//
+// GraphQL 서버는 일시적인 장애도 200 응답의 errors 필드로 알리므로,
+// WithRetryableCodes를 httpretry 설정에 추가하여 해당 에러 코드를 재시도하도록 합니다.
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/dings-things/httpretry"
+)
+
+// PeekLimit 재시도 여부 판단을 위해 읽는 응답 바디의 최대 바이트 수
+const PeekLimit = 64 << 10
+
+// DefaultRetryableCodes 기본으로 재시도하는 GraphQL 에러 코드 (extensions.code)
+var DefaultRetryableCodes = []string{
+	"RATE_LIMITED",
+	"INTERNAL",
+	"INTERNAL_SERVER_ERROR",
+	"SERVICE_UNAVAILABLE",
+}
+
+type (
+	// Request GraphQL 요청
+	Request struct {
+		Query         string         `json:"query"`
+		Variables     map[string]any `json:"variables,omitempty"`
+		OperationName string         `json:"operationName,omitempty"`
+	}
+
+	// Error GraphQL 응답의 errors 항목
+	Error struct {
+		Message    string         `json:"message"`
+		Path       []any          `json:"path,omitempty"`
+		Extensions map[string]any `json:"extensions,omitempty"`
+	}
+
+	// Errors GraphQL 응답의 errors 필드
+	Errors []Error
+
+	// Client GraphQL 엔드포인트로 쿼리를 전송하는 클라이언트
+	Client struct {
+		client   httpretry.Doer
+		endpoint string
+	}
+)
+
+// Code extensions.code 값을 반환
+func (e Error) Code() string {
+	code, _ := e.Extensions["code"].(string)
+	return code
+}
+
+// Error errors 항목의 메시지를 합쳐서 반환
+func (e Errors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		if code := err.Code(); code != "" {
+			messages = append(messages, fmt.Sprintf("%s (%s)", err.Message, code))
+			continue
+		}
+		messages = append(messages, err.Message)
+	}
+	return "graphql: " + strings.Join(messages, "; ")
+}
+
+// WithRetryableCodes 지정한 GraphQL 에러 코드를 재시도하도록 하는 Option
+//
+// 응답 바디를 최대 PeekLimit 바이트까지 읽어 errors[].extensions.code를 확인합니다.
+// codes를 지정하지 않으면 DefaultRetryableCodes를 사용합니다.
+//
+// Parameters:
+//   - codes: (...string) 재시도할 GraphQL 에러 코드
+func WithRetryableCodes(codes ...string) httpretry.HTTPOption {
+	if len(codes) == 0 {
+		codes = DefaultRetryableCodes
+	}
+	retryable := make(map[string]struct{}, len(codes))
+	for _, code := range codes {
+		retryable[code] = struct{}{}
+	}
+
+	return httpretry.WithBodyPeek(PeekLimit, func(resp *http.Response, peek []byte) bool {
+		for _, err := range peekErrors(peek) {
+			if _, ok := retryable[err.Code()]; ok {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// NewClient GraphQL 클라이언트를 생성
+//
+// Parameters:
+//   - client: (httpretry.Doer) 요청에 사용할 클라이언트. 보통 httpretry.NewClient로 생성한 클라이언트
+//   - endpoint: (string) GraphQL 엔드포인트 URL
+func NewClient(client httpretry.Doer, endpoint string) *Client {
+	return &Client{client: client, endpoint: endpoint}
+}
+
+// Do 쿼리를 전송하고 data 필드를 out에 디코딩
+//
+// 요청 바디는 한 번만 직렬화되므로, 재시도 시에도 동일한 variables가 전송됩니다.
+// 응답에 errors 필드가 있으면 data를 디코딩한 뒤 Errors를 반환합니다.
+func (c *Client) Do(ctx context.Context, request Request, out any) error {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("graphql: marshalling request: %w", err)
+	}
+
+	// bytes.Reader로 생성한 요청은 GetBody가 설정되어 재시도 시 바디를 다시 전송
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("graphql: creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("graphql: unexpected status %d: %s", resp.StatusCode, body)
+	}
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors Errors          `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("graphql: decoding response: %w", err)
+	}
+	if out != nil && len(result.Data) > 0 && string(result.Data) != "null" {
+		if err := json.Unmarshal(result.Data, out); err != nil {
+			return fmt.Errorf("graphql: decoding data: %w", err)
+		}
+	}
+	if len(result.Errors) > 0 {
+		return result.Errors
+	}
+	return nil
+}
+
+// peekErrors 잘린 바디에서도 최상위 errors 필드를 찾아 디코딩
+//
+// errors 필드 이전에서 바디가 잘린 경우 nil을 반환합니다.
+func peekErrors(peek []byte) Errors {
+	decoder := json.NewDecoder(bytes.NewReader(peek))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil
+		}
+		if key, _ := token.(string); key == "errors" {
+			var errs Errors
+			if err := decoder.Decode(&errs); err != nil {
+				return nil
+			}
+			return errs
+		}
+		var skip json.RawMessage
+		if err := decoder.Decode(&skip); err != nil {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/dings-things/httpretry/graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient(t *testing.T) {
+	newClient := func(endpoint string) *graphql.Client {
+		return graphql.NewClient(
+			httpretry.NewClient(
+				httpretry.NewHTTPSettings(
+					httpretry.WithMaxRetry(3),
+					httpretry.WithBackoffPolicy(func(int) time.Duration { return 0 }),
+					graphql.WithRetryableCodes(),
+				),
+			),
+			endpoint,
+		)
+	}
+
+	t.Run("일시적인 GraphQL 에러 코드 응답 시, 동일한 variables로 재시도 테스트", func(t *testing.T) {
+		// given
+		var received []graphql.Request
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var request graphql.Request
+				json.NewDecoder(r.Body).Decode(&request)
+				received = append(received, request)
+				if len(received) == 1 {
+					w.Write([]byte(`{"errors":[{"message":"slow down","extensions":{"code":"RATE_LIMITED"}}],"data":null}`))
+					return
+				}
+				w.Write([]byte(`{"data":{"user":{"name":"dings"}}}`))
+			}),
+		)
+		defer testServer.Close()
+
+		var out struct {
+			User struct {
+				Name string `json:"name"`
+			} `json:"user"`
+		}
+
+		// when
+		err := newClient(testServer.URL).Do(context.Background(), graphql.Request{
+			Query:     "query($id: ID!) { user(id: $id) { name } }",
+			Variables: map[string]any{"id": "42"},
+		}, &out)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "dings", out.User.Name)
+		assert.Len(t, received, 2)
+		assert.Equal(t, received[0], received[1], "재시도 시 동일한 요청이 전송되어야 합니다.")
+	})
+
+	t.Run("재시도 대상이 아닌 GraphQL 에러는 Errors로 반환 테스트", func(t *testing.T) {
+		// given
+		reqCount := 0
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reqCount++
+				w.Write([]byte(`{"data":null,"errors":[{"message":"not found","extensions":{"code":"NOT_FOUND"}}]}`))
+			}),
+		)
+		defer testServer.Close()
+
+		// when
+		err := newClient(testServer.URL).Do(context.Background(), graphql.Request{Query: "{ me { id } }"}, nil)
+
+		// then
+		var gqlErrs graphql.Errors
+		assert.True(t, errors.As(err, &gqlErrs))
+		assert.Equal(t, "NOT_FOUND", gqlErrs[0].Code())
+		assert.Equal(t, 1, reqCount)
+	})
+}
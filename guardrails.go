@@ -0,0 +1,99 @@
+package httpretry
+
+import (
+	"fmt"
+	"time"
+)
+
+// GuardrailMode 허용 범위를 벗어난 설정의 처리 방식
+type GuardrailMode string
+
+const (
+	// GuardrailClamp 허용 범위의 경계값으로 조정
+	GuardrailClamp GuardrailMode = "clamp"
+	// GuardrailReject Settings.Validate에서 유효하지 않은 설정으로 보고
+	//
+	// 에러를 반환할 수 없는 생성자(NewClient, New 등)는 허용 범위의 경계값으로 조정합니다.
+	GuardrailReject GuardrailMode = "reject"
+)
+
+// Guardrails 조직 전체에 적용할 설정의 허용 범위
+//
+// 0인 항목은 제한하지 않습니다.
+type Guardrails struct {
+	// Mode 허용 범위를 벗어난 설정의 처리 방식 (clamp, reject). 비어 있으면 clamp
+	Mode GuardrailMode
+	// MaxRetry 최대 시도 횟수. HostPolicies, RoutePolicies의 MaxRetry에도 적용
+	MaxRetry int
+	// MinResponseHeaderTimeout 최소 ResponseHeaderTimeout. ResponseHeaderTimeout이 0(제한 없음)인 경우 적용하지 않음
+	MinResponseHeaderTimeout time.Duration
+	// MinAttemptTimeout 시도 1회의 최소 타임아웃
+	MinAttemptTimeout time.Duration
+}
+
+// DefaultGuardrails 시도 10회, 1초 미만의 타임아웃을 허용하지 않는 허용 범위
+var DefaultGuardrails = Guardrails{
+	Mode:                     GuardrailReject,
+	MaxRetry:                 10,
+	MinResponseHeaderTimeout: time.Second,
+	MinAttemptTimeout:        time.Second,
+}
+
+// check 허용 범위를 벗어난 설정을 추가. reject 모드가 아닌 경우 검사하지 않음
+func (g *Guardrails) check(s *Settings, v *validator) {
+	if g == nil || g.Mode != GuardrailReject {
+		return
+	}
+	if g.MaxRetry > 0 {
+		v.check(s.MaxRetry <= g.MaxRetry, "MaxRetry", "must not exceed the guardrail of %d attempts, got %d", g.MaxRetry, s.MaxRetry)
+		for host, policy := range s.HostPolicies {
+			v.check(policy.MaxRetry <= g.MaxRetry, fmt.Sprintf("HostPolicies[%q].MaxRetry", host),
+				"must not exceed the guardrail of %d attempts, got %d", g.MaxRetry, policy.MaxRetry)
+		}
+		for i, route := range s.RoutePolicies {
+			v.check(route.Policy.MaxRetry <= g.MaxRetry, fmt.Sprintf("RoutePolicies[%d].Policy.MaxRetry", i),
+				"must not exceed the guardrail of %d attempts, got %d", g.MaxRetry, route.Policy.MaxRetry)
+		}
+	}
+	if s.ResponseHeaderTimeout > 0 {
+		v.check(s.ResponseHeaderTimeout >= g.MinResponseHeaderTimeout, "ResponseHeaderTimeout",
+			"must be at least the guardrail of %s, got %s", g.MinResponseHeaderTimeout, s.ResponseHeaderTimeout)
+	}
+	if timeout := s.attemptTimeout(); timeout > 0 {
+		v.check(timeout >= g.MinAttemptTimeout, "AttemptTimeout",
+			"must be at least the guardrail of %s, got %s", g.MinAttemptTimeout, timeout)
+	}
+}
+
+// clamp 허용 범위를 벗어난 설정을 경계값으로 조정
+func (g *Guardrails) clamp(s *Settings) {
+	if g == nil {
+		return
+	}
+	if g.MaxRetry > 0 {
+		s.MaxRetry = min(s.MaxRetry, g.MaxRetry)
+		for host, policy := range s.HostPolicies {
+			policy.MaxRetry = min(policy.MaxRetry, g.MaxRetry)
+			s.HostPolicies[host] = policy
+		}
+		for i := range s.RoutePolicies {
+			s.RoutePolicies[i].Policy.MaxRetry = min(s.RoutePolicies[i].Policy.MaxRetry, g.MaxRetry)
+		}
+	}
+	if s.ResponseHeaderTimeout > 0 {
+		s.ResponseHeaderTimeout = max(s.ResponseHeaderTimeout, g.MinResponseHeaderTimeout)
+	}
+	if timeout := s.attemptTimeout(); timeout > 0 && timeout < g.MinAttemptTimeout {
+		s.AttemptTimeout = g.MinAttemptTimeout
+	}
+}
+
+// guarded 허용 범위로 조정한 설정을 반환. 조정할 허용 범위가 없으면 설정을 그대로 반환
+func (s *Settings) guarded() *Settings {
+	if s.Guardrails == nil {
+		return s
+	}
+	guarded := s.clone()
+	s.Guardrails.clamp(guarded)
+	return guarded
+}
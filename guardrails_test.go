@@ -0,0 +1,60 @@
+package httpretry_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithGuardrails(t *testing.T) {
+	t.Run("reject 모드에서 허용 범위를 벗어난 설정을 보고하는 테스트", func(t *testing.T) {
+		// given
+		settings := httpretry.NewHTTPSettings(
+			httpretry.WithGuardrails(httpretry.DefaultGuardrails),
+			httpretry.WithMaxRetry(20),
+			httpretry.WithResponseHeaderTimeout(100*time.Millisecond),
+			httpretry.WithHostPolicy("api.example.com", httpretry.Policy{MaxRetry: 15}),
+		)
+
+		// when
+		client, err := httpretry.NewValidated(settings)
+
+		// then
+		assert.Nil(t, client)
+		assert.ErrorIs(t, err, httpretry.ErrInvalidSettings)
+		assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 3)
+		assert.ErrorContains(t, err, "MaxRetry: must not exceed the guardrail of 10 attempts, got 20")
+		assert.ErrorContains(t, err, `HostPolicies["api.example.com"].MaxRetry`)
+		assert.ErrorContains(t, err, "ResponseHeaderTimeout: must be at least the guardrail of 1s, got 100ms")
+	})
+
+	t.Run("clamp 모드에서 허용 범위의 경계값으로 조정하는 테스트", func(t *testing.T) {
+		// given
+		var received atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		settings := httpretry.NewHTTPSettings(
+			httpretry.WithGuardrails(httpretry.Guardrails{Mode: httpretry.GuardrailClamp, MaxRetry: 2}),
+			httpretry.WithMaxRetry(5),
+			httpretry.WithBackoffPolicy(noBackoff),
+		)
+		client := httpretry.NewClient(settings)
+
+		// when
+		_, err := client.Get(server.URL)
+
+		// then
+		assert.Error(t, err)
+		assert.Equal(t, int32(2), received.Load())
+		assert.NoError(t, settings.Validate())
+		assert.Equal(t, 5, settings.MaxRetry, "호출자의 설정은 변경하지 않아야 합니다.")
+	})
+}
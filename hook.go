@@ -0,0 +1,27 @@
+package httpretry
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// BeforeAttemptFunc 시도마다 요청을 보내기 직전에 호출되는 hook
+//
+// API 키 교체, 서명 갱신, 리전 전환처럼 시도마다 바뀌어야 하는 헤더와 URL을 수정합니다.
+// req는 시도마다 헤더와 URL을 복제한 요청이므로 원본 요청에 영향을 주지 않습니다.
+// 에러를 반환하면 요청을 보내지 않고 ReasonAborted로 재시도를 중단합니다.
+type BeforeAttemptFunc func(attempt int, req *http.Request) error
+
+// beforeAttempt 시도 요청의 헤더와 URL을 복제한 뒤 hook을 호출
+func (rt *retriableTransport) beforeAttempt(attempt int, attemptReq *http.Request) error {
+	attemptReq.Header = attemptReq.Header.Clone()
+	if attemptReq.Header == nil {
+		attemptReq.Header = make(http.Header)
+	}
+	attemptURL := *attemptReq.URL
+	attemptReq.URL = &attemptURL
+	if err := rt.beforeAttemptHook(attempt, attemptReq); err != nil {
+		return fmt.Errorf("before attempt(%d): %w", attempt, err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package httpretry_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithBeforeAttempt(t *testing.T) {
+	t.Run("시도마다 hook으로 수정한 헤더를 전송하고 원본 요청은 유지하는 테스트", func(t *testing.T) {
+		// given
+		var received []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = append(received, r.Header.Get("Authorization"))
+			if len(received) < 3 {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(3),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithBeforeAttempt(func(attempt int, req *http.Request) error {
+				req.Header.Set("Authorization", fmt.Sprintf("Bearer key-%d", attempt))
+				return nil
+			}),
+		), http.StatusUnauthorized)
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		req.Header.Set("Authorization", "Bearer original")
+
+		// when
+		resp, err := client.Do(req)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []string{"Bearer key-1", "Bearer key-2", "Bearer key-3"}, received)
+		assert.Equal(t, "Bearer original", req.Header.Get("Authorization"))
+	})
+
+	t.Run("hook이 에러를 반환하면 요청을 보내지 않고 재시도를 중단하는 테스트", func(t *testing.T) {
+		// given
+		errNoCredentials := errors.New("no credentials left")
+		var received int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(3),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithBeforeAttempt(func(attempt int, req *http.Request) error {
+				if attempt > 1 {
+					return errNoCredentials
+				}
+				return nil
+			}),
+		))
+
+		// when
+		_, err := client.Get(server.URL)
+
+		// then
+		var retryErr *httpretry.RetryError
+		assert.True(t, errors.As(err, &retryErr))
+		assert.Equal(t, httpretry.ReasonAborted, retryErr.Reason)
+		assert.Equal(t, 1, retryErr.Attempts)
+		assert.Equal(t, 1, received)
+		assert.ErrorIs(t, err, errNoCredentials)
+		assert.ErrorContains(t, err, "before attempt(2): no credentials left")
+	})
+}
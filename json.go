@@ -0,0 +1,90 @@
+package httpretry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// contentTypeJSON JSON 요청 바디의 Content-Type
+const contentTypeJSON = "application/json"
+
+// GetJSON GET 요청의 JSON 응답을 out에 디코딩
+//
+// 재시도는 client의 재시도 정책을 따르며, 2xx가 아닌 최종 응답은 에러로 반환합니다.
+//
+//	var user User
+//	err := httpretry.GetJSON(ctx, client, "https://api.example.com/users/1", &user)
+//
+// Parameters:
+//   - ctx: (context.Context) 요청 context
+//   - client: (Doer) 요청에 사용할 클라이언트. 보통 NewClient로 생성한 재시도 클라이언트
+//   - url: (string) 요청 URL
+//   - out: (any) 응답을 디코딩할 값의 포인터. nil인 경우 응답 바디를 버림
+func GetJSON(ctx context.Context, client Doer, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	return doJSON(client, req, out)
+}
+
+// PostJSON in을 JSON으로 인코딩하여 POST 요청하고, JSON 응답을 out에 디코딩
+//
+// 재시도할 때마다 같은 바디를 다시 전송합니다.
+//
+//	var created User
+//	err := httpretry.PostJSON(ctx, client, "https://api.example.com/users", NewUser{Name: "kim"}, &created)
+//
+// Parameters:
+//   - ctx: (context.Context) 요청 context
+//   - client: (Doer) 요청에 사용할 클라이언트. 보통 NewClient로 생성한 재시도 클라이언트
+//   - url: (string) 요청 URL
+//   - in: (any) JSON으로 인코딩할 요청 바디
+//   - out: (any) 응답을 디코딩할 값의 포인터. nil인 경우 응답 바디를 버림
+func PostJSON(ctx context.Context, client Doer, url string, in, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("encoding json request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentTypeJSON)
+	return doJSON(client, req, out)
+}
+
+// doJSON 요청을 전송하고 2xx 응답의 JSON 바디를 out에 디코딩
+func doJSON(client Doer, req *http.Request, out any) error {
+	req.Header.Set("Accept", contentTypeJSON)
+	return doDecode(client, req, out, func(r io.Reader) error {
+		if err := json.NewDecoder(r).Decode(out); err != nil {
+			return fmt.Errorf("decoding json response: %w", err)
+		}
+		return nil
+	})
+}
+
+// doDecode 요청을 전송하고 2xx 응답의 바디를 decode로 디코딩
+//
+// out이 nil이면 바디를 버리며, 2xx가 아닌 응답은 상태 코드를 포함한 에러로 반환합니다.
+func doDecode(client Doer, req *http.Request, out any, decode func(io.Reader) error) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d from %s %s", resp.StatusCode, req.Method, redactURL(req.URL))
+	}
+	if out == nil {
+		_, err := io.Copy(io.Discard, resp.Body)
+		return err
+	}
+	return decode(resp.Body)
+}
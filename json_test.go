@@ -0,0 +1,115 @@
+package httpretry_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+type jsonUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestJSON(t *testing.T) {
+	newClient := func() *http.Client {
+		return httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithRetryMethods(http.MethodGet, http.MethodPost),
+		))
+	}
+
+	t.Run("GetJSON으로 재시도한 응답을 디코딩하는 테스트", func(t *testing.T) {
+		// given
+		var received atomic.Int32
+		var accept atomic.Value
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			accept.Store(r.Header.Get("Accept"))
+			if received.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": 7, "name": "kim"}`))
+		}))
+		defer server.Close()
+		var user jsonUser
+
+		// when
+		err := httpretry.GetJSON(context.Background(), newClient(), server.URL, &user)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, jsonUser{ID: 7, Name: "kim"}, user)
+		assert.Equal(t, int32(2), received.Load())
+		assert.Equal(t, "application/json", accept.Load())
+	})
+
+	t.Run("PostJSON이 재시도마다 같은 JSON 바디를 전송하는 테스트", func(t *testing.T) {
+		// given
+		var (
+			mu          sync.Mutex
+			bodies      []string
+			contentType string
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			bodies = append(bodies, string(body))
+			contentType = r.Header.Get("Content-Type")
+			first := len(bodies) == 1
+			mu.Unlock()
+			if first {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.Write([]byte(`{"id": 8, "name": "lee"}`))
+		}))
+		defer server.Close()
+		var created jsonUser
+
+		// when
+		err := httpretry.PostJSON(context.Background(), newClient(), server.URL, jsonUser{Name: "lee"}, &created)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, jsonUser{ID: 8, Name: "lee"}, created)
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, "application/json", contentType)
+		assert.Equal(t, []string{`{"id":0,"name":"lee"}`, `{"id":0,"name":"lee"}`}, bodies)
+	})
+
+	t.Run("2xx가 아닌 응답과 잘못된 JSON은 에러를 반환하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/missing" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(`{"id":`))
+		}))
+		defer server.Close()
+		var user jsonUser
+
+		// when
+		statusErr := httpretry.GetJSON(context.Background(), newClient(), server.URL+"/missing?token=secret", &user)
+		decodeErr := httpretry.GetJSON(context.Background(), newClient(), server.URL, &user)
+		encodeErr := httpretry.PostJSON(context.Background(), newClient(), server.URL, func() {}, nil)
+		discardErr := httpretry.PostJSON(context.Background(), newClient(), server.URL, jsonUser{ID: 1}, nil)
+
+		// then
+		assert.ErrorContains(t, statusErr, "unexpected status code 404 from GET")
+		assert.NotContains(t, statusErr.Error(), "secret")
+		assert.ErrorContains(t, decodeErr, "decoding json response")
+		assert.ErrorContains(t, encodeErr, "encoding json request")
+		assert.NoError(t, discardErr)
+	})
+}
@@ -0,0 +1,65 @@
+package httpretry
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// jsonErrorPeekLimit JSON 에러 응답의 코드를 확인하기 위해 읽는 최대 바이트 수
+const jsonErrorPeekLimit = 64 << 10
+
+// JSONErrorInspector JSON 응답 바디의 에러 코드로 재시도 여부를 판단하는 BodyInspector
+//
+// path는 "error.code"처럼 '.'으로 구분한 필드 경로이며, 배열은 "errors.0.code"처럼 인덱스로 지정합니다.
+// JSONPath 형식의 "$." 접두사는 무시합니다. 숫자, bool 값은 문자열로 바꾸어 비교합니다.
+// JSON이 아니거나 peek 범위를 넘어 잘린 바디, 경로에 값이 없는 응답은 재시도하지 않습니다.
+//
+//	httpretry.WithBodyPeek(64<<10, httpretry.JSONErrorInspector("error.code", "RATE_LIMITED", "TRY_LATER"))
+//
+// Parameters:
+//   - path: (string) 에러 코드 필드 경로
+//   - codes: (...string) 재시도할 에러 코드
+func JSONErrorInspector(path string, codes ...string) BodyInspector {
+	fields := strings.Split(strings.TrimPrefix(path, "$."), ".")
+	return func(_ *http.Response, peek []byte) bool {
+		value, ok := jsonField(peek, fields)
+		return ok && slices.Contains(codes, value)
+	}
+}
+
+// jsonField JSON 바디에서 필드 경로의 값을 문자열로 반환
+func jsonField(body []byte, fields []string) (string, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return "", false
+	}
+	for _, field := range fields {
+		switch node := value.(type) {
+		case map[string]any:
+			value = node[field]
+		case []any:
+			index, err := strconv.Atoi(field)
+			if err != nil || index < 0 || index >= len(node) {
+				return "", false
+			}
+			value = node[index]
+		default:
+			return "", false
+		}
+	}
+	switch value := value.(type) {
+	case string:
+		return value, true
+	case json.Number:
+		return value.String(), true
+	case bool:
+		return strconv.FormatBool(value), true
+	}
+	return "", false
+}
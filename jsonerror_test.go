@@ -0,0 +1,86 @@
+package httpretry_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithJSONErrorRetry(t *testing.T) {
+	t.Run("200, 400 응답이어도 JSON 에러 코드가 일치하면 재시도하고 바디를 그대로 반환하는 테스트", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			status int
+			first  string
+		}{
+			{"200 응답", http.StatusOK, `{"error": {"code": "RATE_LIMITED"}}`},
+			{"400 응답", http.StatusBadRequest, `{"error": {"code": "TRY_LATER", "message": "busy"}}`},
+		}
+
+		for _, tt := range tests {
+			// given
+			var received atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if received.Add(1) == 1 {
+					w.WriteHeader(tt.status)
+					w.Write([]byte(tt.first))
+					return
+				}
+				w.Write([]byte(`{"data": {"id": 1}}`))
+			}))
+			client := httpretry.NewClient(httpretry.NewHTTPSettings(
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithJSONErrorRetry("error.code", "RATE_LIMITED", "TRY_LATER"),
+			))
+
+			// when
+			resp, err := client.Get(server.URL)
+
+			// then
+			assert.NoError(t, err, tt.name)
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode, tt.name)
+			assert.JSONEq(t, `{"data": {"id": 1}}`, string(body), tt.name)
+			assert.Equal(t, int32(2), received.Load(), tt.name)
+			server.Close()
+		}
+	})
+
+	t.Run("경로의 값이 재시도할 코드인지 판단하는 테스트", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			path  string
+			codes []string
+			body  string
+			retry bool
+		}{
+			{"문자열 코드", "error.code", []string{"RATE_LIMITED"}, `{"error": {"code": "RATE_LIMITED"}}`, true},
+			{"다른 코드", "error.code", []string{"RATE_LIMITED"}, `{"error": {"code": "NOT_FOUND"}}`, false},
+			{"JSONPath 접두사와 배열 인덱스", "$.errors.0.code", []string{"TRY_LATER"}, `{"errors": [{"code": "TRY_LATER"}]}`, true},
+			{"숫자 코드", "code", []string{"1013"}, `{"code": 1013}`, true},
+			{"필드 없음", "error.code", []string{"RATE_LIMITED"}, `{"data": []}`, false},
+			{"범위를 벗어난 인덱스", "errors.1.code", []string{"TRY_LATER"}, `{"errors": [{"code": "TRY_LATER"}]}`, false},
+			{"객체 값", "error", []string{"RATE_LIMITED"}, `{"error": {"code": "RATE_LIMITED"}}`, false},
+			{"JSON이 아닌 바디", "error.code", []string{"RATE_LIMITED"}, `RATE_LIMITED`, false},
+			{"잘린 바디", "error.code", []string{"RATE_LIMITED"}, `{"error": {"code": "RATE_LIMITED"`, false},
+		}
+
+		for _, tt := range tests {
+			// given
+			inspector := httpretry.JSONErrorInspector(tt.path, tt.codes...)
+
+			// when
+			retry := inspector(&http.Response{}, []byte(tt.body))
+
+			// then
+			assert.Equal(t, tt.retry, retry, tt.name)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package httpretry
+
+import "net/http"
+
+// Locale 재시도 사유와 디버그 로그에 사용하는 언어
+type Locale string
+
+const (
+	// LocaleEnglish 영어 (기본값)
+	LocaleEnglish Locale = "en"
+	// LocaleKorean 한국어
+	LocaleKorean Locale = "ko"
+)
+
+// localeMessages 언어별 메시지
+type localeMessages struct {
+	// statusReasons 기본 재시도 상태 코드의 재시도 사유
+	statusReasons map[int]string
+	// retrying 재시도 디버그 로그 메시지
+	retrying string
+	// givingUp 재시도 포기 디버그 로그 메시지
+	givingUp string
+}
+
+var messages = map[Locale]localeMessages{
+	LocaleEnglish: {
+		statusReasons: map[int]string{
+			http.StatusRequestTimeout:      "retrying on request timeout",
+			http.StatusTooEarly:            "retrying on too early",
+			http.StatusTooManyRequests:     "retrying on too many requests",
+			http.StatusInternalServerError: "retrying on internal server error",
+			http.StatusBadGateway:          "retrying on bad gateway",
+			http.StatusServiceUnavailable:  "retrying on service unavailable",
+			http.StatusGatewayTimeout:      "retrying on gateway timeout",
+		},
+		retrying: "retrying request",
+		givingUp: "giving up request",
+	},
+	LocaleKorean: {
+		statusReasons: map[int]string{
+			http.StatusRequestTimeout:      "요청 시간 초과로 재시도",
+			http.StatusTooEarly:            "early data 거절로 재시도",
+			http.StatusTooManyRequests:     "요청 한도 초과로 재시도",
+			http.StatusInternalServerError: "서버 처리 불가로 재시도",
+			http.StatusBadGateway:          "게이트웨이 오류로 재시도",
+			http.StatusServiceUnavailable:  "서비스 사용 불가상태로 재시도",
+			http.StatusGatewayTimeout:      "게이트웨이 타임아웃으로 재시도",
+		},
+		retrying: "요청 재시도",
+		givingUp: "요청 재시도 포기",
+	},
+}
+
+// messages 언어별 메시지를 반환. 지원하지 않는 언어는 영어를 사용
+func (l Locale) messages() localeMessages {
+	if m, ok := messages[l]; ok {
+		return m
+	}
+	return messages[LocaleEnglish]
+}
@@ -0,0 +1,51 @@
+package httpretry_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLocale(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		opts     []httpretry.HTTPOption
+		expected string
+	}{
+		{name: "기본 언어는 영어인 테스트", expected: "retrying on service unavailable"},
+		{
+			name:     "한국어 설정 시 한국어 재시도 사유를 사용하는 테스트",
+			opts:     []httpretry.HTTPOption{httpretry.WithLocale(httpretry.LocaleKorean)},
+			expected: "서비스 사용 불가상태로 재시도",
+		},
+		{
+			name:     "지원하지 않는 언어는 영어를 사용하는 테스트",
+			opts:     []httpretry.HTTPOption{httpretry.WithLocale("fr")},
+			expected: "retrying on service unavailable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			opts := append([]httpretry.HTTPOption{
+				httpretry.WithMaxRetry(1),
+				httpretry.WithBackoffPolicy(noBackoff),
+			}, tt.opts...)
+			client := httpretry.NewClient(httpretry.NewHTTPSettings(opts...))
+
+			// when
+			_, err := client.Get(server.URL)
+
+			// then
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
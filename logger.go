@@ -0,0 +1,54 @@
+package httpretry
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Logger 재시도 과정의 디버그 로그를 받는 인터페이스
+//
+// keysAndValues는 key, value가 번갈아 오는 구조화 필드입니다. (*slog.Logger를 그대로 사용할 수 있음)
+// zap, logrus는 logzap, loglogrus 패키지의 어댑터를 사용합니다.
+type Logger interface {
+	Debug(msg string, keysAndValues ...any)
+}
+
+type (
+	// stdLogger 디버그 모드에서 표준 log 패키지로 출력하는 Logger
+	stdLogger struct{}
+
+	// nopLogger 아무것도 출력하지 않는 Logger
+	nopLogger struct{}
+)
+
+// newLogger 설정으로 Logger를 생성
+//
+// Logger가 지정되면 디버그 모드와 관계없이 사용하며(레벨 필터링은 Logger가 담당),
+// 지정되지 않은 경우 디버그 모드에서만 표준 log 패키지로 출력합니다.
+func newLogger(settings *Settings) Logger {
+	switch {
+	case settings.Logger != nil:
+		return settings.Logger
+	case settings.DebugMode:
+		return stdLogger{}
+	default:
+		return nopLogger{}
+	}
+}
+
+// Debug "msg. key: value, key: value" 형식으로 출력
+func (stdLogger) Debug(msg string, keysAndValues ...any) {
+	fields := make([]string, 0, len(keysAndValues)/2)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		fields = append(fields, fmt.Sprintf("%v: %v", keysAndValues[i], keysAndValues[i+1]))
+	}
+	if len(fields) == 0 {
+		log.Println(msg)
+		return
+	}
+	log.Printf("%s. %s\n", msg, strings.Join(fields, ", "))
+}
+
+// Debug 출력하지 않음
+func (nopLogger) Debug(string, ...any) {}
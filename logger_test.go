@@ -0,0 +1,63 @@
+package httpretry_test
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLogger(t *testing.T) {
+	t.Run("디버그 모드가 아니어도 지정한 Logger로 재시도 로그를 전달하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(1),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithLogger(logger),
+		))
+
+		// when
+		client.Get(server.URL)
+
+		// then
+		assert.Contains(t, buf.String(), `msg="retrying request" attempt=1 status_code=502`)
+	})
+}
+
+func TestWithDebugSampling(t *testing.T) {
+	t.Run("재시도 로그는 N번 중 1번만 출력하고 재시도 포기 로그는 항상 출력하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(4),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithLogger(logger),
+			httpretry.WithDebugSampling(2),
+		))
+
+		// when
+		client.Get(server.URL)
+
+		// then
+		assert.Equal(t, 2, strings.Count(buf.String(), `msg="retrying request"`))
+		assert.Contains(t, buf.String(), `msg="retrying request" attempt=1 status_code=502`)
+		assert.Contains(t, buf.String(), "sample_rate=2")
+		assert.Contains(t, buf.String(), `msg="giving up request" reason=`)
+	})
+}
@@ -0,0 +1,39 @@
+// Package loglogrus httpretry 재시도 로그를 logrus Logger로 전달하는 어댑터
+//
+//	settings := httpretry.NewHTTPSettings(httpretry.WithLogger(loglogrus.New(logrus.StandardLogger())))
+package loglogrus
+
+import (
+	"fmt"
+
+	"github.com/dings-things/httpretry"
+	"github.com/sirupsen/logrus"
+)
+
+type logger struct {
+	entry logrus.FieldLogger
+}
+
+// New logrus Logger를 httpretry.Logger로 변환
+//
+// 로그 필드는 logrus.Fields로 그대로 전달됩니다. (attempt, status_code, error 등)
+//
+// Parameters:
+//   - l: (logrus.FieldLogger) 로그를 받을 logrus Logger 또는 Entry
+func New(l logrus.FieldLogger) httpretry.Logger {
+	return &logger{entry: l}
+}
+
+// Debug Debug 레벨로 출력
+func (l *logger) Debug(msg string, keysAndValues ...any) {
+	l.entry.WithFields(fields(keysAndValues)).Debug(msg)
+}
+
+// fields key, value 목록을 logrus.Fields로 변환. error 값은 logrus.ErrorKey 규칙과 같이 그대로 보관
+func fields(keysAndValues []any) logrus.Fields {
+	result := make(logrus.Fields, len(keysAndValues)/2)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		result[fmt.Sprint(keysAndValues[i])] = keysAndValues[i+1]
+	}
+	return result
+}
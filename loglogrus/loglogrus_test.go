@@ -0,0 +1,44 @@
+package loglogrus_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/dings-things/httpretry/loglogrus"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("재시도 로그를 필드와 함께 logrus로 전달하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		logger, hook := test.NewNullLogger()
+		logger.SetLevel(logrus.DebugLevel)
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(func(int) time.Duration { return 0 }),
+			httpretry.WithLogger(loglogrus.New(logger)),
+		))
+
+		// when
+		client.Get(server.URL)
+
+		// then
+		entries := hook.AllEntries()
+		assert.Len(t, entries, 3)
+		assert.Equal(t, logrus.DebugLevel, entries[0].Level)
+		assert.Equal(t, "retrying request", entries[0].Message)
+		assert.Equal(t, 1, entries[0].Data["attempt"])
+		assert.Equal(t, http.StatusServiceUnavailable, entries[0].Data["status_code"])
+		assert.Equal(t, "giving up request", entries[2].Message)
+		assert.Equal(t, 2, entries[2].Data["attempts"])
+	})
+}
@@ -0,0 +1,18 @@
+module github.com/dings-things/httpretry/logzap
+
+go 1.24
+
+replace github.com/dings-things/httpretry => ..
+
+require (
+	github.com/dings-things/httpretry v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.9.0
+	go.uber.org/zap v1.26.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.uber.org/multierr v1.11.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
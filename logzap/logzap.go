@@ -0,0 +1,28 @@
+// Package logzap httpretry 재시도 로그를 zap Logger로 전달하는 어댑터
+//
+//	settings := httpretry.NewHTTPSettings(httpretry.WithLogger(logzap.New(zapLogger)))
+package logzap
+
+import (
+	"github.com/dings-things/httpretry"
+	"go.uber.org/zap"
+)
+
+type logger struct {
+	sugar *zap.SugaredLogger
+}
+
+// New zap Logger를 httpretry.Logger로 변환
+//
+// 로그 필드는 zap 필드로 그대로 전달됩니다. (attempt, status_code, error 등)
+//
+// Parameters:
+//   - l: (*zap.Logger) 로그를 받을 zap Logger
+func New(l *zap.Logger) httpretry.Logger {
+	return &logger{sugar: l.WithOptions(zap.AddCallerSkip(1)).Sugar()}
+}
+
+// Debug Debug 레벨로 출력
+func (l *logger) Debug(msg string, keysAndValues ...any) {
+	l.sugar.Debugw(msg, keysAndValues...)
+}
@@ -0,0 +1,42 @@
+package logzap_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/dings-things/httpretry/logzap"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("재시도 로그를 필드와 함께 zap으로 전달하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		core, logs := observer.New(zapcore.DebugLevel)
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(func(int) time.Duration { return 0 }),
+			httpretry.WithLogger(logzap.New(zap.New(core))),
+		))
+
+		// when
+		client.Get(server.URL)
+
+		// then
+		entries := logs.FilterMessage("retrying request").All()
+		assert.Len(t, entries, 2)
+		fields := entries[0].ContextMap()
+		assert.Equal(t, int64(1), fields["attempt"])
+		assert.Equal(t, int64(http.StatusServiceUnavailable), fields["status_code"])
+		assert.Contains(t, fields["error"], "retrying on service unavailable")
+	})
+}
@@ -0,0 +1,180 @@
+// Package metricsprom httpretry 재시도 이벤트를 Prometheus 메트릭으로 기록하는 Observer
+//
+// 레이블은 Option으로 조절하여 cardinality를 관리합니다. method 레이블은 항상 포함됩니다.
+//
+//	observer, err := metricsprom.New(prometheus.DefaultRegisterer, metricsprom.WithStatusClass(true))
+//	settings := httpretry.NewHTTPSettings(httpretry.WithObserver(observer))
+package metricsprom
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/dings-things/httpretry"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type (
+	// Option 메트릭 레이블 설정
+	Option func(*config)
+
+	config struct {
+		host        bool
+		path        func(*http.Request) string
+		statusClass bool
+		buckets     []float64
+	}
+
+	// Observer 재시도 이벤트를 Prometheus 메트릭으로 기록
+	Observer struct {
+		config
+		attempts *prometheus.CounterVec
+		duration *prometheus.HistogramVec
+		retries  *prometheus.CounterVec
+		giveUps  *prometheus.CounterVec
+	}
+)
+
+// WithHost host 레이블 포함 여부를 설정 (기본값 true)
+//
+// Parameters:
+//   - enabled: (bool) host 레이블 포함 여부
+func WithHost(enabled bool) Option {
+	return func(c *config) {
+		c.host = enabled
+	}
+}
+
+// WithPathTemplate path 레이블을 만드는 함수를 설정. 설정하지 않으면 path 레이블을 포함하지 않음
+//
+// 경로의 ID 등은 "/users/{id}"처럼 템플릿으로 바꾸어 cardinality를 제한해야 합니다.
+//
+// Parameters:
+//   - template: (func(*http.Request) string) 요청의 path 레이블 값을 반환하는 함수
+func WithPathTemplate(template func(*http.Request) string) Option {
+	return func(c *config) {
+		c.path = template
+	}
+}
+
+// WithStatusClass status 레이블 대신 status_class 레이블(2xx, 5xx 등)을 사용 (기본값 false)
+//
+// Parameters:
+//   - enabled: (bool) status_class 레이블 사용 여부
+func WithStatusClass(enabled bool) Option {
+	return func(c *config) {
+		c.statusClass = enabled
+	}
+}
+
+// WithBuckets 시도 시간 histogram의 bucket을 설정 (기본값 prometheus.DefBuckets)
+//
+// Parameters:
+//   - buckets: ([]float64) histogram bucket (초)
+func WithBuckets(buckets []float64) Option {
+	return func(c *config) {
+		c.buckets = buckets
+	}
+}
+
+// New 메트릭을 등록하고 Observer를 생성
+//
+// 기록하는 메트릭:
+//   - httpretry_attempts_total: 시도 수 (status 또는 status_class)
+//   - httpretry_attempt_duration_seconds: 시도 시간
+//   - httpretry_retries_total: 재시도 수
+//   - httpretry_give_ups_total: 재시도 포기 수 (reason)
+//
+// Parameters:
+//   - registerer: (prometheus.Registerer) 메트릭을 등록할 Registerer
+//   - opts: (...Option) 레이블 설정
+func New(registerer prometheus.Registerer, opts ...Option) (*Observer, error) {
+	cfg := config{host: true, buckets: prometheus.DefBuckets}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	labels := cfg.labels()
+	status := "status"
+	if cfg.statusClass {
+		status = "status_class"
+	}
+	o := &Observer{
+		config: cfg,
+		attempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "httpretry_attempts_total",
+			Help: "Number of HTTP attempts sent, including retries.",
+		}, slices.Concat(labels, []string{status})),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "httpretry_attempt_duration_seconds",
+			Help:    "Duration of HTTP attempts until response headers or failure.",
+			Buckets: cfg.buckets,
+		}, labels),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "httpretry_retries_total",
+			Help: "Number of retries scheduled.",
+		}, labels),
+		giveUps: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "httpretry_give_ups_total",
+			Help: "Number of requests that gave up retrying.",
+		}, slices.Concat(labels, []string{"reason"})),
+	}
+	for _, collector := range []prometheus.Collector{o.attempts, o.duration, o.retries, o.giveUps} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, fmt.Errorf("metricsprom: registering metrics: %w", err)
+		}
+	}
+	return o, nil
+}
+
+// Observe 이벤트를 메트릭으로 기록
+func (o *Observer) Observe(req *http.Request, event httpretry.RetryEvent) {
+	values := o.values(req)
+	switch event.Type {
+	case httpretry.EventAttemptFinish:
+		o.attempts.WithLabelValues(append(values, o.status(event.StatusCode))...).Inc()
+		o.duration.WithLabelValues(values...).Observe(event.Duration.Seconds())
+	case httpretry.EventRetryScheduled:
+		o.retries.WithLabelValues(values...).Inc()
+	case httpretry.EventGiveUp:
+		o.giveUps.WithLabelValues(append(values, string(event.Reason))...).Inc()
+	}
+}
+
+// labels 설정에 따라 공통 레이블 이름을 반환
+func (c config) labels() []string {
+	labels := []string{"method"}
+	if c.host {
+		labels = append(labels, "host")
+	}
+	if c.path != nil {
+		labels = append(labels, "path")
+	}
+	return labels
+}
+
+// values 공통 레이블 값을 반환. labels와 같은 순서
+func (c config) values(req *http.Request) []string {
+	values := []string{req.Method}
+	if c.host {
+		values = append(values, req.URL.Host)
+	}
+	if c.path != nil {
+		values = append(values, c.path(req))
+	}
+	return values
+}
+
+// status 상태 코드 레이블 값. 응답을 받지 못한 경우 "error"
+func (c config) status(code int) string {
+	switch {
+	case code <= 0:
+		return "error"
+	case c.statusClass:
+		return strconv.Itoa(code/100) + "xx"
+	default:
+		return strconv.Itoa(code)
+	}
+}
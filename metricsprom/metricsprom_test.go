@@ -0,0 +1,97 @@
+package metricsprom_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/dings-things/httpretry/metricsprom"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	newServer := func() *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+	}
+	newClient := func(observer httpretry.Observer) *http.Client {
+		return httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(func(int) time.Duration { return 0 }),
+			httpretry.WithObserver(observer),
+		))
+	}
+
+	t.Run("기본 레이블(method, host, status)로 기록하는 테스트", func(t *testing.T) {
+		// given
+		server := newServer()
+		defer server.Close()
+		registry := prometheus.NewRegistry()
+		observer, err := metricsprom.New(registry)
+		assert.NoError(t, err)
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/users/1", nil)
+
+		// when
+		newClient(observer).Do(req)
+
+		// then
+		host := strings.TrimPrefix(server.URL, "http://")
+		expected := `
+# HELP httpretry_attempts_total Number of HTTP attempts sent, including retries.
+# TYPE httpretry_attempts_total counter
+httpretry_attempts_total{host="` + host + `",method="GET",status="503"} 2
+# HELP httpretry_give_ups_total Number of requests that gave up retrying.
+# TYPE httpretry_give_ups_total counter
+httpretry_give_ups_total{host="` + host + `",method="GET",reason="budget_exhausted"} 1
+`
+		assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+			"httpretry_attempts_total", "httpretry_give_ups_total"))
+	})
+
+	t.Run("host를 제외하고 path 템플릿과 status_class를 사용하는 테스트", func(t *testing.T) {
+		// given
+		server := newServer()
+		defer server.Close()
+		registry := prometheus.NewRegistry()
+		observer, err := metricsprom.New(registry,
+			metricsprom.WithHost(false),
+			metricsprom.WithPathTemplate(func(*http.Request) string { return "/users/{id}" }),
+			metricsprom.WithStatusClass(true),
+		)
+		assert.NoError(t, err)
+		client := newClient(observer)
+
+		// when
+		for _, path := range []string{"/users/1", "/users/2"} {
+			req, _ := http.NewRequest(http.MethodGet, server.URL+path, nil)
+			client.Do(req)
+		}
+
+		// then
+		expected := `
+# HELP httpretry_attempts_total Number of HTTP attempts sent, including retries.
+# TYPE httpretry_attempts_total counter
+httpretry_attempts_total{method="GET",path="/users/{id}",status_class="5xx"} 4
+`
+		assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+			"httpretry_attempts_total"))
+	})
+
+	t.Run("이미 등록된 메트릭은 에러를 반환하는 테스트", func(t *testing.T) {
+		// given
+		registry := prometheus.NewRegistry()
+		_, _ = metricsprom.New(registry)
+
+		// when
+		_, err := metricsprom.New(registry)
+
+		// then
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,74 @@
+package httpretry
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+)
+
+type (
+	// mirrorKey 미러링된 요청임을 표시하는 context key. 미러링된 요청은 다시 미러링하지 않음
+	mirrorKey struct{}
+
+	// mirror 요청 일부를 shadow 엔드포인트로 복제
+	mirror struct {
+		target  *url.URL
+		percent float64
+	}
+)
+
+// newMirror 미러링 설정을 생성. 미러링하지 않는 경우 nil을 반환
+func newMirror(settings *Settings) *mirror {
+	if settings.MirrorURL == "" || settings.MirrorPercent <= 0 {
+		return nil
+	}
+	target, err := url.Parse(settings.MirrorURL)
+	if err != nil {
+		newLogger(settings).Debug("mirroring disabled. invalid mirror url", "url", settings.MirrorURL, "error", err)
+		return nil
+	}
+	return &mirror{target: target, percent: settings.MirrorPercent}
+}
+
+// mirrorRequest 설정한 비율로 요청을 shadow 엔드포인트에 비동기로 전송하고, 응답은 버림
+//
+// 미러링된 요청은 원본 요청의 취소와 관계없이 같은 재시도 정책으로 전송됩니다.
+// 바디가 MaxReplayableBodySize보다 커서 한 번만 읽을 수 있는 경우(replayable이 false), 원본 요청이 바디를 사용하도록 미러링하지 않습니다.
+func (rt *retriableTransport) mirrorRequest(req *http.Request, getBody func() (io.ReadCloser, error), replayable bool) {
+	if rt.mirror == nil || req.Context().Value(mirrorKey{}) != nil {
+		return
+	}
+	if getBody != nil && !replayable {
+		rt.logger.Debug("mirror skipped. request body is not replayable", "url", redactURL(req.URL))
+		return
+	}
+	if rand.Float64()*100 >= rt.mirror.percent {
+		return
+	}
+
+	ctx := context.WithValue(context.WithoutCancel(req.Context()), mirrorKey{}, true)
+	shadow := req.Clone(ctx)
+	shadow.URL.Scheme = rt.mirror.target.Scheme
+	shadow.URL.Host = rt.mirror.target.Host
+	shadow.Host = ""
+	if getBody != nil {
+		body, err := getBody()
+		if err != nil {
+			return
+		}
+		shadow.Body = body
+		shadow.GetBody = getBody
+	}
+
+	go func() {
+		resp, err := rt.RoundTrip(shadow)
+		if err != nil {
+			rt.logger.Debug("mirror request failed", "url", redactURL(shadow.URL), "error", err)
+			return
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+}
@@ -0,0 +1,99 @@
+package httpretry_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithMirror(t *testing.T) {
+	t.Run("요청을 shadow 엔드포인트로 복제하고, 원본 응답을 반환하는 테스트", func(t *testing.T) {
+		// given
+		mirrored := make(chan string, 1)
+		shadowServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				mirrored <- r.Method + " " + r.URL.RequestURI() + " " + string(body)
+				w.WriteHeader(http.StatusInternalServerError)
+			}),
+		)
+		defer shadowServer.Close()
+		primaryServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				w.Write(body)
+			}),
+		)
+		defer primaryServer.Close()
+
+		client := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(1),
+				httpretry.WithMirror(shadowServer.URL, 100),
+			),
+		)
+
+		// when
+		resp, err := client.Post(primaryServer.URL+"/orders?dry=1", "text/plain", strings.NewReader("payload"))
+
+		// then
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, "payload", string(body))
+		select {
+		case got := <-mirrored:
+			assert.Equal(t, "POST /orders?dry=1 payload", got)
+		case <-time.After(time.Second):
+			t.Fatal("요청이 shadow 엔드포인트로 복제되어야 합니다.")
+		}
+	})
+
+	t.Run("바디가 재전송 가능한 크기를 넘으면 미러링하지 않고 원본 요청을 전송하는 테스트", func(t *testing.T) {
+		// given
+		mirrored := make(chan string, 1)
+		shadowServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				mirrored <- string(body)
+			}),
+		)
+		defer shadowServer.Close()
+		primaryServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				w.Write(body)
+			}),
+		)
+		defer primaryServer.Close()
+
+		client := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(1),
+				httpretry.WithMaxReplayableBodySize(4),
+				httpretry.WithMirror(shadowServer.URL, 100),
+			),
+		)
+		req, _ := http.NewRequest(http.MethodPost, primaryServer.URL, io.NopCloser(strings.NewReader("large payload")))
+
+		// when
+		resp, err := client.Do(req)
+
+		// then
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, "large payload", string(body))
+		select {
+		case got := <-mirrored:
+			t.Fatalf("한 번만 읽을 수 있는 바디는 미러링하지 않아야 합니다. got %q", got)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+}
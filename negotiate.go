@@ -0,0 +1,67 @@
+package httpretry
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+)
+
+// ErrNotAcceptable 모든 표현(content type)이 406 Not Acceptable 또는 415 Unsupported Media Type으로 거절된 경우
+var ErrNotAcceptable = errors.New("no acceptable representation")
+
+// Negotiate 선호하는 순서대로 Accept 헤더를 바꿔 가며 요청하고, 응답과 실제로 받은 표현을 반환
+//
+// 406 Not Acceptable 또는 415 Unsupported Media Type 응답은 서버가 해당 표현을 제공할 수 없다는 의미이므로,
+// 다음 content type으로 다시 요청합니다. 개별 요청의 재시도는 client의 재시도 정책을 따릅니다.
+// 받은 표현은 응답의 Content-Type(파라미터 제외)이며, Content-Type이 없으면 요청한 content type입니다.
+//
+// 바디가 있는 요청은 다시 보낼 수 있도록 GetBody가 설정되어 있어야 합니다. (http.NewRequest에 bytes.Reader 등을 전달한 경우)
+// 반환한 응답의 바디는 호출자가 닫아야 합니다.
+//
+//	resp, served, err := httpretry.Negotiate(client, req, "application/vnd.api.v2+json", "application/json", "text/csv")
+//
+// Parameters:
+//   - client: (Doer) 요청에 사용할 클라이언트. 보통 NewClient로 생성한 재시도 클라이언트
+//   - req: (*http.Request) 보낼 요청. 변경하지 않고 content type마다 복제하여 사용
+//   - contentTypes: (...string) 선호하는 순서대로 나열한 content type
+func Negotiate(client Doer, req *http.Request, contentTypes ...string) (*http.Response, string, error) {
+	if len(contentTypes) == 0 {
+		return nil, "", errors.New("negotiate: no content types")
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return nil, "", errors.New("negotiate: request body cannot be replayed without GetBody")
+	}
+
+	for i, contentType := range contentTypes {
+		attempt := req.Clone(req.Context())
+		attempt.Header.Set("Accept", contentType)
+		if i > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, "", fmt.Errorf("negotiate: rewinding request body: %w", err)
+			}
+			attempt.Body = body
+		}
+
+		resp, err := client.Do(attempt)
+		if err != nil {
+			return nil, "", err
+		}
+		if resp.StatusCode == http.StatusNotAcceptable || resp.StatusCode == http.StatusUnsupportedMediaType {
+			resp.Body.Close()
+			continue
+		}
+		return resp, servedType(resp, contentType), nil
+	}
+	return nil, "", fmt.Errorf("%s %s accepts none of %q: %w", req.Method, redactURL(req.URL), contentTypes, ErrNotAcceptable)
+}
+
+// servedType 응답의 Content-Type에서 파라미터를 제외한 media type. 없으면 요청한 content type
+func servedType(resp *http.Response, requested string) string {
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return requested
+	}
+	return mediaType
+}
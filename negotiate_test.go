@@ -0,0 +1,68 @@
+package httpretry_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	// csvOnly text/csv만 제공하고 바디를 그대로 돌려주는 서버
+	csvOnly := func(accepts *[]string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*accepts = append(*accepts, r.Header.Get("Accept"))
+			switch r.Header.Get("Accept") {
+			case "text/csv":
+				w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+				io.Copy(w, r.Body)
+			case "application/xml":
+				w.WriteHeader(http.StatusUnsupportedMediaType)
+			default:
+				w.WriteHeader(http.StatusNotAcceptable)
+			}
+		}))
+	}
+
+	t.Run("406, 415 응답이면 다음 content type으로 요청하고 받은 표현을 반환하는 테스트", func(t *testing.T) {
+		// given
+		var accepts []string
+		server := csvOnly(&accepts)
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings())
+		req, _ := http.NewRequest(http.MethodPost, server.URL, bytes.NewReader([]byte("a,b")))
+
+		// when
+		resp, served, err := httpretry.Negotiate(client, req, "application/json", "application/xml", "text/csv")
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "text/csv", served)
+		assert.Equal(t, "a,b", string(body), "다음 content type으로 요청할 때 바디를 다시 보내야 합니다.")
+		assert.Equal(t, []string{"application/json", "application/xml", "text/csv"}, accepts)
+		assert.Empty(t, req.Header.Get("Accept"), "호출자의 요청은 변경하지 않아야 합니다.")
+	})
+
+	t.Run("모든 content type이 거절되면 ErrNotAcceptable을 반환하는 테스트", func(t *testing.T) {
+		// given
+		var accepts []string
+		server := csvOnly(&accepts)
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings())
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+		// when
+		resp, _, err := httpretry.Negotiate(client, req, "application/json", "application/xml")
+
+		// then
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, httpretry.ErrNotAcceptable)
+		assert.Len(t, accepts, 2)
+	})
+}
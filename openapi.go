@@ -0,0 +1,52 @@
+package httpretry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RequestEditor 생성된 클라이언트의 operation 메서드에 넘기는 요청 편집 함수
+//
+// oapi-codegen의 RequestEditorFn과 같은 함수 타입의 별칭이므로 reqEditors 인자로 그대로 넘길 수 있습니다.
+// operation별 재시도 설정은 요청 헤더(HeaderMaxRetries, HeaderTimeout)로 전달되며, 재시도 클라이언트가 전송 전에 제거합니다.
+//
+//	resp, err := api.GetItem(ctx, id, httpretry.OperationMaxRetry(5), httpretry.OperationTimeout(time.Second))
+type RequestEditor = func(ctx context.Context, req *http.Request) error
+
+// OperationMaxRetry operation의 최대 시도 횟수를 지정하는 RequestEditor
+//
+// Parameters:
+//   - maxRetry: (int) 첫 시도를 포함한 최대 시도 횟수. 1 이상
+func OperationMaxRetry(maxRetry int) RequestEditor {
+	return func(_ context.Context, req *http.Request) error {
+		if maxRetry < 1 {
+			return fmt.Errorf("operation max retry must be at least 1 (total attempts), got %d", maxRetry)
+		}
+		req.Header.Set(HeaderMaxRetries, strconv.Itoa(maxRetry))
+		return nil
+	}
+}
+
+// OperationTimeout operation의 시도별 타임아웃을 지정하는 RequestEditor
+//
+// Parameters:
+//   - timeout: (time.Duration) 시도 1회의 타임아웃. 0보다 커야 함
+func OperationTimeout(timeout time.Duration) RequestEditor {
+	return func(_ context.Context, req *http.Request) error {
+		if timeout <= 0 {
+			return fmt.Errorf("operation timeout must be positive, got %s", timeout)
+		}
+		req.Header.Set(HeaderTimeout, timeout.String())
+		return nil
+	}
+}
+
+// OperationNoRetry operation을 재시도하지 않도록 하는 RequestEditor
+//
+// 멱등하지 않은 operation(결제 생성 등)에 사용합니다.
+func OperationNoRetry() RequestEditor {
+	return OperationMaxRetry(1)
+}
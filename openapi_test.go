@@ -0,0 +1,143 @@
+package httpretry_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	// httpRequestDoer oapi-codegen이 생성하는 클라이언트 인터페이스
+	httpRequestDoer interface {
+		Do(req *http.Request) (*http.Response, error)
+	}
+
+	// requestEditorFn oapi-codegen이 생성하는 요청 편집 함수 타입
+	requestEditorFn func(ctx context.Context, req *http.Request) error
+
+	// generatedClient oapi-codegen이 생성하는 클라이언트
+	generatedClient struct {
+		server string
+		client httpRequestDoer
+	}
+)
+
+// getItem oapi-codegen이 생성하는 operation 메서드
+func (c *generatedClient) getItem(ctx context.Context, reqEditors ...requestEditorFn) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.server+"/items/1", nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, editor := range reqEditors {
+		if err := editor(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return c.client.Do(req)
+}
+
+func TestGeneratedClient(t *testing.T) {
+	newServer := func(calls *atomic.Int32) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+	}
+	newAPI := func(server *httptest.Server) *generatedClient {
+		return &generatedClient{server: server.URL, client: httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(3),
+			httpretry.WithBackoffPolicy(noBackoff),
+		))}
+	}
+
+	t.Run("operation별 RequestEditor로 재시도 횟수를 바꾸는 테스트", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			editors []requestEditorFn
+			calls   int32
+		}{
+			{"기본 설정", nil, 3},
+			{"최대 시도 횟수 지정", []requestEditorFn{httpretry.OperationMaxRetry(2)}, 2},
+			{"재시도하지 않음", []requestEditorFn{httpretry.OperationNoRetry(), httpretry.OperationTimeout(time.Second)}, 1},
+		}
+
+		for _, tt := range tests {
+			// given
+			var calls atomic.Int32
+			server := newServer(&calls)
+			api := newAPI(server)
+
+			// when
+			_, err := api.getItem(context.Background(), tt.editors...)
+
+			// then
+			var retryErr *httpretry.RetryError
+			assert.ErrorAs(t, err, &retryErr, tt.name)
+			assert.Equal(t, int(tt.calls), retryErr.Attempts, tt.name)
+			assert.Equal(t, tt.calls, calls.Load(), tt.name)
+			server.Close()
+		}
+	})
+
+	t.Run("유효하지 않은 값이면 요청하지 않고 에러를 반환하는 테스트", func(t *testing.T) {
+		// given
+		var calls atomic.Int32
+		server := newServer(&calls)
+		defer server.Close()
+		api := newAPI(server)
+
+		// when
+		_, retryErr := api.getItem(context.Background(), httpretry.OperationMaxRetry(0))
+		_, timeoutErr := api.getItem(context.Background(), httpretry.OperationTimeout(0))
+
+		// then
+		assert.ErrorContains(t, retryErr, "operation max retry must be at least 1")
+		assert.ErrorContains(t, timeoutErr, "operation timeout must be positive")
+		assert.Zero(t, calls.Load())
+	})
+
+	t.Run("취소된 context는 재시도하지 않고 context 에러를 반환하는 테스트", func(t *testing.T) {
+		// given
+		var calls atomic.Int32
+		server := newServer(&calls)
+		defer server.Close()
+		api := newAPI(server)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		resp, err := api.getItem(ctx)
+
+		// then
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Zero(t, calls.Load())
+	})
+
+	t.Run("DoerFunc로 요청을 감싸 전달하는 테스트", func(t *testing.T) {
+		// given
+		var calls atomic.Int32
+		server := newServer(&calls)
+		defer server.Close()
+		retryClient := newAPI(server).client
+		var seen []string
+		api := &generatedClient{server: server.URL, client: httpretry.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			seen = append(seen, req.URL.Path)
+			return retryClient.Do(req)
+		})}
+
+		// when
+		_, err := api.getItem(context.Background(), httpretry.OperationNoRetry())
+
+		// then
+		assert.Error(t, err)
+		assert.Equal(t, []string{"/items/1"}, seen)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
@@ -0,0 +1,151 @@
+package httpretry
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+type (
+	// OutlierDetection 엔드포인트 분배에서 장애 엔드포인트를 일시적으로 제외하는 정책 (Envoy outlier detection)
+	//
+	// 연속 실패 수 또는 Interval 동안의 실패율이 기준을 넘은 엔드포인트를 제외하며,
+	// 제외 시간은 제외될 때마다 BaseEjectionTime부터 두 배씩 늘어납니다. (MaxEjectionTime까지)
+	// 실패는 응답을 받지 못했거나 5xx 응답을 받은 시도입니다.
+	OutlierDetection struct {
+		// ConsecutiveFailures 엔드포인트를 제외하는 연속 실패 수. 0인 경우 5
+		ConsecutiveFailures int
+		// FailureRate 엔드포인트를 제외하는 실패율 (0~1). 0인 경우 실패율로 제외하지 않음
+		FailureRate float64
+		// MinRequests 실패율을 판단하기 위한 Interval 동안의 최소 시도 수. 0인 경우 10
+		MinRequests int
+		// Interval 실패율을 집계하는 기간. 0인 경우 10초
+		Interval time.Duration
+		// BaseEjectionTime 처음 제외하는 시간. 0인 경우 30초
+		BaseEjectionTime time.Duration
+		// MaxEjectionTime 최대 제외 시간. 0인 경우 5분
+		MaxEjectionTime time.Duration
+		// MaxEjectionPercent 동시에 제외할 수 있는 엔드포인트 비율 (0~100). 0인 경우 50.
+		// 최소 한 개는 제외할 수 있으며, 모든 엔드포인트를 제외하지는 않음
+		MaxEjectionPercent float64
+	}
+
+	// endpointHealth 엔드포인트별 실패 추적
+	endpointHealth struct {
+		consecutive  int
+		requests     int
+		failures     int
+		windowStart  time.Time
+		ejections    int
+		ejectedUntil time.Time
+	}
+
+	// outlierDetector 엔드포인트별 실패를 추적하고 장애 엔드포인트를 제외
+	outlierDetector struct {
+		policy OutlierDetection
+		mu     sync.Mutex
+		health []endpointHealth
+	}
+)
+
+// newOutlierDetector 정책이 설정되지 않으면 nil을 반환
+func newOutlierDetector(policy *OutlierDetection, group *endpointGroup) *outlierDetector {
+	if policy == nil || group == nil {
+		return nil
+	}
+	p := *policy
+	if p.ConsecutiveFailures <= 0 {
+		p.ConsecutiveFailures = 5
+	}
+	if p.MinRequests <= 0 {
+		p.MinRequests = 10
+	}
+	if p.Interval <= 0 {
+		p.Interval = 10 * time.Second
+	}
+	if p.BaseEjectionTime <= 0 {
+		p.BaseEjectionTime = 30 * time.Second
+	}
+	if p.MaxEjectionTime <= 0 {
+		p.MaxEjectionTime = 5 * time.Minute
+	}
+	if p.MaxEjectionPercent <= 0 {
+		p.MaxEjectionPercent = 50
+	}
+	return &outlierDetector{
+		policy: p,
+		health: make([]endpointHealth, len(group.endpoints)),
+	}
+}
+
+// observe 시도 종료 이벤트로 엔드포인트의 성공/실패를 기록하고, 기준을 넘으면 제외
+//
+// 엔드포인트는 host가 아닌 시도 context에 기록된 인덱스로 구분하므로, 같은 host의 엔드포인트도 따로 추적합니다.
+func (d *outlierDetector) observe(req *http.Request, event RetryEvent) {
+	if event.Type != EventAttemptFinish {
+		return
+	}
+	index, ok := endpointIndex(req.Context())
+	if !ok || index >= len(d.health) {
+		return
+	}
+	failed := event.Err != nil || event.StatusCode >= http.StatusInternalServerError
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := event.Time
+	health := &d.health[index]
+	if now.Sub(health.windowStart) >= d.policy.Interval {
+		// 실패 없이 Interval이 지나면 제외 시간을 한 단계 줄임
+		if health.failures == 0 && health.ejections > 0 {
+			health.ejections--
+		}
+		health.requests, health.failures, health.windowStart = 0, 0, now
+	}
+	health.requests++
+	if !failed {
+		health.consecutive = 0
+		return
+	}
+	health.consecutive++
+	health.failures++
+
+	if now.Before(health.ejectedUntil) {
+		return
+	}
+	exceeded := health.consecutive >= d.policy.ConsecutiveFailures ||
+		(d.policy.FailureRate > 0 && health.requests >= d.policy.MinRequests &&
+			float64(health.failures)/float64(health.requests) >= d.policy.FailureRate)
+	if !exceeded || !d.canEject(now) {
+		return
+	}
+	ejection := min(d.policy.BaseEjectionTime<<health.ejections, d.policy.MaxEjectionTime)
+	if ejection <= 0 {
+		ejection = d.policy.MaxEjectionTime
+	}
+	health.ejectedUntil = now.Add(ejection)
+	health.ejections++
+	health.consecutive, health.requests, health.failures, health.windowStart = 0, 0, 0, now
+}
+
+// canEject MaxEjectionPercent를 넘지 않는지 확인. 최소 한 개는 제외할 수 있으며, 모두 제외하지는 않음
+func (d *outlierDetector) canEject(now time.Time) bool {
+	ejected := 0
+	for _, health := range d.health {
+		if now.Before(health.ejectedUntil) {
+			ejected++
+		}
+	}
+	allowed := max(1, int(float64(len(d.health))*d.policy.MaxEjectionPercent/100))
+	return ejected < min(allowed, len(d.health)-1)
+}
+
+// available 엔드포인트가 제외되지 않았는지 확인
+func (d *outlierDetector) available(index int) bool {
+	if d == nil {
+		return true
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return !time.Now().Before(d.health[index].ejectedUntil)
+}
@@ -0,0 +1,117 @@
+package httpretry_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithOutlierDetection(t *testing.T) {
+	newServers := func(badHits *atomic.Int32) (bad, good *httptest.Server) {
+		bad = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			badHits.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		good = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		return bad, good
+	}
+	get := func(t *testing.T, client *http.Client) {
+		req, _ := http.NewRequest(http.MethodGet, "http://orders/v1/orders", nil)
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	t.Run("연속으로 실패한 엔드포인트를 제외하는 테스트", func(t *testing.T) {
+		// given
+		var badHits atomic.Int32
+		bad, good := newServers(&badHits)
+		defer bad.Close()
+		defer good.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithEndpoints("http://orders", bad.URL, good.URL),
+			httpretry.WithOutlierDetection(httpretry.OutlierDetection{ConsecutiveFailures: 2}),
+		))
+
+		// when
+		for range 10 {
+			get(t, client)
+		}
+
+		// then
+		assert.Equal(t, int32(2), badHits.Load())
+	})
+
+	t.Run("제외 시간이 지나면 엔드포인트로 다시 분배하는 테스트", func(t *testing.T) {
+		// given
+		var badHits atomic.Int32
+		bad, good := newServers(&badHits)
+		defer bad.Close()
+		defer good.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithEndpoints("http://orders", bad.URL, good.URL),
+			httpretry.WithOutlierDetection(httpretry.OutlierDetection{
+				ConsecutiveFailures: 1,
+				BaseEjectionTime:    50 * time.Millisecond,
+			}),
+		))
+		get(t, client)
+		ejectedHits := badHits.Load()
+		for range 4 {
+			get(t, client)
+		}
+		assert.Equal(t, ejectedHits, badHits.Load())
+
+		// when
+		time.Sleep(60 * time.Millisecond)
+		for range 4 {
+			get(t, client)
+		}
+
+		// then
+		assert.Greater(t, badHits.Load(), ejectedHits)
+	})
+
+	t.Run("같은 host의 엔드포인트는 따로 추적하여 실패한 엔드포인트만 제외하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		address := strings.TrimPrefix(server.URL, "http://")
+		var tlsAttempts atomic.Int32
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(noBackoff),
+			// TLS를 사용하지 않는 서버이므로 https 엔드포인트는 항상 실패
+			httpretry.WithEndpoints("http://orders", "https://"+address, "http://"+address),
+			httpretry.WithOutlierDetection(httpretry.OutlierDetection{ConsecutiveFailures: 2}),
+			httpretry.WithBeforeAttempt(func(_ int, req *http.Request) error {
+				if req.URL.Scheme == "https" {
+					tlsAttempts.Add(1)
+				}
+				return nil
+			}),
+		))
+
+		// when
+		for range 10 {
+			get(t, client)
+		}
+
+		// then
+		assert.Equal(t, int32(2), tlsAttempts.Load())
+	})
+}
@@ -0,0 +1,73 @@
+package httpretry
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// HeaderMaxRetries 요청의 최대 재시도 횟수를 지정하는 헤더
+	HeaderMaxRetries = "X-Httpretry-Max-Retries"
+	// HeaderTimeout 요청의 시도별 타임아웃을 지정하는 헤더 (time.ParseDuration 형식, 예: "500ms")
+	HeaderTimeout = "X-Httpretry-Timeout"
+)
+
+// overrides 요청 헤더로 지정한 재시도 설정
+type overrides struct {
+	maxRetries     *int
+	attemptTimeout time.Duration
+}
+
+// parseOverrides 요청 헤더에서 재시도 설정을 읽고, 해당 헤더를 제거한 요청을 반환
+//
+// 헤더가 없으면 원본 요청을 그대로 반환하며, 헤더가 있으면 원본 요청을 변경하지 않도록 복제합니다.
+// 헤더 값이 잘못된 경우 RoundTripper 규약에 따라 요청 바디를 닫고 에러를 반환합니다.
+func parseOverrides(req *http.Request) (*http.Request, overrides, error) {
+	var result overrides
+	maxRetries := req.Header.Get(HeaderMaxRetries)
+	timeout := req.Header.Get(HeaderTimeout)
+	if maxRetries == "" && timeout == "" {
+		return req, result, nil
+	}
+
+	if maxRetries != "" {
+		value, err := strconv.Atoi(maxRetries)
+		if err != nil || value < 0 {
+			closeRequestBody(req)
+			return nil, result, fmt.Errorf("invalid %s header: %q", HeaderMaxRetries, maxRetries)
+		}
+		result.maxRetries = &value
+	}
+	if timeout != "" {
+		value, err := time.ParseDuration(timeout)
+		if err != nil || value <= 0 {
+			closeRequestBody(req)
+			return nil, result, fmt.Errorf("invalid %s header: %q", HeaderTimeout, timeout)
+		}
+		result.attemptTimeout = value
+	}
+
+	stripped := req.Clone(req.Context())
+	stripped.Header.Del(HeaderMaxRetries)
+	stripped.Header.Del(HeaderTimeout)
+	return stripped, result, nil
+}
+
+// apply 요청 헤더로 지정한 설정을 재시도 정책과 시도별 타임아웃에 적용
+func (o overrides) apply(policy *retryPolicy, attemptTimeout *time.Duration) {
+	if o.maxRetries != nil {
+		policy.maxRetries = *o.maxRetries
+	}
+	if o.attemptTimeout > 0 {
+		*attemptTimeout = o.attemptTimeout
+	}
+}
+
+// closeRequestBody 요청을 전송하지 않고 종료할 때 요청 바디를 닫음
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
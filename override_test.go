@@ -0,0 +1,107 @@
+package httpretry_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeaderOverrides(t *testing.T) {
+	t.Run("요청 헤더로 최대 재시도 횟수와 타임아웃을 지정하고, 헤더는 전송하지 않는 테스트", func(t *testing.T) {
+		// given
+		var (
+			mu       sync.Mutex
+			reqCount int
+			received http.Header
+		)
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				reqCount++
+				received = r.Header.Clone()
+				mu.Unlock()
+				time.Sleep(100 * time.Millisecond)
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+		defer testServer.Close()
+
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(5),
+				httpretry.WithAttemptTimeout(time.Second),
+				httpretry.WithBackoffPolicy(noBackoff),
+			),
+		)
+		req, _ := http.NewRequest(http.MethodGet, testServer.URL, nil)
+		req.Header.Set(httpretry.HeaderMaxRetries, "2")
+		req.Header.Set(httpretry.HeaderTimeout, "20ms")
+
+		// when
+		_, err := retryClient.Do(req)
+
+		// then
+		assert.Error(t, err)
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, 2, reqCount)
+		assert.Empty(t, received.Get(httpretry.HeaderMaxRetries))
+		assert.Empty(t, received.Get(httpretry.HeaderTimeout))
+		assert.Equal(t, "2", req.Header.Get(httpretry.HeaderMaxRetries), "원본 요청은 변경하지 않아야 합니다.")
+	})
+
+	t.Run("잘못된 헤더 값인 경우, 요청하지 않고 요청 바디를 닫은 뒤 에러 반환 테스트", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			header string
+			value  string
+		}{
+			{"잘못된 최대 재시도 횟수", httpretry.HeaderMaxRetries, "-1"},
+			{"잘못된 타임아웃", httpretry.HeaderTimeout, "soon"},
+		}
+
+		for _, tt := range tests {
+			// given
+			var reqCount atomic.Int32
+			testServer := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					reqCount.Add(1)
+				}),
+			)
+
+			retryClient := httpretry.NewClient(httpretry.NewHTTPSettings())
+			body := &closeTrackingBody{Reader: strings.NewReader("payload")}
+			req, _ := http.NewRequest(http.MethodPost, testServer.URL, body)
+			req.Header.Set(tt.header, tt.value)
+
+			// when
+			_, err := retryClient.Do(req)
+
+			// then
+			assert.ErrorContains(t, err, tt.header, tt.name)
+			assert.Zero(t, reqCount.Load(), tt.name)
+			assert.True(t, body.closed.Load(), tt.name)
+			testServer.Close()
+		}
+	})
+}
+
+// closeTrackingBody Close 호출 여부를 기록하는 요청 바디
+type closeTrackingBody struct {
+	io.Reader
+	closed atomic.Bool
+}
+
+// Close 호출 여부를 기록
+func (b *closeTrackingBody) Close() error {
+	b.closed.Store(true)
+	return nil
+}
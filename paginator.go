@@ -0,0 +1,117 @@
+package httpretry
+
+import (
+	"fmt"
+	"iter"
+	"net/http"
+	"strings"
+)
+
+type (
+	// NextPageFunc 현재 페이지의 응답으로 다음 페이지 요청을 생성하는 함수
+	//
+	// 다음 페이지가 없으면 nil 요청을 반환합니다.
+	NextPageFunc func(resp *http.Response) (*http.Request, error)
+
+	// Paginator 페이지마다 재시도 클라이언트로 요청하며 페이지를 순회
+	Paginator struct {
+		client Doer
+		first  *http.Request
+		next   NextPageFunc
+	}
+)
+
+// NewPaginator 페이지 순회를 위한 Paginator를 생성
+//
+// Parameters:
+//   - client: (Doer) 페이지 요청에 사용할 클라이언트. 보통 NewClient로 생성한 재시도 클라이언트
+//   - first: (*http.Request) 첫 페이지 요청
+//   - next: (NextPageFunc) 다음 페이지 요청 생성 함수. nil인 경우 LinkHeaderNext를 사용
+func NewPaginator(client Doer, first *http.Request, next NextPageFunc) *Paginator {
+	if next == nil {
+		next = LinkHeaderNext
+	}
+	return &Paginator{client: client, first: first, next: next}
+}
+
+// Pages 페이지 응답을 순서대로 반환하는 iterator
+//
+// 각 페이지 응답의 바디는 다음 페이지로 넘어가기 전에 닫히므로, 루프 안에서 읽어야 합니다.
+// 요청이 실패하거나 다음 페이지 요청 생성에 실패하면 에러를 반환하고 순회를 종료합니다.
+//
+//	for resp, err := range paginator.Pages() {
+//		if err != nil {
+//			return err
+//		}
+//		json.NewDecoder(resp.Body).Decode(&items)
+//	}
+func (p *Paginator) Pages() iter.Seq2[*http.Response, error] {
+	return func(yield func(*http.Response, error) bool) {
+		for req := p.first; req != nil; {
+			resp, err := p.client.Do(req)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if !yield(resp, nil) {
+				resp.Body.Close()
+				return
+			}
+
+			next, err := p.next(resp)
+			resp.Body.Close()
+			if err != nil {
+				yield(nil, fmt.Errorf("building next page request: %w", err))
+				return
+			}
+			req = next
+		}
+	}
+}
+
+// LinkHeaderNext Link 헤더의 rel="next" URL로 다음 페이지 요청을 생성
+//
+// 다음 페이지 요청은 이전 요청의 context와 헤더를 그대로 사용하는 GET 요청입니다.
+func LinkHeaderNext(resp *http.Response) (*http.Request, error) {
+	target, ok := nextLink(resp.Header.Values("Link"))
+	if !ok || resp.Request == nil {
+		return nil, nil
+	}
+
+	nextURL, err := resp.Request.URL.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("parsing next link %q: %w", target, err)
+	}
+	next, err := http.NewRequestWithContext(resp.Request.Context(), http.MethodGet, nextURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	next.Header = resp.Request.Header.Clone()
+	return next, nil
+}
+
+// nextLink Link 헤더 값에서 rel="next" URL을 찾음
+func nextLink(values []string) (string, bool) {
+	for _, value := range values {
+		for _, link := range strings.Split(value, ",") {
+			segments := strings.Split(link, ";")
+			target := strings.TrimSpace(segments[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range segments[1:] {
+				key, val, found := strings.Cut(strings.TrimSpace(param), "=")
+				if !found || !strings.EqualFold(key, "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(val, `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return strings.Trim(target, "<>"), true
+					}
+				}
+			}
+		}
+	}
+	return "", false
+}
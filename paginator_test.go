@@ -0,0 +1,129 @@
+package httpretry_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginator(t *testing.T) {
+	t.Run("Link 헤더를 따라 모든 페이지를 순회하며, 실패한 페이지는 재시도하는 테스트", func(t *testing.T) {
+		// given
+		failed := false
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				page := r.URL.Query().Get("page")
+				if page == "" {
+					page = "1"
+				}
+				if page == "2" && !failed {
+					failed = true
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				if page != "3" {
+					next := map[string]string{"1": "2", "2": "3"}[page]
+					w.Header().Set("Link", fmt.Sprintf(`</items?page=%s>; rel="next", </items?page=3>; rel="last"`, next))
+				}
+				w.Write([]byte("page-" + page))
+			}),
+		)
+		defer testServer.Close()
+
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(3),
+				httpretry.WithBackoffPolicy(noBackoff),
+			),
+		)
+		first, _ := http.NewRequest(http.MethodGet, testServer.URL+"/items", nil)
+
+		// when
+		var pages []string
+		for resp, err := range httpretry.NewPaginator(retryClient, first, nil).Pages() {
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(resp.Body)
+			pages = append(pages, string(body))
+		}
+
+		// then
+		assert.Equal(t, []string{"page-1", "page-2", "page-3"}, pages)
+		assert.True(t, failed, "2페이지는 재시도 되어야 합니다.")
+	})
+
+	t.Run("사용자 지정 다음 페이지 함수로 순회하는 테스트", func(t *testing.T) {
+		// given
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("cursor-" + r.URL.Query().Get("cursor")))
+			}),
+		)
+		defer testServer.Close()
+
+		retryClient := httpretry.NewClient(httpretry.NewHTTPSettings())
+		first, _ := http.NewRequest(http.MethodGet, testServer.URL+"?cursor=0", nil)
+		cursor := 0
+		next := func(resp *http.Response) (*http.Request, error) {
+			cursor++
+			if cursor > 2 {
+				return nil, nil
+			}
+			return http.NewRequest(http.MethodGet, fmt.Sprintf("%s?cursor=%d", testServer.URL, cursor), nil)
+		}
+
+		// when
+		var pages []string
+		for resp, err := range httpretry.NewPaginator(retryClient, first, next).Pages() {
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(resp.Body)
+			pages = append(pages, string(body))
+		}
+
+		// then
+		assert.Equal(t, []string{"cursor-0", "cursor-1", "cursor-2"}, pages)
+	})
+}
+
+// doerFunc 테스트에서 재시도 클라이언트를 대체하는 Doer
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestPaginator_Doer(t *testing.T) {
+	t.Run("Doer를 대체하여 서버 없이 순회하는 테스트", func(t *testing.T) {
+		// given
+		first, _ := http.NewRequest(http.MethodGet, "https://api.example.com/items", nil)
+		mock := doerFunc(func(req *http.Request) (*http.Response, error) {
+			header := http.Header{}
+			if req.URL.Query().Get("page") == "" {
+				header.Set("Link", `</items?page=2>; rel="next"`)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     header,
+				Body:       io.NopCloser(strings.NewReader(req.URL.String())),
+				Request:    req,
+			}, nil
+		})
+
+		// when
+		var bodies []string
+		for resp, err := range httpretry.NewPaginator(mock, first, nil).Pages() {
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(resp.Body)
+			bodies = append(bodies, string(body))
+		}
+
+		// then
+		assert.Equal(t, []string{
+			"https://api.example.com/items",
+			"https://api.example.com/items?page=2",
+		}, bodies)
+	})
+}
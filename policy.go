@@ -0,0 +1,103 @@
+package httpretry
+
+import (
+	"net/http"
+	"time"
+)
+
+type (
+	// Policy 목적지별로 다르게 적용할 재시도 정책
+	Policy struct {
+		// MaxRetry 최대 재시도 횟수
+		MaxRetry int
+		// BackoffPolicy 백오프 정책. nil인 경우 클라이언트의 BackoffPolicy를 사용
+		BackoffPolicy func(attempt int) time.Duration
+		// RetryStatusCodes 재시도할 상태 코드. nil인 경우 클라이언트의 재시도 상태 코드를 사용하며,
+		// 지정한 경우 기본 재시도 상태 코드를 대체
+		RetryStatusCodes []int
+		// Bulkhead 동시 요청 수를 제한할 bulkhead 이름. 여러 정책이 같은 이름을 사용하면 bulkhead를 공유
+		Bulkhead string
+	}
+
+	// RoutePolicy 요청 패턴에 적용할 재시도 정책
+	RoutePolicy struct {
+		// Pattern http.ServeMux 패턴 ("POST /v1/charges", "GET /v1/", "api.example.com/v1/{id}")
+		Pattern string
+		Policy  Policy
+	}
+
+	// routeHandler 패턴 매칭 결과로 재시도 정책을 전달하기 위한 핸들러
+	routeHandler retryPolicy
+
+	// retryPolicy 요청에 적용되는 재시도 정책
+	retryPolicy struct {
+		maxRetries       int
+		backoffPolicy    func(attempt int) time.Duration
+		retryStatusCodes map[int]string
+		bulkhead         string
+	}
+)
+
+// resolve 클라이언트 정책을 기준으로 Policy를 적용한 재시도 정책을 생성
+func (p Policy) resolve(base retryPolicy) retryPolicy {
+	resolved := retryPolicy{
+		maxRetries:       p.MaxRetry,
+		backoffPolicy:    base.backoffPolicy,
+		retryStatusCodes: base.retryStatusCodes,
+		bulkhead:         p.Bulkhead,
+	}
+	if p.BackoffPolicy != nil {
+		resolved.backoffPolicy = p.BackoffPolicy
+	}
+	if p.RetryStatusCodes != nil {
+		resolved.retryStatusCodes = make(map[int]string, len(p.RetryStatusCodes))
+		for _, code := range p.RetryStatusCodes {
+			resolved.retryStatusCodes[code] = http.StatusText(code)
+		}
+	}
+	return resolved
+}
+
+// ServeHTTP 매칭에만 사용하므로 아무것도 하지 않음
+func (routeHandler) ServeHTTP(http.ResponseWriter, *http.Request) {}
+
+// newRouteMux 패턴별 재시도 정책을 등록한 ServeMux를 생성. 등록된 패턴이 없으면 nil을 반환
+//
+// 패턴이 잘못되었거나 다른 패턴과 충돌하면 http.ServeMux와 동일하게 panic이 발생합니다.
+func newRouteMux(routes []RoutePolicy, base retryPolicy) *http.ServeMux {
+	if len(routes) == 0 {
+		return nil
+	}
+	mux := http.NewServeMux()
+	for _, route := range routes {
+		mux.Handle(route.Pattern, routeHandler(route.Policy.resolve(base)))
+	}
+	return mux
+}
+
+// policyFor 요청에 적용할 재시도 정책을 반환
+//
+// 요청 패턴 정책, 호스트 정책, 클라이언트 정책 순으로 적용합니다.
+// 호스트 정책은 포트를 포함한 호스트("api.example.com:8443")가 먼저 일치하는지 확인하고, 이후 호스트 이름으로 확인합니다.
+func (rt *retriableTransport) policyFor(req *http.Request) retryPolicy {
+	if rt.routes != nil {
+		// 클라이언트 요청은 Host가 비어 있을 수 있으므로, 매칭용 요청에 목적지 호스트를 지정
+		probe := &http.Request{Method: req.Method, Host: req.Host, URL: req.URL}
+		if probe.Host == "" {
+			probe.Host = req.URL.Host
+		}
+		// 경로 정리가 필요한 경우 리다이렉트 핸들러가 반환되므로, 타입으로 매칭 여부를 확인
+		if handler, _ := rt.routes.Handler(probe); handler != nil {
+			if policy, ok := handler.(routeHandler); ok {
+				return retryPolicy(policy)
+			}
+		}
+	}
+	if policy, ok := rt.hostPolicies[req.URL.Host]; ok {
+		return policy
+	}
+	if policy, ok := rt.hostPolicies[req.URL.Hostname()]; ok {
+		return policy
+	}
+	return rt.policy
+}
@@ -0,0 +1,152 @@
+package httpretry_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithHostPolicy(t *testing.T) {
+	t.Run("호스트별로 최대 재시도 횟수와 재시도 상태 코드를 다르게 적용하는 테스트", func(t *testing.T) {
+		// given
+		var stripeCount, defaultCount int
+		newServer := func(status int, count *int) *httptest.Server {
+			return httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					*count++
+					w.WriteHeader(status)
+				}),
+			)
+		}
+		stripeServer := newServer(http.StatusTooManyRequests, &stripeCount)
+		defer stripeServer.Close()
+		defaultServer := newServer(http.StatusTooManyRequests, &defaultCount)
+		defer defaultServer.Close()
+
+		stripeURL, _ := url.Parse(stripeServer.URL)
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(2),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithoutStatusCodes(http.StatusTooManyRequests),
+				httpretry.WithHostPolicy(stripeURL.Host, httpretry.Policy{
+					MaxRetry:         5,
+					RetryStatusCodes: []int{http.StatusTooManyRequests},
+				}),
+			),
+		)
+
+		// when
+		_, stripeErr := retryClient.Get(stripeServer.URL)
+		defaultResp, defaultErr := retryClient.Get(defaultServer.URL)
+
+		// then
+		assert.Error(t, stripeErr)
+		assert.Equal(t, 5, stripeCount, "호스트 정책의 최대 재시도 횟수만큼 요청해야 합니다.")
+		assert.NoError(t, defaultErr)
+		assert.Equal(t, http.StatusTooManyRequests, defaultResp.StatusCode)
+		assert.Equal(t, 1, defaultCount, "클라이언트 정책에서 제외한 429는 재시도 대상이 아닙니다.")
+	})
+}
+
+func TestWithRoutePolicy(t *testing.T) {
+	t.Run("메서드와 경로 패턴별로 재시도 정책을 다르게 적용하는 테스트", func(t *testing.T) {
+		// given
+		counts := make(map[string]int)
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				counts[r.Method+" "+r.URL.Path]++
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}),
+		)
+		defer testServer.Close()
+
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(2),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithRoutePolicy("POST /v1/charges", httpretry.Policy{MaxRetry: 1}),
+				httpretry.WithRoutePolicy("GET /v1/", httpretry.Policy{MaxRetry: 4}),
+			),
+		)
+
+		// when
+		_, chargeErr := retryClient.Post(testServer.URL+"/v1/charges", "application/json", nil)
+		_, listErr := retryClient.Get(testServer.URL + "/v1/charges")
+		_, otherErr := retryClient.Get(testServer.URL + "/v2/charges")
+
+		// then
+		assert.Error(t, chargeErr)
+		assert.Error(t, listErr)
+		assert.Error(t, otherErr)
+		assert.Equal(t, 1, counts["POST /v1/charges"])
+		assert.Equal(t, 4, counts["GET /v1/charges"])
+		assert.Equal(t, 2, counts["GET /v2/charges"], "일치하는 패턴이 없으면 클라이언트 정책을 사용해야 합니다.")
+	})
+}
+
+func TestWithRetryMethods(t *testing.T) {
+	t.Run("재시도 허용 메서드만 재시도하고, 그 외 메서드는 첫 응답을 반환하는 테스트", func(t *testing.T) {
+		// given
+		counts := make(map[string]int)
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				counts[r.Method]++
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}),
+		)
+		defer testServer.Close()
+
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(3),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithRetryMethods(http.MethodGet, http.MethodHead),
+			),
+		)
+
+		// when
+		_, getErr := retryClient.Get(testServer.URL)
+		postResp, postErr := retryClient.Post(testServer.URL, "application/json", nil)
+
+		// then
+		assert.Error(t, getErr)
+		assert.Equal(t, 3, counts[http.MethodGet])
+		assert.NoError(t, postErr)
+		assert.Equal(t, http.StatusServiceUnavailable, postResp.StatusCode)
+		assert.Equal(t, 1, counts[http.MethodPost])
+	})
+
+	t.Run("재시도 금지 메서드는 재시도 허용 메서드보다 우선하는 테스트", func(t *testing.T) {
+		// given
+		reqCount := 0
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reqCount++
+				w.WriteHeader(http.StatusBadGateway)
+			}),
+		)
+		defer testServer.Close()
+
+		retryClient := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(3),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithRetryMethods(http.MethodPost),
+				httpretry.WithoutRetryMethods(http.MethodPost),
+			),
+		)
+
+		// when
+		resp, err := retryClient.Post(testServer.URL, "application/json", nil)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+		assert.Equal(t, 1, reqCount)
+	})
+}
@@ -0,0 +1,22 @@
+package httpretry
+
+import (
+	"context"
+	"net/http"
+	"runtime/pprof"
+	"strconv"
+)
+
+// labelAttempt 시도를 처리하는 goroutine에 pprof 레이블(host, method, attempt)을 지정
+//
+// 레이블은 시도 요청의 context에도 추가되며, 전송 중 생성되는 goroutine(dial 등)에도 상속됩니다.
+// 반환된 함수는 goroutine 레이블을 요청 이전 상태로 되돌립니다.
+func labelAttempt(parent context.Context, attemptReq *http.Request, attempt int) (*http.Request, func()) {
+	ctx := pprof.WithLabels(attemptReq.Context(), pprof.Labels(
+		"httpretry.host", attemptReq.URL.Host,
+		"httpretry.method", attemptReq.Method,
+		"httpretry.attempt", strconv.Itoa(attempt),
+	))
+	pprof.SetGoroutineLabels(ctx)
+	return attemptReq.WithContext(ctx), func() { pprof.SetGoroutineLabels(parent) }
+}
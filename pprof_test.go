@@ -0,0 +1,46 @@
+package httpretry_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime/pprof"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithPprofLabels(t *testing.T) {
+	t.Run("시도마다 host, method, attempt 레이블을 지정하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		var attempts []string
+		var hosts []string
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithPprofLabels(true),
+			httpretry.WithTransportMiddleware(func(next http.RoundTripper) http.RoundTripper {
+				return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					attempt, _ := pprof.Label(req.Context(), "httpretry.attempt")
+					host, _ := pprof.Label(req.Context(), "httpretry.host")
+					attempts = append(attempts, attempt)
+					hosts = append(hosts, host)
+					return next.RoundTrip(req)
+				})
+			}),
+		))
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+
+		// when
+		client.Do(req)
+
+		// then
+		assert.Equal(t, []string{"1", "2"}, attempts)
+		assert.Equal(t, server.Listener.Addr().String(), hosts[0])
+	})
+}
@@ -0,0 +1,86 @@
+package httpretry
+
+import (
+	"context"
+	"time"
+)
+
+// Priority 요청의 우선순위
+type Priority int
+
+const (
+	// PriorityLow 경합 시 재시도를 양보하는 우선순위 (배치 작업 등)
+	PriorityLow Priority = iota - 1
+	// PriorityNormal 우선순위를 지정하지 않은 요청의 기본 우선순위
+	PriorityNormal
+	// PriorityHigh 경합과 관계없이 전체 재시도 예산을 유지하는 우선순위 (사용자 요청 등)
+	PriorityHigh
+)
+
+type (
+	// PriorityPolicy 경합 시 낮은 우선순위 요청의 재시도를 줄이는 정책
+	//
+	// 진행 중인 요청 수가 ContentionThreshold 이상이면 경합으로 보고, PriorityLow 요청에만 적용합니다.
+	// PriorityNormal, PriorityHigh 요청은 클라이언트 정책을 그대로 사용합니다.
+	PriorityPolicy struct {
+		// ContentionThreshold 경합으로 판단하는 클라이언트의 진행 중인 요청 수 (해당 요청 포함)
+		ContentionThreshold int
+		// MaxRetry 경합 시 최대 시도 횟수. 0 이하이거나 클라이언트 정책보다 크면 클라이언트 정책을 사용
+		MaxRetry int
+		// BackoffMultiplier 경합 시 백오프 대기 시간에 곱하는 배수. 1 이하이면 변경하지 않음
+		BackoffMultiplier float64
+		// AttemptTimeout 경합 시 시도 1회에 적용되는 타임아웃. 0인 경우 변경하지 않음
+		AttemptTimeout time.Duration
+	}
+
+	// priorityKey 요청 우선순위를 저장하는 context key
+	priorityKey struct{}
+)
+
+// Prioritize 요청의 우선순위를 지정한 context를 반환
+//
+// 배치 작업과 사용자 요청이 같은 클라이언트를 공유할 때, 배치 요청을 PriorityLow로 지정하면
+// 경합 시 PriorityPolicy에 따라 재시도를 양보합니다.
+//
+//	req, _ := http.NewRequestWithContext(httpretry.Prioritize(ctx, httpretry.PriorityLow), http.MethodGet, url, nil)
+//
+// Parameters:
+//   - ctx: (context.Context) 부모 context
+//   - priority: (Priority) 요청 우선순위
+func Prioritize(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// priorityFromContext context에 지정된 우선순위를 반환. 지정되지 않았으면 PriorityNormal
+func priorityFromContext(ctx context.Context) Priority {
+	priority, ok := ctx.Value(priorityKey{}).(Priority)
+	if !ok {
+		return PriorityNormal
+	}
+	return priority
+}
+
+// apply 경합 중인 낮은 우선순위 요청의 재시도 정책과 시도별 타임아웃을 조정
+//
+// Parameters:
+//   - priority: (Priority) 요청 우선순위
+//   - inFlight: (int) 클라이언트의 진행 중인 요청 수
+//   - policy: (*retryPolicy) 요청에 적용할 재시도 정책
+//   - attemptTimeout: (*time.Duration) 요청에 적용할 시도별 타임아웃
+func (p *PriorityPolicy) apply(priority Priority, inFlight int, policy *retryPolicy, attemptTimeout *time.Duration) {
+	if p == nil || priority >= PriorityNormal || inFlight < p.ContentionThreshold {
+		return
+	}
+	if p.MaxRetry > 0 && p.MaxRetry < policy.maxRetries {
+		policy.maxRetries = p.MaxRetry
+	}
+	if p.BackoffMultiplier > 1 && policy.backoffPolicy != nil {
+		backoff, multiplier := policy.backoffPolicy, p.BackoffMultiplier
+		policy.backoffPolicy = func(attempt int) time.Duration {
+			return time.Duration(float64(backoff(attempt)) * multiplier)
+		}
+	}
+	if p.AttemptTimeout > 0 {
+		*attemptTimeout = p.AttemptTimeout
+	}
+}
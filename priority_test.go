@@ -0,0 +1,90 @@
+package httpretry_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithPriorityPolicy(t *testing.T) {
+	unavailableServer := func(t *testing.T) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+	do := func(client *http.Client, priority httpretry.Priority, url string) *httpretry.RetryError {
+		req, _ := http.NewRequestWithContext(httpretry.Prioritize(context.Background(), priority), http.MethodGet, url, nil)
+		_, err := client.Do(req)
+		var retryErr *httpretry.RetryError
+		if !errors.As(err, &retryErr) {
+			return nil
+		}
+		return retryErr
+	}
+
+	t.Run("경합 시 낮은 우선순위 요청은 적게, 길게 대기하며 재시도하는 테스트", func(t *testing.T) {
+		// given
+		server := unavailableServer(t)
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(3),
+			httpretry.WithBackoffPolicy(func(int) time.Duration { return 10 * time.Millisecond }),
+			httpretry.WithPriorityPolicy(httpretry.PriorityPolicy{
+				ContentionThreshold: 1,
+				MaxRetry:            2,
+				BackoffMultiplier:   3,
+			}),
+		))
+
+		// when
+		retryErr := do(client, httpretry.PriorityLow, server.URL)
+
+		// then
+		assert.NotNil(t, retryErr)
+		assert.Equal(t, 2, retryErr.Attempts)
+		var attemptErr *httpretry.AttemptError
+		assert.True(t, errors.As(retryErr, &attemptErr))
+		assert.Equal(t, 30*time.Millisecond, attemptErr.Backoff)
+	})
+
+	t.Run("높은 우선순위 요청은 경합 시에도 전체 재시도 예산을 유지하는 테스트", func(t *testing.T) {
+		// given
+		server := unavailableServer(t)
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(3),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithPriorityPolicy(httpretry.PriorityPolicy{ContentionThreshold: 1, MaxRetry: 1}),
+		))
+
+		// when
+		high := do(client, httpretry.PriorityHigh, server.URL)
+		normal := do(client, httpretry.PriorityNormal, server.URL)
+
+		// then
+		assert.Equal(t, 3, high.Attempts)
+		assert.Equal(t, 3, normal.Attempts)
+	})
+
+	t.Run("경합이 아니면 낮은 우선순위 요청도 클라이언트 정책을 사용하는 테스트", func(t *testing.T) {
+		// given
+		server := unavailableServer(t)
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(3),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithPriorityPolicy(httpretry.PriorityPolicy{ContentionThreshold: 2, MaxRetry: 1}),
+		))
+
+		// when
+		retryErr := do(client, httpretry.PriorityLow, server.URL)
+
+		// then
+		assert.Equal(t, 3, retryErr.Attempts)
+	})
+}
@@ -0,0 +1,108 @@
+package httpretry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"time"
+)
+
+// ErrNoProxyAvailable 모든 프록시가 연속 실패로 제외되어 요청할 수 없음을 나타내는 에러
+var ErrNoProxyAvailable = errors.New("no proxy available")
+
+// proxyFailureStatus 프록시가 차단되었거나 장애로 판단하는 상태 코드
+var proxyFailureStatus = map[int]struct{}{
+	http.StatusForbidden:          {},
+	http.StatusProxyAuthRequired:  {},
+	http.StatusTooManyRequests:    {},
+	http.StatusBadGateway:         {},
+	http.StatusServiceUnavailable: {},
+	http.StatusGatewayTimeout:     {},
+}
+
+type (
+	// proxyKey 시도에 사용할 프록시를 전달하는 context key
+	proxyKey struct{}
+
+	// proxyEntry 프록시와 프록시별 실패 추적
+	proxyEntry struct {
+		url     *url.URL
+		breaker *CircuitBreaker
+	}
+
+	// proxyRotation 시도마다 다음 프록시를 선택하는 Transport
+	//
+	// 연속으로 실패한 프록시는 cooldown 동안 선택하지 않습니다.
+	proxyRotation struct {
+		next    http.RoundTripper
+		proxies []proxyEntry
+		err     error
+		cursor  atomic.Uint64
+	}
+)
+
+// newProxyRotation 프록시 순환 Transport를 생성
+func newProxyRotation(next http.RoundTripper, proxies []string, threshold int, cooldown time.Duration) *proxyRotation {
+	rotation := &proxyRotation{next: next}
+	for _, proxy := range proxies {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil {
+			// 잘못된 프록시 주소는 요청 시 에러로 반환
+			rotation.err = fmt.Errorf("parsing proxy %q: %w", proxy, err)
+			return rotation
+		}
+		rotation.proxies = append(rotation.proxies, proxyEntry{
+			url:     proxyURL,
+			breaker: NewCircuitBreaker(threshold, cooldown),
+		})
+	}
+	return rotation
+}
+
+// RoundTrip 다음 프록시로 요청하고, 결과를 프록시별로 기록
+func (p *proxyRotation) RoundTrip(req *http.Request) (*http.Response, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	proxy, ok := p.pick()
+	if !ok {
+		return nil, ErrNoProxyAvailable
+	}
+
+	ctx := context.WithValue(req.Context(), proxyKey{}, proxy.url)
+	resp, err := p.next.RoundTrip(req.WithContext(ctx))
+	switch {
+	case err != nil && ctx.Err() != nil:
+		// 취소된 요청은 프록시 장애로 판단하지 않음
+		proxy.breaker.release()
+	case err != nil:
+		proxy.breaker.Record(false)
+	default:
+		_, failed := proxyFailureStatus[resp.StatusCode]
+		proxy.breaker.Record(!failed)
+	}
+	return resp, err
+}
+
+// pick 순서대로 다음 프록시를 선택하며, 제외된 프록시는 건너뜀
+func (p *proxyRotation) pick() (proxyEntry, bool) {
+	start := p.cursor.Add(1) - 1
+	for i := range uint64(len(p.proxies)) {
+		proxy := p.proxies[(start+i)%uint64(len(p.proxies))]
+		if proxy.breaker.Allow() == nil {
+			return proxy, true
+		}
+	}
+	return proxyEntry{}, false
+}
+
+// proxyFromContext 시도에 선택된 프록시를 반환. 선택된 프록시가 없으면 환경 변수 설정을 사용
+func proxyFromContext(req *http.Request) (*url.URL, error) {
+	if proxy, ok := req.Context().Value(proxyKey{}).(*url.URL); ok {
+		return proxy, nil
+	}
+	return http.ProxyFromEnvironment(req)
+}
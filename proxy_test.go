@@ -0,0 +1,79 @@
+package httpretry_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithProxyRotation(t *testing.T) {
+	t.Run("시도마다 다음 프록시를 사용하고, 실패한 프록시는 제외하는 테스트", func(t *testing.T) {
+		// given
+		newProxy := func(status int, count *int) *httptest.Server {
+			return httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					// 프록시로 전달된 요청은 절대 경로를 가짐
+					if r.URL.Host == "origin.httpretry.test" {
+						*count++
+					}
+					w.WriteHeader(status)
+				}),
+			)
+		}
+		var blockedCount, healthyCount int
+		blocked := newProxy(http.StatusForbidden, &blockedCount)
+		defer blocked.Close()
+		healthy := newProxy(http.StatusOK, &healthyCount)
+		defer healthy.Close()
+
+		client := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithProxyRotation(blocked.URL, healthy.URL),
+				httpretry.WithProxyFailureTracking(1, time.Minute),
+			),
+			http.StatusForbidden,
+		)
+
+		// when
+		first, firstErr := client.Get("http://origin.httpretry.test/")
+		second, secondErr := client.Get("http://origin.httpretry.test/")
+
+		// then
+		assert.NoError(t, firstErr)
+		assert.Equal(t, http.StatusOK, first.StatusCode)
+		assert.NoError(t, secondErr)
+		assert.Equal(t, http.StatusOK, second.StatusCode)
+		assert.Equal(t, 1, blockedCount, "실패한 프록시는 cooldown 동안 사용하지 않아야 합니다.")
+		assert.Equal(t, 2, healthyCount)
+	})
+
+	t.Run("모든 프록시가 제외된 경우, 에러 반환 테스트", func(t *testing.T) {
+		// given
+		blocked := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusProxyAuthRequired)
+			}),
+		)
+		defer blocked.Close()
+
+		client := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithProxyRotation(blocked.URL),
+				httpretry.WithProxyFailureTracking(1, time.Minute),
+			),
+			http.StatusProxyAuthRequired,
+		)
+
+		// when
+		_, err := client.Get("http://origin.httpretry.test/")
+
+		// then
+		assert.ErrorIs(t, err, httpretry.ErrNoProxyAvailable)
+	})
+}
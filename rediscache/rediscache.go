@@ -0,0 +1,65 @@
+// Package rediscache httpretry 응답 캐시를 Redis에 보관하는 CacheStore 구현
+//
+// 여러 replica가 같은 Redis를 사용하면 캐시된 응답을 공유하며, 프로세스를 재시작해도 캐시가 유지됩니다.
+//
+//	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+//	httpClient := httpretry.NewClient(httpretry.NewHTTPSettings(httpretry.WithCache(rediscache.New(client, ""))))
+package rediscache
+
+import (
+	"context"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultPrefix 키 prefix를 지정하지 않은 경우 사용하는 기본값
+const DefaultPrefix = "httpretry:cache"
+
+// Store Redis 캐시 저장소
+type Store struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+var _ httpretry.CacheStore = (*Store)(nil)
+
+// New Redis 캐시 저장소를 생성
+//
+// Parameters:
+//   - client: (redis.UniversalClient) Redis 클라이언트 (단일 노드, 클러스터, 센티널)
+//   - prefix: (string) 키 prefix. 비어 있으면 DefaultPrefix를 사용
+func New(client redis.UniversalClient, prefix string) *Store {
+	if prefix == "" {
+		prefix = DefaultPrefix
+	}
+	return &Store{client: client, prefix: prefix}
+}
+
+// Get 키에 해당하는 값을 반환. 만료된 값은 Redis가 삭제하므로 조회되지 않음
+func (s *Store) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := s.client.Get(ctx, s.key(key)).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Set 값을 ttl 동안 보관
+func (s *Store) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.client.Set(ctx, s.key(key), value, ttl).Err()
+}
+
+// Delete 키에 해당하는 값을 삭제
+func (s *Store) Delete(ctx context.Context, key string) error {
+	return s.client.Del(ctx, s.key(key)).Err()
+}
+
+// key prefix를 붙인 Redis 키
+func (s *Store) key(key string) string {
+	return s.prefix + ":" + key
+}
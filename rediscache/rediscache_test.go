@@ -0,0 +1,57 @@
+package rediscache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/dings-things/httpretry/rediscache"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("저장한 값을 조회하고, ttl이 지나면 조회되지 않는 테스트", func(t *testing.T) {
+		// given
+		server := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		t.Cleanup(func() { client.Close() })
+		store := rediscache.New(client, "")
+		assert.NoError(t, store.Set(ctx, "GET http://example.com", []byte("cached"), time.Minute))
+
+		// when
+		value, ok, err := store.Get(ctx, "GET http://example.com")
+		stored := server.Exists(rediscache.DefaultPrefix + ":GET http://example.com")
+		server.FastForward(2 * time.Minute)
+		_, expiredOK, expiredErr := store.Get(ctx, "GET http://example.com")
+
+		// then
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []byte("cached"), value)
+		assert.True(t, stored)
+		assert.NoError(t, expiredErr)
+		assert.False(t, expiredOK)
+	})
+
+	t.Run("삭제한 값은 조회되지 않는 테스트", func(t *testing.T) {
+		// given
+		server := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		t.Cleanup(func() { client.Close() })
+		store := rediscache.New(client, "svc")
+		assert.NoError(t, store.Set(ctx, "key", []byte("cached"), time.Minute))
+
+		// when
+		err := store.Delete(ctx, "key")
+		_, ok, getErr := store.Get(ctx, "key")
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,61 @@
+package httpretry
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// ErrClientRegistered 같은 이름으로 이미 등록된 클라이언트가 있음을 나타내는 에러
+var ErrClientRegistered = errors.New("client already registered")
+
+// clientRegistry 서비스 이름별로 등록한 클라이언트
+var clientRegistry = struct {
+	mu      sync.RWMutex
+	clients map[string]*http.Client
+}{clients: make(map[string]*http.Client)}
+
+// Register 서비스 이름으로 클라이언트를 등록
+//
+// 시작 시점에 서비스별로 설정한 클라이언트를 등록해 두면, 생성자마다 클라이언트를 전달하지 않고 Lookup으로 꺼내 사용할 수 있습니다.
+// 여러 goroutine에서 동시에 호출할 수 있으며, 같은 이름으로 다시 등록하면 덮어쓰지 않고 ErrClientRegistered를 반환합니다.
+//
+//	httpretry.Register("payments", httpretry.NewClient(paymentsSettings))
+//
+// Parameters:
+//   - name: (string) 서비스 이름
+//   - client: (*http.Client) 등록할 클라이언트. New로 만든 클라이언트는 Client 필드를 전달
+func Register(name string, client *http.Client) error {
+	if client == nil {
+		return fmt.Errorf("httpretry: register %q: nil client", name)
+	}
+	clientRegistry.mu.Lock()
+	defer clientRegistry.mu.Unlock()
+	if _, exists := clientRegistry.clients[name]; exists {
+		return fmt.Errorf("httpretry: register %q: %w", name, ErrClientRegistered)
+	}
+	clientRegistry.clients[name] = client
+	return nil
+}
+
+// Lookup 서비스 이름으로 등록한 클라이언트를 반환. 등록하지 않은 이름이면 false를 반환
+//
+// Parameters:
+//   - name: (string) 서비스 이름
+func Lookup(name string) (*http.Client, bool) {
+	clientRegistry.mu.RLock()
+	defer clientRegistry.mu.RUnlock()
+	client, ok := clientRegistry.clients[name]
+	return client, ok
+}
+
+// Unregister 서비스 이름으로 등록한 클라이언트를 제거. 테스트에서 클라이언트를 교체할 때 사용
+//
+// Parameters:
+//   - name: (string) 서비스 이름
+func Unregister(name string) {
+	clientRegistry.mu.Lock()
+	defer clientRegistry.mu.Unlock()
+	delete(clientRegistry.clients, name)
+}
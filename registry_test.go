@@ -0,0 +1,84 @@
+package httpretry_test
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	t.Run("등록한 클라이언트를 서비스 이름으로 꺼내는 테스트", func(t *testing.T) {
+		// given
+		client := httpretry.NewClient(nil)
+		defer httpretry.Unregister("payments")
+
+		// when
+		err := httpretry.Register("payments", client)
+		found, ok := httpretry.Lookup("payments")
+		_, missing := httpretry.Lookup("inventory")
+
+		// then
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Same(t, client, found)
+		assert.False(t, missing)
+	})
+
+	t.Run("같은 이름으로 다시 등록하면 덮어쓰지 않는 테스트", func(t *testing.T) {
+		// given
+		first := httpretry.NewClient(nil)
+		defer httpretry.Unregister("orders")
+		assert.NoError(t, httpretry.Register("orders", first))
+
+		// when
+		err := httpretry.Register("orders", httpretry.NewClient(nil))
+
+		// then
+		assert.ErrorIs(t, err, httpretry.ErrClientRegistered)
+		assert.ErrorContains(t, err, `"orders"`)
+		found, _ := httpretry.Lookup("orders")
+		assert.Same(t, first, found)
+	})
+
+	t.Run("nil 클라이언트는 등록하지 않는 테스트", func(t *testing.T) {
+		// when
+		err := httpretry.Register("nil-client", nil)
+
+		// then
+		assert.Error(t, err)
+		_, ok := httpretry.Lookup("nil-client")
+		assert.False(t, ok)
+	})
+
+	t.Run("여러 goroutine에서 동시에 등록하고 조회하는 테스트", func(t *testing.T) {
+		// given
+		var wg sync.WaitGroup
+		clients := make([]*http.Client, 16)
+		for i := range clients {
+			clients[i] = httpretry.NewClient(nil)
+			defer httpretry.Unregister(fmt.Sprintf("service-%d", i))
+		}
+
+		// when
+		for i, client := range clients {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, httpretry.Register(fmt.Sprintf("service-%d", i), client))
+				httpretry.Lookup(fmt.Sprintf("service-%d", (i+1)%len(clients)))
+			}()
+		}
+		wg.Wait()
+
+		// then
+		for i, client := range clients {
+			found, ok := httpretry.Lookup(fmt.Sprintf("service-%d", i))
+			assert.True(t, ok)
+			assert.Same(t, client, found)
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package httpretry
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryAfterPolicy Retry-After 값이 최대 대기 시간을 넘는 경우의 처리 방식
+type RetryAfterPolicy string
+
+const (
+	// RetryAfterClamp 최대 대기 시간만큼만 대기한 뒤 재시도 (기본값)
+	RetryAfterClamp RetryAfterPolicy = "clamp"
+	// RetryAfterFailFast 재시도하지 않고 즉시 실패
+	RetryAfterFailFast RetryAfterPolicy = "fail"
+)
+
+// ErrRetryAfterTooLong Retry-After 값이 최대 대기 시간을 넘어 재시도를 포기한 경우
+var ErrRetryAfterTooLong = errors.New("retry-after exceeds maximum")
+
+// parseRetryAfter 응답의 Retry-After 헤더를 대기 시간으로 변환. 헤더가 없거나 형식이 잘못된 경우 false를 반환
+//
+// 초 단위 정수와 HTTP-date 형식을 지원하며, 이미 지난 시각은 0으로 처리합니다.
+// time.Duration으로 표현할 수 없을 만큼 큰 값은 최대 대기 시간을 넘는 값으로 처리합니다.
+func parseRetryAfter(response *http.Response) (time.Duration, bool) {
+	if response == nil {
+		return 0, false
+	}
+	value := response.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil || errors.Is(err, strconv.ErrRange) {
+		// time.Duration으로 표현할 수 없는 값은 곱하면 overflow되므로 가장 긴 대기 시간으로 처리
+		if seconds > math.MaxInt64/int64(time.Second) {
+			return time.Duration(math.MaxInt64), true
+		}
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// retryDelay 재시도 전 대기 시간을 계산
+//
+// 응답에 Retry-After가 있으면 백오프 정책 대신 사용하며, 최대 대기 시간을 넘는 값은 정책에 따라
+// 최대 대기 시간으로 줄이거나 ErrRetryAfterTooLong을 반환합니다. 사용한 Retry-After 값도 함께 반환합니다.
+// Retry-After가 없는 429 응답은 429 전용 jitter 구간이 설정된 경우 그 구간의 임의 시간만큼 대기합니다.
+func (rt *retriableTransport) retryDelay(policy retryPolicy, attempt int, response *http.Response) (time.Duration, time.Duration, error) {
+	retryAfter, ok := parseRetryAfter(response)
+	if !ok {
+		if response != nil && response.StatusCode == http.StatusTooManyRequests && rt.rateLimitJitterMax > 0 {
+			return rateLimitJitter(rt.rateLimitJitterMin, rt.rateLimitJitterMax), 0, nil
+		}
+		return policy.backoffPolicy(attempt), 0, nil
+	}
+	if rt.maxRetryAfter > 0 && retryAfter > rt.maxRetryAfter {
+		if rt.retryAfterPolicy == RetryAfterFailFast {
+			return 0, retryAfter, fmt.Errorf("retry after %s, maximum %s: %w", retryAfter, rt.maxRetryAfter, ErrRetryAfterTooLong)
+		}
+		retryAfter = rt.maxRetryAfter
+	}
+	return retryAfter, retryAfter, nil
+}
+
+// rateLimitJitter minDelay 이상 maxDelay 이하의 임의 대기 시간
+//
+// rate limit은 회복 시점에 요청이 몰리지 않도록 넓게 분산하는 것이 중요하므로, 시도 횟수와 관계없이 같은 구간을 사용합니다.
+func rateLimitJitter(minDelay, maxDelay time.Duration) time.Duration {
+	if maxDelay <= minDelay {
+		return maxDelay
+	}
+	return minDelay + rand.N(maxDelay-minDelay+1)
+}
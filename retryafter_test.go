@@ -0,0 +1,159 @@
+package httpretry_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryAfter(t *testing.T) {
+	newServer := func(t *testing.T, retryAfter string) (*httptest.Server, *int32) {
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&requests, 1) == 1 {
+				w.Header().Set("Retry-After", retryAfter)
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(server.Close)
+		return server, &requests
+	}
+
+	t.Run("Retry-After 헤더만큼 대기한 뒤 재시도하는 테스트", func(t *testing.T) {
+		// given
+		server, requests := newServer(t, "1")
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(httpretry.WithBackoffPolicy(noBackoff)))
+
+		// when
+		start := time.Now()
+		resp, err := client.Get(server.URL)
+		elapsed := time.Since(start)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+		assert.GreaterOrEqual(t, elapsed, time.Second)
+	})
+
+	t.Run("최대 대기 시간을 넘는 Retry-After는 최대 시간만큼만 대기하는 테스트", func(t *testing.T) {
+		// given
+		server, _ := newServer(t, "3600")
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithMaxRetryAfter(50*time.Millisecond),
+		))
+
+		// when
+		start := time.Now()
+		resp, err := client.Get(server.URL)
+		elapsed := time.Since(start)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Less(t, elapsed, time.Second)
+	})
+
+	t.Run("fail fast 정책은 최대 대기 시간을 넘으면 재시도하지 않고 Retry-After 값을 에러로 알리는 테스트", func(t *testing.T) {
+		// given
+		server, requests := newServer(t, "3600")
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetryAfter(time.Minute),
+			httpretry.WithRetryAfterPolicy(httpretry.RetryAfterFailFast),
+		))
+
+		// when
+		_, err := client.Get(server.URL)
+
+		// then
+		assert.True(t, errors.Is(err, httpretry.ErrRetryAfterTooLong))
+		var attemptErr *httpretry.AttemptError
+		assert.True(t, errors.As(err, &attemptErr))
+		assert.Equal(t, time.Hour, attemptErr.RetryAfter)
+		assert.Equal(t, http.StatusServiceUnavailable, attemptErr.StatusCode)
+		assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+	})
+	t.Run("표현할 수 없을 만큼 큰 Retry-After는 최대 대기 시간을 넘는 값으로 처리하는 테스트", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			retryAfter string
+		}{
+			{"time.Duration overflow", "10000000000"},
+			{"int64 범위 초과", "99999999999999999999"},
+		}
+
+		for _, tt := range tests {
+			// given
+			server, requests := newServer(t, tt.retryAfter)
+			client := httpretry.NewClient(httpretry.NewHTTPSettings(
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithMaxRetryAfter(time.Minute),
+				httpretry.WithRetryAfterPolicy(httpretry.RetryAfterFailFast),
+			))
+
+			// when
+			_, err := client.Get(server.URL)
+
+			// then
+			assert.ErrorIs(t, err, httpretry.ErrRetryAfterTooLong, tt.name)
+			assert.Equal(t, int32(1), atomic.LoadInt32(requests), tt.name)
+		}
+	})
+}
+
+func TestWithRateLimitJitter(t *testing.T) {
+	t.Run("Retry-After가 없는 429 응답은 jitter 구간만큼 대기하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(func(int) time.Duration { return time.Minute }),
+			httpretry.WithRateLimitJitter(10*time.Millisecond, 30*time.Millisecond),
+		), http.StatusTooManyRequests)
+
+		// when
+		start := time.Now()
+		_, err := client.Get(server.URL)
+
+		// then
+		assert.Less(t, time.Since(start), time.Second)
+		var attemptErr *httpretry.AttemptError
+		assert.True(t, errors.As(err, &attemptErr))
+		assert.GreaterOrEqual(t, attemptErr.Backoff, 10*time.Millisecond)
+		assert.LessOrEqual(t, attemptErr.Backoff, 30*time.Millisecond)
+	})
+
+	t.Run("429 이외의 응답은 백오프 정책을 사용하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(func(int) time.Duration { return 50 * time.Millisecond }),
+			httpretry.WithRateLimitJitter(time.Millisecond, 2*time.Millisecond),
+		))
+
+		// when
+		_, err := client.Get(server.URL)
+
+		// then
+		var attemptErr *httpretry.AttemptError
+		assert.True(t, errors.As(err, &attemptErr))
+		assert.Equal(t, 50*time.Millisecond, attemptErr.Backoff)
+	})
+
+}
@@ -0,0 +1,105 @@
+package httpretry
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrRetryLimited 프로세스 전체의 초당 재시도 제한에 도달하여 재시도하지 않음
+var ErrRetryLimited = errors.New("global retry limit reached")
+
+type (
+	// retryLimiter 초당 재시도 횟수를 제한하는 token bucket
+	retryLimiter struct {
+		mu        sync.Mutex
+		perSecond float64
+		burst     float64
+		tokens    float64
+		last      time.Time
+	}
+)
+
+// globalRetryLimiter 모든 클라이언트가 공유하는 재시도 제한. nil인 경우 제한하지 않음
+var globalRetryLimiter atomic.Pointer[retryLimiter]
+
+// SetGlobalRetryLimit 프로세스의 모든 클라이언트가 공유하는 초당 재시도 횟수 제한을 설정
+//
+// 광범위한 장애에서 바이너리의 모든 클라이언트가 동시에 재시도하여 트래픽이 몇 배로 늘어나는 것을 막습니다.
+// 제한에 도달하면 재시도하지 않고 ErrRetryLimited(ReasonRetryLimited)로 실패하며, 첫 시도는 제한하지 않습니다.
+// perSecond가 0 이하이면 제한을 해제합니다.
+//
+// Parameters:
+//   - perSecond: (float64) 초당 허용하는 재시도 횟수
+//   - burst: (int) 한 번에 허용하는 최대 재시도 횟수. 0 이하이면 perSecond를 사용
+func SetGlobalRetryLimit(perSecond float64, burst int) {
+	globalRetryLimiter.Store(newRetryLimiter(perSecond, burst))
+}
+
+// newRetryLimiter 초당 perSecond개의 토큰을 채우는 token bucket을 생성. perSecond가 0 이하이면 nil을 반환
+func newRetryLimiter(perSecond float64, burst int) *retryLimiter {
+	if perSecond <= 0 {
+		return nil
+	}
+	b := float64(burst)
+	if b <= 0 {
+		b = max(perSecond, 1)
+	}
+	return &retryLimiter{perSecond: perSecond, burst: b, tokens: b, last: time.Now()}
+}
+
+// allowGlobalRetry 프로세스 전체 재시도 제한에서 재시도 한 번을 허용하는지 확인
+func allowGlobalRetry() bool {
+	limiter := globalRetryLimiter.Load()
+	if limiter == nil {
+		return true
+	}
+	return limiter.allow()
+}
+
+// allow 토큰이 있으면 하나를 사용
+func (l *retryLimiter) allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill()
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// wait 토큰을 하나 예약하고, 토큰이 채워질 때까지 대기
+//
+// ctx가 먼저 끝나면 예약한 토큰을 돌려주고 ctx의 에러를 반환합니다.
+func (l *retryLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	l.refill()
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.perSecond * float64(time.Second))
+	l.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return context.Cause(ctx)
+	}
+}
+
+// refill 지난 시간만큼 토큰을 채움. mu를 잠근 상태에서 호출
+func (l *retryLimiter) refill() {
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.perSecond)
+	l.last = now
+}
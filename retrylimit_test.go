@@ -0,0 +1,70 @@
+package httpretry_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetGlobalRetryLimit(t *testing.T) {
+	t.Run("모든 클라이언트의 재시도를 함께 제한하는 테스트", func(t *testing.T) {
+		// given
+		var received atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		httpretry.SetGlobalRetryLimit(0.001, 2)
+		defer httpretry.SetGlobalRetryLimit(0, 0)
+		newClient := func() *http.Client {
+			return httpretry.NewClient(httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(3),
+				httpretry.WithBackoffPolicy(noBackoff),
+			))
+		}
+		first, second := newClient(), newClient()
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+		// when
+		_, firstErr := first.Do(req)
+		_, secondErr := second.Do(req)
+
+		// then
+		assert.Equal(t, int32(4), received.Load(), "첫 요청은 3번, 두 번째 요청은 재시도 없이 1번 전송")
+		var retryErr *httpretry.RetryError
+		assert.True(t, errors.As(firstErr, &retryErr))
+		assert.Equal(t, httpretry.ReasonBudgetExhausted, retryErr.Reason)
+		assert.True(t, errors.As(secondErr, &retryErr))
+		assert.Equal(t, httpretry.ReasonRetryLimited, retryErr.Reason)
+		assert.ErrorIs(t, secondErr, httpretry.ErrRetryLimited)
+	})
+
+	t.Run("제한을 해제하면 재시도하는 테스트", func(t *testing.T) {
+		// given
+		var received atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		httpretry.SetGlobalRetryLimit(0.001, 1)
+		httpretry.SetGlobalRetryLimit(0, 0)
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(3),
+			httpretry.WithBackoffPolicy(noBackoff),
+		))
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+		// when
+		client.Do(req)
+
+		// then
+		assert.Equal(t, int32(3), received.Load())
+	})
+}
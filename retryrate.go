@@ -0,0 +1,104 @@
+package httpretry
+
+import (
+	"sync"
+	"time"
+)
+
+// retryRateBuckets 슬라이딩 윈도우를 나누는 bucket 수
+const retryRateBuckets = 10
+
+type (
+	// RetryRateAlert 최근 재시도 비율이 임계값을 넘으면 호출되는 알림 설정
+	RetryRateAlert struct {
+		// Threshold 재시도 비율 임계값 (0~1). 재시도 수 / 시도 수가 Threshold를 넘으면 알림
+		Threshold float64
+		// Window 재시도 비율을 계산하는 기간. 0인 경우 1분
+		Window time.Duration
+		// MinAttempts 비율을 판단하기 위한 최소 시도 수. 시도가 적을 때 오탐을 막음
+		MinAttempts int
+		// OnChange 임계값을 넘거나 다시 내려오면 호출. 요청을 처리하는 goroutine에서 호출되므로 빠르게 반환해야 함
+		OnChange func(RetryRate)
+	}
+
+	// RetryRate 윈도우 기간의 재시도 비율
+	RetryRate struct {
+		Attempts int
+		Retries  int
+		// Ratio 재시도 수 / 시도 수
+		Ratio  float64
+		Window time.Duration
+		// Exceeded 임계값을 넘었는지 여부. false인 경우 임계값 아래로 회복됨
+		Exceeded bool
+	}
+
+	// retryRateBucket 윈도우의 한 구간에서 집계한 시도와 재시도 수
+	retryRateBucket struct {
+		index    int64
+		attempts int
+		retries  int
+	}
+
+	// retryRate 시간 bucket으로 나눈 슬라이딩 윈도우로 재시도 비율을 계산
+	retryRate struct {
+		alert    RetryRateAlert
+		width    time.Duration
+		mu       sync.Mutex
+		buckets  [retryRateBuckets]retryRateBucket
+		exceeded bool
+	}
+)
+
+// newRetryRate 알림이 설정되지 않으면 nil을 반환
+func newRetryRate(alert *RetryRateAlert) *retryRate {
+	if alert == nil || alert.OnChange == nil {
+		return nil
+	}
+	r := &retryRate{alert: *alert}
+	if r.alert.Window <= 0 {
+		r.alert.Window = time.Minute
+	}
+	r.width = max(r.alert.Window/retryRateBuckets, time.Nanosecond)
+	return r
+}
+
+// observe 시도 종료와 재시도 예정 이벤트를 집계하고, 임계값 상태가 바뀌면 알림
+func (r *retryRate) observe(event RetryEvent) {
+	var attempts, retries int
+	switch event.Type {
+	case EventAttemptFinish:
+		attempts = 1
+	case EventRetryScheduled:
+		retries = 1
+	default:
+		return
+	}
+
+	r.mu.Lock()
+	index := event.Time.UnixNano() / int64(r.width)
+	bucket := &r.buckets[index%retryRateBuckets]
+	if bucket.index != index {
+		*bucket = retryRateBucket{index: index}
+	}
+	bucket.attempts += attempts
+	bucket.retries += retries
+
+	rate := RetryRate{Window: r.alert.Window}
+	for _, b := range r.buckets {
+		if b.index > index-retryRateBuckets {
+			rate.Attempts += b.attempts
+			rate.Retries += b.retries
+		}
+	}
+	if rate.Attempts > 0 {
+		rate.Ratio = float64(rate.Retries) / float64(rate.Attempts)
+	}
+	rate.Exceeded = rate.Attempts >= r.alert.MinAttempts && rate.Ratio > r.alert.Threshold
+	changed := rate.Exceeded != r.exceeded
+	r.exceeded = rate.Exceeded
+	r.mu.Unlock()
+
+	if changed {
+		r.alert.OnChange(rate)
+	}
+}
@@ -0,0 +1,63 @@
+package httpretry_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRetryRateAlert(t *testing.T) {
+	t.Run("재시도 비율이 임계값을 넘을 때와 회복될 때 한 번씩 알리는 테스트", func(t *testing.T) {
+		// given
+		var failing atomic.Bool
+		failing.Store(true)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if failing.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		var rates []httpretry.RetryRate
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithRetryRateAlert(httpretry.RetryRateAlert{
+				Threshold:   0.2,
+				Window:      time.Minute,
+				MinAttempts: 4,
+				OnChange:    func(rate httpretry.RetryRate) { rates = append(rates, rate) },
+			}),
+		))
+		get := func() {
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			if resp, err := client.Do(req); err == nil {
+				resp.Body.Close()
+			}
+		}
+
+		// when
+		get()
+		assert.Empty(t, rates, "MinAttempts보다 시도가 적으면 알리지 않음")
+		get()
+		failing.Store(false)
+		for range 20 {
+			get()
+		}
+
+		// then
+		assert.Len(t, rates, 2)
+		assert.True(t, rates[0].Exceeded)
+		assert.Equal(t, 4, rates[0].Attempts)
+		assert.Greater(t, rates[0].Ratio, 0.2)
+		assert.False(t, rates[1].Exceeded)
+		assert.LessOrEqual(t, rates[1].Ratio, 0.2)
+		assert.Equal(t, time.Minute, rates[1].Window)
+	})
+}
@@ -1,10 +1,13 @@
 package httpretry
 
 import (
-	"log"
+	"crypto/tls"
+	"maps"
+	"net"
+	"net/http"
+	"reflect"
+	"slices"
 	"time"
-
-	"github.com/Netflix/go-env"
 )
 
 // Settings of http retry
@@ -18,17 +21,243 @@ type (
 		TLSHandshakeTimeout   time.Duration `env:"TLS_TIMEOUT,default=10s"`
 		ExpectContinueTimeout time.Duration `env:"CONTINUE_TIMEOUT,defualt=1s"`
 		ResponseHeaderTimeout time.Duration `env:"HEADER_TIMEOUT,default=10s"`
-		RequestTimeout        time.Duration `env:"REQUEST_TIMEOUT,default=10s"`
-		BackoffPolicy         func(attempt int) time.Duration
+		DisableKeepAlives     bool          `env:"DISABLE_KEEP_ALIVES,default=false"`
+		DisableCompression    bool          `env:"DISABLE_COMPRESSION,default=false"`
+		// RetryConnectionPolicy 재시도의 유휴 커넥션 재사용 정책 (reuse, fresh, fresh-after-network-error)
+		RetryConnectionPolicy RetryConnectionPolicy `env:"RETRY_CONNECTION_POLICY,default=fresh-after-network-error"`
+		// ReadBufferSize, WriteBufferSize 커넥션의 읽기/쓰기 버퍼 크기 (bytes). 0인 경우 4KB
+		ReadBufferSize  int `env:"READ_BUFFER_SIZE,default=0"`
+		WriteBufferSize int `env:"WRITE_BUFFER_SIZE,default=0"`
+		// MaxResponseHeaderBytes 응답 헤더의 최대 크기 (bytes). 0인 경우 1MB
+		MaxResponseHeaderBytes int64 `env:"MAX_RESPONSE_HEADER_BYTES,default=0"`
+		// TLSConfig 커넥션에 사용할 TLS 설정. 지정한 경우 Insecure는 적용되지 않음
+		TLSConfig *tls.Config
+		// Deprecated: AttemptTimeout을 사용하세요. AttemptTimeout이 설정되지 않은 경우에만 사용됩니다.
+		RequestTimeout time.Duration `env:"REQUEST_TIMEOUT,default=10s"`
+		AttemptTimeout time.Duration `env:"ATTEMPT_TIMEOUT"`
+		TotalTimeout   time.Duration `env:"TOTAL_TIMEOUT"`
+		StreamingMode  bool          `env:"STREAMING_MODE,default=false"`
+		RetryTLSErrors bool          `env:"RETRY_TLS_ERRORS,default=true"`
+		// CircuitBreakerThreshold 0인 경우 서킷 브레이커를 사용하지 않음
+		CircuitBreakerThreshold int           `env:"CIRCUIT_BREAKER_THRESHOLD,default=0"`
+		CircuitBreakerCooldown  time.Duration `env:"CIRCUIT_BREAKER_COOLDOWN,default=30s"`
+		// CircuitProbePolicy half-open 상태의 probe 요청 수, 메서드, 서킷을 닫는 성공 횟수
+		CircuitProbePolicy CircuitProbePolicy
+		// CircuitStateListener 호스트별 서킷 브레이커 상태가 바뀌면 호출되는 함수
+		CircuitStateListener CircuitStateListener
+		BackoffPolicy        func(attempt int) time.Duration
+		ErrorClassifier      ErrorClassifier
+		RetryOnErrors        []error
+		NoRetryOnErrors      []error
+		BodyPeekLimit        int
+		BodyInspector        BodyInspector
+		// BodySpillThreshold GetBody가 없는 요청 바디를 임시 파일에 저장하는 크기 (bytes). 0인 경우 메모리에 버퍼링
+		BodySpillThreshold int64 `env:"BODY_SPILL_THRESHOLD,default=0"`
+		// BodySpillDir 요청 바디를 저장할 임시 디렉터리. 비어 있으면 os.TempDir
+		BodySpillDir string `env:"BODY_SPILL_DIR"`
+		// MaxReplayableBodySize 재시도를 위해 보관하는 요청 바디의 최대 크기 (bytes). 넘는 바디는 재시도하지 않음. 0인 경우 제한하지 않음
+		MaxReplayableBodySize int64 `env:"MAX_REPLAYABLE_BODY_SIZE,default=0"`
+		// CaptureAttempts 실패한 시도의 응답을 보관할 때 복사하는 바디 크기 (bytes). 0인 경우 보관하지 않음
+		CaptureAttempts      int `env:"CAPTURE_ATTEMPTS,default=0"`
+		TransportMiddlewares []func(http.RoundTripper) http.RoundTripper
+		// TransportTweaks 생성한 기본 Transport를 마지막으로 변경하는 함수
+		TransportTweaks []func(*http.Transport)
+		// HostPolicies 호스트별 재시도 정책. 일치하는 호스트가 없으면 클라이언트 정책을 사용
+		HostPolicies map[string]Policy
+		// RoutePolicies 요청 패턴별 재시도 정책. HostPolicies보다 우선 적용
+		RoutePolicies []RoutePolicy
+		// NoRetryStatusCodes 기본 재시도 상태 코드에서 제외할 상태 코드. 추가한 재시도 상태 코드보다 우선 적용
+		NoRetryStatusCodes []int `env:"NO_RETRY_STATUS_CODES,separator=,"`
+		// RetryMethods 재시도할 HTTP 메서드. 비어 있으면 모든 메서드를 재시도
+		RetryMethods []string `env:"RETRY_METHODS,separator=,"`
+		// NoRetryMethods 재시도하지 않을 HTTP 메서드. RetryMethods보다 우선 적용
+		NoRetryMethods []string `env:"NO_RETRY_METHODS,separator=,"`
+		// WarmupHosts 클라이언트 생성 시 미리 커넥션을 생성할 호스트
+		WarmupHosts []string `env:"WARMUP_HOSTS,separator=,"`
+		// WarmupConnections 호스트별로 미리 생성할 커넥션 수
+		WarmupConnections int `env:"WARMUP_CONNECTIONS,default=1"`
+		// DialControl 소켓 옵션 설정 함수 (TCP_NODELAY, SO_MARK 등)
+		DialControl DialControl
+		// Resolver 호스트 이름 조회에 사용할 Resolver. nil인 경우 net.DefaultResolver를 사용
+		Resolver *net.Resolver
+		// IPPreference 연결에 사용할 IP 주소 체계 (dual, ipv4, ipv6)
+		IPPreference IPPreference `env:"IP_PREFERENCE,default=dual"`
+		// Proxies 시도마다 순서대로 사용할 프록시 URL
+		Proxies []string `env:"PROXIES,separator=,"`
+		// ProxyFailureThreshold 프록시를 제외하는 연속 실패 횟수
+		ProxyFailureThreshold int `env:"PROXY_FAILURE_THRESHOLD,default=3"`
+		// ProxyCooldown 제외된 프록시를 다시 사용하기까지의 시간
+		ProxyCooldown time.Duration `env:"PROXY_COOLDOWN,default=1m"`
+		// MirrorURL 요청을 복제하여 전송할 shadow 엔드포인트
+		MirrorURL string `env:"MIRROR_URL"`
+		// MirrorPercent 복제할 요청의 비율 (0~100)
+		MirrorPercent float64 `env:"MIRROR_PERCENT,default=0"`
+		// CanaryStableURL canary 분배 대상인 stable 엔드포인트
+		CanaryStableURL string `env:"CANARY_STABLE_URL"`
+		// CanaryURL 요청 일부를 보낼 canary 엔드포인트
+		CanaryURL string `env:"CANARY_URL"`
+		// CanaryPercent canary 엔드포인트로 보낼 요청의 비율 (0~100)
+		CanaryPercent float64 `env:"CANARY_PERCENT,default=0"`
+		// EndpointService 여러 엔드포인트로 분배할 서비스 주소
+		EndpointService string `env:"ENDPOINT_SERVICE"`
+		// Endpoints 서비스 요청을 분배할 엔드포인트
+		Endpoints []string `env:"ENDPOINTS,separator=,"`
+		// Affinity 같은 세션의 요청을 같은 엔드포인트로 보내기 위한 세션 키 함수
+		Affinity AffinityKey
+		// OutlierDetection 장애 엔드포인트를 일시적으로 제외하는 정책. nil인 경우 제외하지 않음
+		OutlierDetection *OutlierDetection
+		// Cache 응답 캐시 저장소. nil인 경우 캐시하지 않음
+		Cache CacheStore
+		// CacheDefaultTTL Cache-Control max-age, Expires 헤더가 없는 응답의 캐시 유지 시간. 0인 경우 캐시하지 않음
+		CacheDefaultTTL time.Duration `env:"CACHE_DEFAULT_TTL,default=0s"`
+		// CacheMaxEntrySize 캐시할 응답 바디의 최대 크기 (bytes). 0인 경우 제한하지 않음
+		CacheMaxEntrySize int64 `env:"CACHE_MAX_ENTRY_SIZE,default=0"`
+		// VerifyChecksum 응답 헤더(Content-MD5, x-amz-checksum-*)의 체크섬으로 바디를 검증
+		VerifyChecksum bool `env:"VERIFY_CHECKSUM,default=false"`
+		// MaxRetryAfter 응답의 Retry-After 헤더로 대기하는 최대 시간. 0인 경우 제한하지 않음
+		MaxRetryAfter time.Duration `env:"MAX_RETRY_AFTER,default=1m"`
+		// RetryAfterPolicy Retry-After가 MaxRetryAfter를 넘는 경우의 처리 방식 (clamp, fail)
+		RetryAfterPolicy RetryAfterPolicy `env:"RETRY_AFTER_POLICY,default=clamp"`
+		// DeadlinePolicy 백오프 대기가 요청 기한(context deadline, TotalTimeout)을 넘는 경우의 처리 방식 (fail, truncate)
+		DeadlinePolicy DeadlinePolicy `env:"DEADLINE_POLICY,default=fail"`
+		// RateLimitJitterMin Retry-After가 없는 429 응답의 최소 대기 시간
+		RateLimitJitterMin time.Duration `env:"RATE_LIMIT_JITTER_MIN,default=0s"`
+		// RateLimitJitterMax Retry-After가 없는 429 응답의 최대 대기 시간. 0인 경우 백오프 정책을 사용
+		RateLimitJitterMax time.Duration `env:"RATE_LIMIT_JITTER_MAX,default=0s"`
+		// PriorityPolicy 경합 시 낮은 우선순위 요청의 재시도를 줄이는 정책. nil인 경우 우선순위를 구분하지 않음
+		PriorityPolicy *PriorityPolicy
+		// ErrorAggregation 재시도를 포기한 요청의 최종 에러 구성 방식 (all, last, summary)
+		ErrorAggregation ErrorAggregation `env:"ERROR_AGGREGATION,default=all"`
+		// Locale 재시도 사유와 디버그 로그에 사용하는 언어 (en, ko)
+		Locale Locale `env:"LOCALE,default=en"`
+		// Logger 디버그 로그를 받을 Logger. nil인 경우 디버그 모드에서 표준 log 패키지로 출력
+		Logger Logger
+		// DebugSampleRate 재시도 디버그 로그를 N번 중 1번만 출력. 재시도 포기 로그는 항상 출력
+		DebugSampleRate int `env:"DEBUG_SAMPLE_RATE,default=1"`
+		// EventBufferSize Client.Events 채널의 버퍼 크기
+		EventBufferSize int `env:"EVENT_BUFFER_SIZE,default=256"`
+		// AttemptTimings 시도마다 httptrace로 DNS, Connect, TLS, TTFB 구간 시간을 기록
+		AttemptTimings bool `env:"ATTEMPT_TIMINGS,default=false"`
+		// PprofLabels 시도를 처리하는 goroutine에 pprof 레이블을 지정
+		PprofLabels bool `env:"PPROF_LABELS,default=false"`
+		// Propagators 시도 요청에 추적 정보를 전파할 방식. Tracer만 설정된 경우 W3C Trace Context를 사용
+		Propagators []Propagator
+		// Tracer 시도마다 span을 시작하는 추적 hook
+		Tracer Tracer
+		// Observer 재시도 이벤트를 요청과 함께 받는 hook (메트릭 등)
+		Observer Observer
+		// RetryRateAlert 최근 재시도 비율이 임계값을 넘으면 호출되는 알림. nil인 경우 사용하지 않음
+		RetryRateAlert *RetryRateAlert
+		// SharedLimiter 여러 클라이언트가 이름으로 공유하는 시도 속도, 재시도 제한. 비어 있으면 사용하지 않음
+		SharedLimiter string `env:"SHARED_LIMITER"`
+		// Fallback 재시도를 포기한 요청의 대체 응답을 만드는 함수. nil인 경우 에러를 반환
+		Fallback FallbackFunc
+		// BeforeAttempt 시도마다 요청을 보내기 직전에 헤더와 URL을 수정하는 hook. 에러를 반환하면 재시도를 중단
+		BeforeAttempt BeforeAttemptFunc
+		// Bulkheads 이름(호스트 또는 Policy.Bulkhead)별 동시 요청 제한
+		Bulkheads map[string]Bulkhead
+		// LoadShedding 호스트의 p95 지연 시간 기반 요청 거절 정책. nil인 경우 사용하지 않음
+		LoadShedding *LoadShedding
+		// AdaptiveConcurrency 지연 시간 변화로 호스트별 동시 요청 수를 조절하는 정책. nil인 경우 사용하지 않음
+		AdaptiveConcurrency *AdaptiveConcurrency
+		// Guardrails 재시도 횟수, 타임아웃의 허용 범위. nil인 경우 제한하지 않음
+		Guardrails *Guardrails
 	}
 )
 
-// NewSettings constructor
-func NewSettings() *Settings {
-	var settings Settings
-	_, err := env.UnmarshalFromEnviron(&settings)
-	if err != nil {
-		log.Fatal(err)
+// attemptTimeout 시도 1회에 적용되는 타임아웃
+//
+// AttemptTimeout이 설정되지 않은 경우, 하위 호환을 위해 RequestTimeout을 사용
+func (s *Settings) attemptTimeout() time.Duration {
+	if s.AttemptTimeout > 0 {
+		return s.AttemptTimeout
+	}
+	return s.RequestTimeout
+}
+
+// clone 슬라이스, 맵, 정책 포인터를 공유하지 않도록 설정을 복제
+func (s *Settings) clone() *Settings {
+	cloned := *s
+	cloned.RetryOnErrors = slices.Clone(s.RetryOnErrors)
+	cloned.NoRetryOnErrors = slices.Clone(s.NoRetryOnErrors)
+	cloned.TransportMiddlewares = slices.Clone(s.TransportMiddlewares)
+	cloned.TransportTweaks = slices.Clone(s.TransportTweaks)
+	cloned.HostPolicies = maps.Clone(s.HostPolicies)
+	cloned.Bulkheads = maps.Clone(s.Bulkheads)
+	cloned.RoutePolicies = slices.Clone(s.RoutePolicies)
+	cloned.NoRetryStatusCodes = slices.Clone(s.NoRetryStatusCodes)
+	cloned.RetryMethods = slices.Clone(s.RetryMethods)
+	cloned.NoRetryMethods = slices.Clone(s.NoRetryMethods)
+	cloned.WarmupHosts = slices.Clone(s.WarmupHosts)
+	cloned.Proxies = slices.Clone(s.Proxies)
+	cloned.Endpoints = slices.Clone(s.Endpoints)
+	cloned.Propagators = slices.Clone(s.Propagators)
+	cloned.CircuitProbePolicy.Methods = slices.Clone(s.CircuitProbePolicy.Methods)
+	for host, policy := range cloned.HostPolicies {
+		policy.RetryStatusCodes = slices.Clone(policy.RetryStatusCodes)
+		cloned.HostPolicies[host] = policy
+	}
+	for i := range cloned.RoutePolicies {
+		cloned.RoutePolicies[i].Policy.RetryStatusCodes = slices.Clone(cloned.RoutePolicies[i].Policy.RetryStatusCodes)
+	}
+	cloned.OutlierDetection = clonePointer(s.OutlierDetection)
+	cloned.RetryRateAlert = clonePointer(s.RetryRateAlert)
+	cloned.LoadShedding = clonePointer(s.LoadShedding)
+	cloned.AdaptiveConcurrency = clonePointer(s.AdaptiveConcurrency)
+	cloned.PriorityPolicy = clonePointer(s.PriorityPolicy)
+	cloned.Guardrails = clonePointer(s.Guardrails)
+	return &cloned
+}
+
+// With 설정을 복제하고 Option을 적용한 새 설정을 반환. 원본 설정은 변경하지 않음
+//
+// 클라이언트는 생성 시 설정을 복제하므로, 공통 설정을 여러 goroutine에서 공유하면서
+// 용도별 설정을 안전하게 파생할 때 사용합니다.
+//
+//	base := httpretry.NewHTTPSettings(httpretry.WithMaxRetry(3))
+//	batch := base.With(httpretry.WithMaxRetry(10), httpretry.WithAttemptTimeout(time.Minute))
+//
+// Parameters:
+//   - opts: (...HTTPOption) 파생한 설정에 적용할 Option
+func (s *Settings) With(opts ...HTTPOption) *Settings {
+	derived := s.clone()
+	for _, opt := range opts {
+		opt(derived)
 	}
-	return &settings
+	return derived
+}
+
+// clonePointer 포인터가 가리키는 값을 복제. nil이면 nil을 반환
+func clonePointer[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	cloned := *p
+	return &cloned
+}
+
+// sameConnection 커넥션 풀에 적용되는 설정이 같은지 확인
+func (s *Settings) sameConnection(other *Settings) bool {
+	return s.Insecure == other.Insecure &&
+		s.TLSConfig == other.TLSConfig &&
+		s.MaxIdleConns == other.MaxIdleConns &&
+		s.IdleConnTimeout == other.IdleConnTimeout &&
+		s.TLSHandshakeTimeout == other.TLSHandshakeTimeout &&
+		s.ExpectContinueTimeout == other.ExpectContinueTimeout &&
+		s.ResponseHeaderTimeout == other.ResponseHeaderTimeout &&
+		s.DisableKeepAlives == other.DisableKeepAlives &&
+		s.DisableCompression == other.DisableCompression &&
+		s.ReadBufferSize == other.ReadBufferSize &&
+		s.WriteBufferSize == other.WriteBufferSize &&
+		s.MaxResponseHeaderBytes == other.MaxResponseHeaderBytes &&
+		sameFunc(s.DialControl, other.DialControl) &&
+		s.Resolver == other.Resolver &&
+		s.IPPreference == other.IPPreference &&
+		(len(s.Proxies) > 0) == (len(other.Proxies) > 0) &&
+		slices.EqualFunc(s.TransportTweaks, other.TransportTweaks, func(a, b func(*http.Transport)) bool { return sameFunc(a, b) })
+}
+
+// sameFunc 두 함수가 같은 함수인지 확인. 클로저는 같은 코드라도 다른 값일 수 있음
+func sameFunc(a, b any) bool {
+	return reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
 }
@@ -0,0 +1,97 @@
+package httpretry
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// SharedLimit 이름으로 여러 클라이언트가 공유하는 시도 속도 제한과 재시도 제한
+//
+// 같은 서드파티를 호출하는 여러 서브시스템이 각자의 클라이언트를 사용하더라도 하나의 할당량을 함께 지키도록 합니다.
+// 제한을 설정하지 않은 항목은 제한하지 않으며, 설정은 언제든 바꿀 수 있습니다.
+type SharedLimit struct {
+	name string
+	// attempts 모든 시도(첫 시도 포함)의 초당 제한. 자리가 날 때까지 대기
+	attempts atomic.Pointer[retryLimiter]
+	// retries 재시도의 초당 제한. 제한에 도달하면 재시도하지 않음
+	retries atomic.Pointer[retryLimiter]
+}
+
+// sharedLimits 이름별 공유 제한
+var sharedLimits sync.Map // map[string]*SharedLimit
+
+// SharedLimiter 이름으로 공유하는 제한을 반환. 처음 요청한 이름이면 제한이 없는 상태로 생성
+//
+// 같은 이름은 항상 같은 *SharedLimit을 반환하므로, 시작 시점에 한 번 제한을 설정하고
+// 각 클라이언트는 WithSharedLimiter로 이름만 지정합니다.
+//
+//	httpretry.SharedLimiter("github-api").SetRateLimit(10, 20)
+//	httpretry.SharedLimiter("github-api").SetRetryLimit(1, 5)
+//
+// Parameters:
+//   - name: (string) 제한 이름
+func SharedLimiter(name string) *SharedLimit {
+	limit, _ := sharedLimits.LoadOrStore(name, &SharedLimit{name: name})
+	return limit.(*SharedLimit)
+}
+
+// Name 제한 이름을 반환
+func (l *SharedLimit) Name() string {
+	return l.name
+}
+
+// SetRateLimit 첫 시도와 재시도를 합한 초당 시도 횟수를 제한
+//
+// 자리가 없으면 요청 context가 끝날 때까지 기다립니다. perSecond가 0 이하이면 제한을 해제합니다.
+//
+// Parameters:
+//   - perSecond: (float64) 초당 허용하는 시도 횟수
+//   - burst: (int) 한 번에 허용하는 최대 시도 횟수. 0 이하이면 perSecond를 사용
+func (l *SharedLimit) SetRateLimit(perSecond float64, burst int) {
+	l.attempts.Store(newRetryLimiter(perSecond, burst))
+}
+
+// SetRetryLimit 초당 재시도 횟수를 제한
+//
+// 제한에 도달하면 재시도하지 않고 ErrRetryLimited(ReasonRetryLimited)로 실패하며, 첫 시도는 제한하지 않습니다.
+// perSecond가 0 이하이면 제한을 해제합니다.
+//
+// Parameters:
+//   - perSecond: (float64) 초당 허용하는 재시도 횟수
+//   - burst: (int) 한 번에 허용하는 최대 재시도 횟수. 0 이하이면 perSecond를 사용
+func (l *SharedLimit) SetRetryLimit(perSecond float64, burst int) {
+	l.retries.Store(newRetryLimiter(perSecond, burst))
+}
+
+// sharedLimitFor 설정한 이름의 공유 제한. 이름이 비어 있으면 nil을 반환
+func sharedLimitFor(name string) *SharedLimit {
+	if name == "" {
+		return nil
+	}
+	return SharedLimiter(name)
+}
+
+// waitAttempt 시도 속도 제한에 자리가 날 때까지 대기
+func (l *SharedLimit) waitAttempt(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	limiter := l.attempts.Load()
+	if limiter == nil {
+		return nil
+	}
+	return limiter.wait(ctx)
+}
+
+// allowRetry 재시도 제한에서 재시도 한 번을 허용하는지 확인
+func (l *SharedLimit) allowRetry() bool {
+	if l == nil {
+		return true
+	}
+	limiter := l.retries.Load()
+	if limiter == nil {
+		return true
+	}
+	return limiter.allow()
+}
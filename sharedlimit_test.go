@@ -0,0 +1,90 @@
+package httpretry_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSharedLimiter(t *testing.T) {
+	t.Run("같은 이름은 같은 제한을 반환하는 테스트", func(t *testing.T) {
+		// when
+		first, second := httpretry.SharedLimiter("same-name"), httpretry.SharedLimiter("same-name")
+
+		// then
+		assert.Same(t, first, second)
+		assert.NotSame(t, first, httpretry.SharedLimiter("other-name"))
+		assert.Equal(t, "same-name", first.Name())
+	})
+
+	t.Run("같은 이름을 지정한 클라이언트의 재시도를 함께 제한하는 테스트", func(t *testing.T) {
+		// given
+		var received atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		httpretry.SharedLimiter("retry-quota").SetRetryLimit(0.001, 2)
+		newClient := func(name string) *http.Client {
+			return httpretry.NewClient(httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(3),
+				httpretry.WithBackoffPolicy(noBackoff),
+				httpretry.WithSharedLimiter(name),
+			))
+		}
+		first, second, other := newClient("retry-quota"), newClient("retry-quota"), newClient("")
+
+		// when
+		_, firstErr := first.Get(server.URL)
+		_, secondErr := second.Get(server.URL)
+		_, otherErr := other.Get(server.URL)
+
+		// then
+		assert.Equal(t, int32(7), received.Load(), "첫 요청은 3번, 두 번째 요청은 재시도 없이 1번, 다른 클라이언트는 3번 전송")
+		var retryErr *httpretry.RetryError
+		assert.True(t, errors.As(firstErr, &retryErr))
+		assert.Equal(t, httpretry.ReasonBudgetExhausted, retryErr.Reason)
+		assert.True(t, errors.As(secondErr, &retryErr))
+		assert.Equal(t, httpretry.ReasonRetryLimited, retryErr.Reason)
+		assert.True(t, errors.As(otherErr, &retryErr))
+		assert.Equal(t, httpretry.ReasonBudgetExhausted, retryErr.Reason)
+	})
+
+	t.Run("시도 속도 제한에 자리가 없으면 기다리고, context가 끝나면 요청하지 않는 테스트", func(t *testing.T) {
+		// given
+		var received atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received.Add(1)
+		}))
+		defer server.Close()
+		httpretry.SharedLimiter("rate-quota").SetRateLimit(1, 1)
+		newClient := func() *http.Client {
+			return httpretry.NewClient(httpretry.NewHTTPSettings(httpretry.WithSharedLimiter("rate-quota")))
+		}
+		first, second := newClient(), newClient()
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+		// when
+		resp, firstErr := first.Get(server.URL)
+		_, secondErr := second.Do(req)
+
+		// then
+		assert.NoError(t, firstErr)
+		resp.Body.Close()
+		var retryErr *httpretry.RetryError
+		assert.True(t, errors.As(secondErr, &retryErr))
+		assert.Equal(t, httpretry.ReasonContextCancelled, retryErr.Reason)
+		assert.ErrorIs(t, secondErr, context.DeadlineExceeded)
+		assert.Equal(t, int32(1), received.Load())
+	})
+}
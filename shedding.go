@@ -0,0 +1,129 @@
+package httpretry
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrLoadShed 호스트의 지연 시간이 임계값을 넘어 요청하지 않음
+var ErrLoadShed = errors.New("load shed")
+
+const (
+	// defaultSheddingWindow 지연 시간을 집계하는 기본 기간
+	defaultSheddingWindow = 10 * time.Second
+	// defaultSheddingMinSamples p95를 판단하기 위한 기본 최소 표본 수
+	defaultSheddingMinSamples = 20
+	// defaultSheddingMaxRejectRatio 기본 최대 거절 비율
+	defaultSheddingMaxRejectRatio = 0.9
+	// sheddingSamples 호스트별로 보관하는 최근 지연 시간 표본 수
+	sheddingSamples = 128
+)
+
+type (
+	// LoadShedding 지연 시간 기반 요청 거절 정책
+	//
+	// 호스트의 최근 p95 지연 시간이 Threshold를 넘으면, 넘은 정도에 비례한 확률로 새 요청을 연결 전에 거절합니다.
+	// p95가 Threshold의 2배이면 MaxRejectRatio만큼 거절합니다.
+	LoadShedding struct {
+		// Threshold p95 지연 시간 임계값
+		Threshold time.Duration
+		// Window 지연 시간을 집계하는 기간. 0인 경우 10초
+		Window time.Duration
+		// MinSamples p95를 판단하기 위한 최소 표본 수. 0인 경우 20
+		MinSamples int
+		// MaxRejectRatio 최대 거절 비율 (0~1). 회복을 확인할 요청은 항상 남겨둠. 0인 경우 0.9
+		MaxRejectRatio float64
+	}
+
+	// latencySample 시도 지연 시간 표본
+	latencySample struct {
+		at       time.Time
+		duration time.Duration
+	}
+
+	// hostLatency 호스트의 최근 지연 시간 표본 (ring buffer)
+	hostLatency struct {
+		samples [sheddingSamples]latencySample
+		next    int
+	}
+
+	// shedder 호스트별 p95 지연 시간으로 요청을 거절
+	shedder struct {
+		policy LoadShedding
+		mu     sync.Mutex
+		hosts  map[string]*hostLatency
+	}
+)
+
+// newShedder 정책이 설정되지 않으면 nil을 반환
+func newShedder(policy *LoadShedding) *shedder {
+	if policy == nil || policy.Threshold <= 0 {
+		return nil
+	}
+	s := &shedder{policy: *policy, hosts: make(map[string]*hostLatency)}
+	if s.policy.Window <= 0 {
+		s.policy.Window = defaultSheddingWindow
+	}
+	if s.policy.MinSamples <= 0 {
+		s.policy.MinSamples = defaultSheddingMinSamples
+	}
+	if s.policy.MaxRejectRatio <= 0 {
+		s.policy.MaxRejectRatio = defaultSheddingMaxRejectRatio
+	}
+	return s
+}
+
+// observe 시도 종료 이벤트의 지연 시간을 호스트별로 기록
+func (s *shedder) observe(host string, event RetryEvent) {
+	if event.Type != EventAttemptFinish {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	latency, ok := s.hosts[host]
+	if !ok {
+		latency = &hostLatency{}
+		s.hosts[host] = latency
+	}
+	latency.samples[latency.next] = latencySample{at: event.Time, duration: event.Duration}
+	latency.next = (latency.next + 1) % sheddingSamples
+}
+
+// p95 Window 기간 표본의 p95 지연 시간. 표본이 부족하면 false
+func (s *shedder) p95(host string) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	latency, ok := s.hosts[host]
+	if !ok {
+		return 0, false
+	}
+	since := time.Now().Add(-s.policy.Window)
+	durations := make([]time.Duration, 0, sheddingSamples)
+	for _, sample := range latency.samples {
+		if sample.at.After(since) {
+			durations = append(durations, sample.duration)
+		}
+	}
+	if len(durations) < s.policy.MinSamples {
+		return 0, false
+	}
+	slices.Sort(durations)
+	return durations[(len(durations)*95-1)/100], true
+}
+
+// admit 요청을 보낼지 판단. 거절하는 경우 ErrLoadShed를 반환
+func (s *shedder) admit(host string) error {
+	p95, ok := s.p95(host)
+	if !ok || p95 <= s.policy.Threshold {
+		return nil
+	}
+	ratio := min(float64(p95-s.policy.Threshold)/float64(s.policy.Threshold), 1) * s.policy.MaxRejectRatio
+	if rand.Float64() >= ratio {
+		return nil
+	}
+	return fmt.Errorf("host(%s) p95 %s over %s: %w", host, p95, s.policy.Threshold, ErrLoadShed)
+}
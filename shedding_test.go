@@ -0,0 +1,72 @@
+package httpretry_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLoadShedding(t *testing.T) {
+	t.Run("p95 지연 시간이 임계값을 넘으면 연결 전에 거절하는 테스트", func(t *testing.T) {
+		// given
+		var received atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received.Add(1)
+			time.Sleep(20 * time.Millisecond)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithLoadShedding(httpretry.LoadShedding{
+				Threshold:      time.Millisecond,
+				MinSamples:     5,
+				MaxRejectRatio: 1,
+			}),
+		))
+		for range 5 {
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			resp, err := client.Do(req)
+			assert.NoError(t, err)
+			resp.Body.Close()
+		}
+
+		// when
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		_, err := client.Do(req)
+
+		// then
+		assert.ErrorIs(t, err, httpretry.ErrLoadShed)
+		assert.Equal(t, int32(5), received.Load())
+	})
+
+	t.Run("표본이 부족하거나 임계값 이하이면 거절하지 않는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithLoadShedding(httpretry.LoadShedding{Threshold: time.Second, MinSamples: 5}),
+		))
+
+		// when
+		var errs []error
+		for range 10 {
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			resp, err := client.Do(req)
+			if err == nil {
+				resp.Body.Close()
+			}
+			errs = append(errs, err)
+		}
+
+		// then
+		for _, err := range errs {
+			assert.NoError(t, err)
+		}
+	})
+}
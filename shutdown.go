@@ -0,0 +1,143 @@
+package httpretry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// ErrClientClosed 종료된 클라이언트로 요청했음을 나타내는 에러
+var ErrClientClosed = errors.New("httpretry: client closed")
+
+type (
+	// Client 종료 기능을 제공하는 재시도 클라이언트
+	//
+	// *http.Client를 포함하므로 기존 클라이언트와 동일하게 사용할 수 있습니다.
+	Client struct {
+		*http.Client
+		transport *retriableTransport
+	}
+
+	// lifecycle 진행 중인 요청을 추적하여 종료 시 대기
+	lifecycle struct {
+		mu     sync.Mutex
+		active int
+		// closing 종료가 시작되면 닫히는 채널. 백오프 대기를 중단
+		closing chan struct{}
+		// idle 종료가 시작된 이후 진행 중인 요청이 모두 끝나면 닫히는 채널
+		idle     chan struct{}
+		shutdown bool
+	}
+
+	// closeIdler 유휴 커넥션을 정리할 수 있는 Transport
+	closeIdler interface {
+		CloseIdleConnections()
+	}
+)
+
+// New 종료 기능을 제공하는 재시도 클라이언트를 생성
+//
+// Parameters:
+//   - settings: (*Settings) 재시도 설정. nil인 경우 기본 설정을 사용
+//   - retryStatusCodes: (...int) 기본 재시도 상태 코드에 추가할 상태 코드
+func New(settings *Settings, retryStatusCodes ...int) *Client {
+	transport := newRetriableTransport(settings, retryStatusCodes...)
+	return &Client{
+		Client:    &http.Client{Transport: transport},
+		transport: transport,
+	}
+}
+
+// Shutdown 새 요청과 재시도를 중단하고, 진행 중인 요청이 끝나기를 기다린 뒤 유휴 커넥션을 정리
+//
+// 진행 중인 요청은 응답 바디를 모두 읽거나 닫으면 끝난 것으로 봅니다.
+// ctx가 먼저 만료되면 대기를 중단하고 유휴 커넥션을 정리한 뒤 ctx의 에러를 반환합니다.
+// 종료 이후의 요청은 ErrClientClosed를 반환합니다.
+//
+// Parameters:
+//   - ctx: (context.Context) 대기 시간을 제한하는 context
+func (c *Client) Shutdown(ctx context.Context) error {
+	idle := c.transport.lifecycle.close()
+	defer c.transport.CloseIdleConnections()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close 새 요청과 재시도를 중단하고 유휴 커넥션을 정리. 진행 중인 요청은 기다리지 않음
+func (c *Client) Close() error {
+	c.transport.lifecycle.close()
+	c.transport.CloseIdleConnections()
+	return nil
+}
+
+// CloseIdleConnections 기본 Transport의 유휴 커넥션을 정리
+//
+// http.Client.CloseIdleConnections에서 호출됩니다.
+func (rt *retriableTransport) CloseIdleConnections() {
+	if transport, ok := rt.transport.(closeIdler); ok {
+		transport.CloseIdleConnections()
+	}
+}
+
+// newLifecycle 요청 추적을 생성
+func newLifecycle() *lifecycle {
+	return &lifecycle{closing: make(chan struct{}), idle: make(chan struct{})}
+}
+
+// acquire 요청 시작을 기록. 종료된 경우 false를 반환
+func (l *lifecycle) acquire() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.shutdown {
+		return false
+	}
+	l.active++
+	return true
+}
+
+// release 요청 종료를 기록
+func (l *lifecycle) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.active--
+	if l.shutdown && l.active == 0 {
+		close(l.idle)
+	}
+}
+
+// inFlight 진행 중인 요청 수
+func (l *lifecycle) inFlight() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.active
+}
+
+// closed 종료가 시작되었는지 확인
+func (l *lifecycle) closed() bool {
+	select {
+	case <-l.closing:
+		return true
+	default:
+		return false
+	}
+}
+
+// close 종료를 시작하고, 진행 중인 요청이 모두 끝나면 닫히는 채널을 반환
+func (l *lifecycle) close() <-chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.shutdown {
+		l.shutdown = true
+		close(l.closing)
+		if l.active == 0 {
+			close(l.idle)
+		}
+	}
+	return l.idle
+}
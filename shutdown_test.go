@@ -0,0 +1,110 @@
+package httpretry_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Shutdown(t *testing.T) {
+	t.Run("진행 중인 요청이 끝나기를 기다리고, 이후 요청은 거절하는 테스트", func(t *testing.T) {
+		// given
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(100 * time.Millisecond)
+				w.Write([]byte("done"))
+			}),
+		)
+		defer testServer.Close()
+
+		client := httpretry.New(httpretry.NewHTTPSettings())
+		result := make(chan string, 1)
+		go func() {
+			resp, err := client.Get(testServer.URL)
+			if err != nil {
+				result <- err.Error()
+				return
+			}
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			result <- string(body)
+		}()
+		time.Sleep(20 * time.Millisecond)
+
+		// when
+		start := time.Now()
+		err := client.Shutdown(context.Background())
+		elapsed := time.Since(start)
+		_, closedErr := client.Get(testServer.URL)
+
+		// then
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, elapsed, 50*time.Millisecond, "진행 중인 요청을 기다려야 합니다.")
+		assert.Equal(t, "done", <-result)
+		assert.ErrorIs(t, closedErr, httpretry.ErrClientClosed)
+	})
+
+	t.Run("종료 시 백오프 대기 중인 요청은 재시도하지 않는 테스트", func(t *testing.T) {
+		// given
+		reqCount := 0
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reqCount++
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}),
+		)
+		defer testServer.Close()
+
+		client := httpretry.New(
+			httpretry.NewHTTPSettings(
+				httpretry.WithBackoffPolicy(func(int) time.Duration { return time.Minute }),
+			),
+		)
+		result := make(chan error, 1)
+		go func() {
+			_, err := client.Get(testServer.URL)
+			result <- err
+		}()
+		time.Sleep(50 * time.Millisecond)
+
+		// when
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		err := client.Shutdown(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.ErrorIs(t, <-result, httpretry.ErrClientClosed)
+		assert.Equal(t, 1, reqCount)
+	})
+
+	t.Run("대기 시간이 만료되면 context 에러 반환 테스트", func(t *testing.T) {
+		// given
+		release := make(chan struct{})
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				<-release
+			}),
+		)
+		defer testServer.Close()
+		defer close(release)
+
+		client := httpretry.New(httpretry.NewHTTPSettings())
+		go client.Get(testServer.URL)
+		time.Sleep(20 * time.Millisecond)
+
+		// when
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		err := client.Shutdown(ctx)
+
+		// then
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,242 @@
+package httpretry
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// DefaultTimingBuckets 시도 구간별 지연 시간 히스토그램의 기본 구간 상한
+var DefaultTimingBuckets = []time.Duration{
+	time.Millisecond, 5 * time.Millisecond, 10 * time.Millisecond, 25 * time.Millisecond,
+	50 * time.Millisecond, 100 * time.Millisecond, 250 * time.Millisecond, 500 * time.Millisecond,
+	time.Second, 2500 * time.Millisecond, 5 * time.Second, 10 * time.Second,
+}
+
+type (
+	// AttemptTimings 시도 1회의 구간별 소요 시간 (httptrace)
+	//
+	// 재사용한 커넥션은 DNS, Connect, TLS가 0입니다.
+	AttemptTimings struct {
+		// DNS 호스트 이름 조회 시간
+		DNS time.Duration
+		// Connect TCP 연결 시간
+		Connect time.Duration
+		// TLS TLS 핸드셰이크 시간
+		TLS time.Duration
+		// TTFB 시도 시작부터 응답의 첫 바이트를 받기까지의 시간. 응답을 받지 못한 경우 0
+		TTFB time.Duration
+		// ConnReused 유휴 커넥션을 재사용했는지 여부
+		ConnReused bool
+	}
+
+	// TimingHistogram 구간별 지연 시간 히스토그램
+	TimingHistogram struct {
+		// Buckets 구간 상한. Counts[i]는 Buckets[i] 이하인 값의 수이며, 마지막 Counts는 모든 구간을 넘는 값의 수
+		Buckets []time.Duration
+		Counts  []uint64
+		// Count 기록한 값의 수
+		Count uint64
+		// Sum 기록한 값의 합
+		Sum time.Duration
+	}
+
+	// Stats 클라이언트가 보낸 시도의 구간별 지연 시간 통계
+	Stats struct {
+		// Attempts 기록한 시도 수
+		Attempts uint64
+		DNS      TimingHistogram
+		Connect  TimingHistogram
+		TLS      TimingHistogram
+		TTFB     TimingHistogram
+	}
+
+	// attemptTrace 시도 1회의 httptrace 구간 시간을 기록
+	//
+	// 연결 hook은 dial goroutine에서 호출될 수 있으므로 잠금으로 보호합니다.
+	attemptTrace struct {
+		mu                                      sync.Mutex
+		start, dnsStart, connectStart, tlsStart time.Time
+		timings                                 AttemptTimings
+	}
+
+	// timingStats 클라이언트의 구간별 지연 시간 통계
+	timingStats struct {
+		mu    sync.Mutex
+		stats Stats
+	}
+)
+
+// newTimingStats 기본 구간으로 통계를 생성
+func newTimingStats() *timingStats {
+	histogram := func() TimingHistogram {
+		return TimingHistogram{Buckets: DefaultTimingBuckets, Counts: make([]uint64, len(DefaultTimingBuckets)+1)}
+	}
+	return &timingStats{stats: Stats{DNS: histogram(), Connect: histogram(), TLS: histogram(), TTFB: histogram()}}
+}
+
+// traceAttempt 구간 시간을 기록하는 httptrace를 시도 요청에 연결
+//
+// 요청 context에 이미 있는 httptrace hook도 함께 호출됩니다.
+func traceAttempt(attemptReq *http.Request, start time.Time) (*http.Request, *attemptTrace) {
+	t := &attemptTrace{start: start}
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.timings.ConnReused = info.Reused
+		},
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.dnsStart = time.Now()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.timings.DNS = time.Since(t.dnsStart)
+		},
+		ConnectStart: func(string, string) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.connectStart = time.Now()
+		},
+		ConnectDone: func(string, string, error) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.timings.Connect = time.Since(t.connectStart)
+		},
+		TLSHandshakeStart: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.timings.TLS = time.Since(t.tlsStart)
+		},
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.timings.TTFB = time.Since(t.start)
+		},
+	}
+	return attemptReq.WithContext(httptrace.WithClientTrace(attemptReq.Context(), trace)), t
+}
+
+// finish 기록한 구간 시간을 반환. 추적하지 않은 경우 빈 값을 반환
+func (t *attemptTrace) finish() AttemptTimings {
+	if t == nil {
+		return AttemptTimings{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.timings
+}
+
+// observe 값이 속한 구간의 수를 증가
+func (h *TimingHistogram) observe(value time.Duration) {
+	i, _ := slices.BinarySearch(h.Buckets, value)
+	h.Counts[i]++
+	h.Count++
+	h.Sum += value
+}
+
+// clone 구간과 수를 공유하지 않도록 복제
+func (h TimingHistogram) clone() TimingHistogram {
+	h.Buckets = slices.Clone(h.Buckets)
+	h.Counts = slices.Clone(h.Counts)
+	return h
+}
+
+// record 시도의 구간 시간을 통계에 기록. 일어나지 않은 구간은 기록하지 않음
+func (s *timingStats) record(timings AttemptTimings) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.Attempts++
+	for _, phase := range []struct {
+		histogram *TimingHistogram
+		value     time.Duration
+	}{
+		{&s.stats.DNS, timings.DNS},
+		{&s.stats.Connect, timings.Connect},
+		{&s.stats.TLS, timings.TLS},
+		{&s.stats.TTFB, timings.TTFB},
+	} {
+		if phase.value > 0 {
+			phase.histogram.observe(phase.value)
+		}
+	}
+}
+
+// snapshot 현재 통계를 복제하여 반환
+func (s *timingStats) snapshot() Stats {
+	if s == nil {
+		return Stats{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Stats{
+		Attempts: s.stats.Attempts,
+		DNS:      s.stats.DNS.clone(),
+		Connect:  s.stats.Connect.clone(),
+		TLS:      s.stats.TLS.clone(),
+		TTFB:     s.stats.TTFB.clone(),
+	}
+}
+
+// Stats 시도의 구간별(DNS, Connect, TLS, TTFB) 지연 시간 통계를 반환
+//
+// Settings.AttemptTimings가 꺼져 있으면 빈 통계를 반환합니다.
+func (c *Client) Stats() Stats {
+	return c.transport.timings.snapshot()
+}
+
+// TimingTable 시도별 구간 시간을 표로 반환. 장애 분석 시 어느 구간에서 지연되었는지 확인할 때 사용
+//
+//	ATTEMPT  STATUS  DNS  CONNECT  TLS  TTFB   TOTAL  ERROR
+//	1        503     2ms  1ms      5ms  120ms  121ms  retryable status code 503
+func (e *RetryError) TimingTable() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ATTEMPT\tSTATUS\tDNS\tCONNECT\tTLS\tTTFB\tTOTAL\tERROR")
+	for _, err := range e.errs {
+		attemptErr, ok := err.(*AttemptError)
+		if !ok {
+			continue
+		}
+		status := "-"
+		if attemptErr.StatusCode > 0 {
+			status = fmt.Sprint(attemptErr.StatusCode)
+		}
+		timings := attemptErr.Timings
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%v\n",
+			attemptErr.Attempt, status,
+			formatTiming(timings.DNS), formatTiming(timings.Connect), formatTiming(timings.TLS), formatTiming(timings.TTFB),
+			attemptErr.Duration.Round(time.Millisecond), attemptErr.Err)
+	}
+	w.Flush()
+	return b.String()
+}
+
+// formatTiming 표에 표시할 구간 시간. 일어나지 않은 구간은 "-"
+func formatTiming(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return "-"
+	case d < time.Millisecond:
+		return d.Round(time.Microsecond).String()
+	default:
+		return d.Round(time.Millisecond).String()
+	}
+}
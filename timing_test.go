@@ -0,0 +1,75 @@
+package httpretry_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithAttemptTimings(t *testing.T) {
+	t.Run("시도별 구간 시간을 통계와 시도 에러에 기록하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		client := httpretry.New(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithAttemptTimings(true),
+		))
+		events := client.Events()
+
+		// when
+		_, err := client.Get(server.URL)
+
+		// then
+		stats := client.Stats()
+		assert.Equal(t, uint64(2), stats.Attempts)
+		assert.Equal(t, uint64(2), stats.TTFB.Count)
+		assert.Equal(t, uint64(1), stats.Connect.Count, "두 번째 시도는 커넥션을 재사용해야 합니다.")
+		assert.Len(t, stats.TTFB.Counts, len(httpretry.DefaultTimingBuckets)+1)
+
+		var attemptErr *httpretry.AttemptError
+		assert.True(t, errors.As(err, &attemptErr))
+		assert.Positive(t, attemptErr.Timings.TTFB)
+		assert.Positive(t, attemptErr.Timings.Connect)
+
+		var finished []httpretry.RetryEvent
+		for len(events) > 0 {
+			if event := <-events; event.Type == httpretry.EventAttemptFinish {
+				finished = append(finished, event)
+			}
+		}
+		assert.Len(t, finished, 2)
+		assert.True(t, finished[1].Timings.ConnReused)
+
+		var retryErr *httpretry.RetryError
+		assert.True(t, errors.As(err, &retryErr))
+		table := strings.Split(strings.TrimSpace(retryErr.TimingTable()), "\n")
+		assert.Len(t, table, 3)
+		assert.True(t, strings.HasPrefix(table[0], "ATTEMPT"))
+		assert.True(t, strings.HasPrefix(table[1], "1"))
+		assert.Contains(t, table[1], "503")
+	})
+
+	t.Run("구간 시간을 기록하지 않으면 빈 통계를 반환하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+		client := httpretry.New(httpretry.NewHTTPSettings())
+
+		// when
+		resp, err := client.Get(server.URL)
+
+		// then
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, httpretry.Stats{}, client.Stats())
+	})
+}
@@ -0,0 +1,167 @@
+package httpretry
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+const (
+	// HeaderTraceparent W3C Trace Context의 traceparent 헤더
+	HeaderTraceparent = "Traceparent"
+	// HeaderTracestate W3C Trace Context의 tracestate 헤더
+	HeaderTracestate = "Tracestate"
+)
+
+type (
+	// SpanContext 시도 요청에 전파할 추적 정보
+	SpanContext struct {
+		TraceID [16]byte
+		SpanID  [8]byte
+		Sampled bool
+		// TraceState 벤더별 추적 정보 (W3C tracestate)
+		TraceState string
+	}
+
+	// Propagator 추적 정보를 요청 헤더로 주입하고 추출하는 전파 방식
+	Propagator interface {
+		// Inject 추적 정보를 요청 헤더에 주입
+		Inject(header http.Header, sc SpanContext)
+		// Extract 요청 헤더에서 추적 정보를 추출. 없거나 올바르지 않으면 false
+		Extract(header http.Header) (SpanContext, bool)
+	}
+
+	// AttemptSpan 시도 span을 시작하는 데 필요한 정보
+	AttemptSpan struct {
+		// Request 시도 요청
+		Request *http.Request
+		// Attempt 시도 번호 (1부터 시작)
+		Attempt int
+		// Parent 요청 헤더에서 추출한 부모 추적 정보. 없으면 IsValid가 false
+		Parent SpanContext
+		// Previous 직전 시도의 span. 첫 시도는 IsValid가 false
+		Previous SpanContext
+	}
+
+	// Tracer 시도마다 span을 시작하는 추적 hook
+	//
+	// 반환한 SpanContext는 시도 요청 헤더로 전파되며, 반환한 함수는 시도가 끝나면
+	// EventAttemptFinish 이벤트로 호출됩니다. OpenTelemetry는 traceotel 패키지를 사용합니다.
+	Tracer interface {
+		StartAttempt(ctx context.Context, span AttemptSpan) (context.Context, SpanContext, func(RetryEvent))
+	}
+
+	// traceContextPropagator W3C Trace Context (traceparent, tracestate) 전파 방식
+	traceContextPropagator struct{}
+
+	// tracing 시도마다 추적 정보를 전파
+	tracing struct {
+		tracer      Tracer
+		propagators []Propagator
+	}
+)
+
+// TraceContext W3C Trace Context (traceparent, tracestate) 전파 방식
+var TraceContext Propagator = traceContextPropagator{}
+
+// IsValid TraceID와 SpanID가 모두 설정되었는지 확인
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != [16]byte{} && sc.SpanID != [8]byte{}
+}
+
+// Inject traceparent, tracestate 헤더를 설정
+func (traceContextPropagator) Inject(header http.Header, sc SpanContext) {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	header.Set(HeaderTraceparent, "00-"+hex.EncodeToString(sc.TraceID[:])+"-"+hex.EncodeToString(sc.SpanID[:])+"-"+flags)
+	if sc.TraceState != "" {
+		header.Set(HeaderTracestate, sc.TraceState)
+	} else {
+		header.Del(HeaderTracestate)
+	}
+}
+
+// Extract traceparent, tracestate 헤더를 읽음
+//
+// 이후 버전의 traceparent는 앞의 네 필드만 읽습니다.
+func (traceContextPropagator) Extract(header http.Header) (SpanContext, bool) {
+	var sc SpanContext
+	parts := strings.Split(strings.TrimSpace(header.Get(HeaderTraceparent)), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return sc, false
+	}
+	version, err := hex.DecodeString(parts[0])
+	if err != nil || len(version) != 1 {
+		return sc, false
+	}
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil || len(flags) != 1 ||
+		!decodeHex(sc.TraceID[:], parts[1]) || !decodeHex(sc.SpanID[:], parts[2]) || !sc.IsValid() {
+		return SpanContext{}, false
+	}
+	sc.Sampled = flags[0]&0x01 == 0x01
+	sc.TraceState = header.Get(HeaderTracestate)
+	return sc, true
+}
+
+// decodeHex 소문자 16진수 문자열을 dst 길이만큼 디코딩
+func decodeHex(dst []byte, s string) bool {
+	if len(s) != hex.EncodedLen(len(dst)) || strings.ToLower(s) != s {
+		return false
+	}
+	_, err := hex.Decode(dst, []byte(s))
+	return err == nil
+}
+
+// newTracing 추적 설정이 없으면 nil을 반환
+func newTracing(settings *Settings) *tracing {
+	if settings.Tracer == nil && len(settings.Propagators) == 0 {
+		return nil
+	}
+	propagators := settings.Propagators
+	if len(propagators) == 0 {
+		propagators = []Propagator{TraceContext}
+	}
+	return &tracing{tracer: settings.Tracer, propagators: propagators}
+}
+
+// parent 요청 헤더에서 부모 추적 정보를 추출. 설정된 전파 방식 순서로 확인
+func (t *tracing) parent(req *http.Request) SpanContext {
+	for _, propagator := range t.propagators {
+		if sc, ok := propagator.Extract(req.Header); ok {
+			return sc
+		}
+	}
+	return SpanContext{}
+}
+
+// start 시도 span을 시작하고, 추적 정보를 주입한 시도 요청을 반환
+//
+// Tracer가 없으면 부모 추적 정보를 설정된 모든 전파 방식으로 그대로 전파합니다.
+// 헤더는 원본 요청과 공유하지 않도록 복제합니다.
+func (t *tracing) start(attemptReq *http.Request, parent, previous SpanContext, attempt int) (*http.Request, SpanContext, func(RetryEvent)) {
+	sc, end := parent, func(RetryEvent) {}
+	if t.tracer != nil {
+		var ctx context.Context
+		ctx, sc, end = t.tracer.StartAttempt(attemptReq.Context(), AttemptSpan{
+			Request:  attemptReq,
+			Attempt:  attempt,
+			Parent:   parent,
+			Previous: previous,
+		})
+		attemptReq = attemptReq.WithContext(ctx)
+	}
+	if !sc.IsValid() {
+		return attemptReq, sc, end
+	}
+	if attemptReq.Header = attemptReq.Header.Clone(); attemptReq.Header == nil {
+		attemptReq.Header = make(http.Header)
+	}
+	for _, propagator := range t.propagators {
+		propagator.Inject(attemptReq.Header, sc)
+	}
+	return attemptReq, sc, end
+}
@@ -0,0 +1,132 @@
+package httpretry_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// attemptTracer 시도마다 SpanID의 마지막 바이트를 시도 번호로 설정하는 Tracer
+type attemptTracer struct {
+	mu    sync.Mutex
+	spans []httpretry.AttemptSpan
+	ended []httpretry.RetryEvent
+}
+
+func (t *attemptTracer) StartAttempt(ctx context.Context, span httpretry.AttemptSpan) (context.Context, httpretry.SpanContext, func(httpretry.RetryEvent)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spans = append(t.spans, span)
+	sc := span.Parent
+	sc.SpanID = [8]byte{7: byte(span.Attempt)}
+	return ctx, sc, func(event httpretry.RetryEvent) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.ended = append(t.ended, event)
+	}
+}
+
+func TestTraceContext(t *testing.T) {
+	t.Run("traceparent 헤더를 추출하고 다시 주입하는 테스트", func(t *testing.T) {
+		// given
+		header := http.Header{}
+		header.Set(httpretry.HeaderTraceparent, traceparent)
+		header.Set(httpretry.HeaderTracestate, "congo=t61rcWkgMzE")
+
+		// when
+		sc, ok := httpretry.TraceContext.Extract(header)
+		injected := http.Header{}
+		httpretry.TraceContext.Inject(injected, sc)
+
+		// then
+		assert.True(t, ok)
+		assert.True(t, sc.Sampled)
+		assert.Equal(t, traceparent, injected.Get(httpretry.HeaderTraceparent))
+		assert.Equal(t, "congo=t61rcWkgMzE", injected.Get(httpretry.HeaderTracestate))
+	})
+
+	t.Run("올바르지 않은 traceparent는 추출하지 않는 테스트", func(t *testing.T) {
+		for _, value := range []string{
+			"",
+			"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+			"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+			"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		} {
+			// given
+			header := http.Header{}
+			header.Set(httpretry.HeaderTraceparent, value)
+
+			// when
+			_, ok := httpretry.TraceContext.Extract(header)
+
+			// then
+			assert.False(t, ok, value)
+		}
+	})
+}
+
+func TestWithTracer(t *testing.T) {
+	t.Run("시도마다 span을 시작하고 시도 요청에 전파하는 테스트", func(t *testing.T) {
+		// given
+		var received []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = append(received, r.Header.Get(httpretry.HeaderTraceparent))
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		tracer := &attemptTracer{}
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithTracer(tracer),
+		))
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		req.Header.Set(httpretry.HeaderTraceparent, traceparent)
+
+		// when
+		client.Do(req)
+
+		// then
+		assert.Equal(t, []string{
+			"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000001-01",
+			"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000002-01",
+		}, received)
+		assert.Equal(t, traceparent, req.Header.Get(httpretry.HeaderTraceparent), "원본 요청 헤더는 변경하지 않음")
+		assert.Len(t, tracer.spans, 2)
+		assert.False(t, tracer.spans[0].Previous.IsValid())
+		assert.Equal(t, [8]byte{7: 1}, tracer.spans[1].Previous.SpanID)
+		assert.Len(t, tracer.ended, 2)
+		assert.Equal(t, http.StatusServiceUnavailable, tracer.ended[1].StatusCode)
+	})
+
+	t.Run("Tracer 없이 부모 추적 정보를 그대로 전파하는 테스트", func(t *testing.T) {
+		// given
+		var received []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = append(received, r.Header.Get(httpretry.HeaderTraceparent))
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(2),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithPropagators(httpretry.TraceContext),
+		))
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		req.Header.Set(httpretry.HeaderTraceparent, traceparent)
+
+		// when
+		client.Do(req)
+
+		// then
+		assert.Equal(t, []string{traceparent, traceparent}, received)
+	})
+}
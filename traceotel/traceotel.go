@@ -0,0 +1,100 @@
+// Package traceotel httpretry 시도마다 OpenTelemetry span을 시작하는 Tracer
+//
+// 시도 span은 요청 context의 span(없으면 요청 헤더의 traceparent) 아래에 생성되며,
+// 재시도 시도는 직전 시도 span을 link로 참조하고 부모 span에 재시도 이벤트를 남깁니다.
+//
+//	settings := httpretry.NewHTTPSettings(httpretry.WithTracer(traceotel.New(otel.GetTracerProvider())))
+package traceotel
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dings-things/httpretry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName 시도 span을 생성하는 Tracer의 이름
+const ScopeName = "github.com/dings-things/httpretry"
+
+type tracer struct {
+	tracer trace.Tracer
+}
+
+// New TracerProvider로 httpretry.Tracer를 생성
+//
+// Parameters:
+//   - provider: (trace.TracerProvider) span을 생성할 TracerProvider
+func New(provider trace.TracerProvider) httpretry.Tracer {
+	return &tracer{tracer: provider.Tracer(ScopeName)}
+}
+
+// StartAttempt 시도 span을 시작
+func (t *tracer) StartAttempt(ctx context.Context, span httpretry.AttemptSpan) (context.Context, httpretry.SpanContext, func(httpretry.RetryEvent)) {
+	if !trace.SpanContextFromContext(ctx).IsValid() && span.Parent.IsValid() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, toOtel(span.Parent))
+	}
+
+	req := span.Request
+	opts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Hostname()),
+			attribute.Int("http.request.resend_count", span.Attempt-1),
+		),
+	}
+	if span.Previous.IsValid() {
+		opts = append(opts, trace.WithLinks(trace.Link{
+			SpanContext: toOtel(span.Previous),
+			Attributes:  []attribute.KeyValue{attribute.String("httpretry.link", "previous_attempt")},
+		}))
+		trace.SpanFromContext(ctx).AddEvent("httpretry.retry", trace.WithAttributes(
+			attribute.Int("httpretry.attempt", span.Attempt),
+		))
+	}
+
+	ctx, attemptSpan := t.tracer.Start(ctx, req.Method, opts...)
+	return ctx, fromOtel(attemptSpan.SpanContext()), func(event httpretry.RetryEvent) {
+		attemptSpan.SetAttributes(attribute.String("url.full", event.URL))
+		if event.StatusCode > 0 {
+			attemptSpan.SetAttributes(attribute.Int("http.response.status_code", event.StatusCode))
+		}
+		switch {
+		case event.Err != nil:
+			attemptSpan.RecordError(event.Err)
+			attemptSpan.SetStatus(codes.Error, event.Err.Error())
+		case event.StatusCode >= http.StatusBadRequest:
+			attemptSpan.SetStatus(codes.Error, http.StatusText(event.StatusCode))
+		}
+		attemptSpan.End()
+	}
+}
+
+// toOtel httpretry.SpanContext를 OpenTelemetry SpanContext로 변환
+func toOtel(sc httpretry.SpanContext) trace.SpanContext {
+	var flags trace.TraceFlags
+	if sc.Sampled {
+		flags = trace.FlagsSampled
+	}
+	state, _ := trace.ParseTraceState(sc.TraceState)
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    sc.TraceID,
+		SpanID:     sc.SpanID,
+		TraceFlags: flags,
+		TraceState: state,
+		Remote:     true,
+	})
+}
+
+// fromOtel OpenTelemetry SpanContext를 httpretry.SpanContext로 변환
+func fromOtel(sc trace.SpanContext) httpretry.SpanContext {
+	return httpretry.SpanContext{
+		TraceID:    sc.TraceID(),
+		SpanID:     sc.SpanID(),
+		Sampled:    sc.IsSampled(),
+		TraceState: sc.TraceState().String(),
+	}
+}
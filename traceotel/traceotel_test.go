@@ -0,0 +1,87 @@
+package traceotel_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/dings-things/httpretry/traceotel"
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("시도 span을 부모 span 아래에 생성하고 직전 시도를 link로 참조하는 테스트", func(t *testing.T) {
+		// given
+		var received []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = append(received, r.Header.Get(httpretry.HeaderTraceparent))
+			if len(received) < 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		recorder := tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(3),
+			httpretry.WithBackoffPolicy(func(int) time.Duration { return 0 }),
+			httpretry.WithTracer(traceotel.New(provider)),
+		))
+		ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+		// when
+		resp, err := client.Do(req)
+		parent.End()
+
+		// then
+		assert.NoError(t, err)
+		resp.Body.Close()
+		spans := recorder.Ended()
+		assert.Len(t, spans, 3)
+		first, second, root := spans[0], spans[1], spans[2]
+		assert.Equal(t, parent.SpanContext().SpanID(), first.Parent().SpanID())
+		assert.Equal(t, parent.SpanContext().SpanID(), second.Parent().SpanID())
+		assert.Equal(t, trace.SpanKindClient, first.SpanKind())
+		assert.Len(t, second.Links(), 1)
+		assert.Equal(t, first.SpanContext().SpanID(), second.Links()[0].SpanContext.SpanID())
+		assert.Len(t, root.Events(), 1)
+		assert.Equal(t, "httpretry.retry", root.Events()[0].Name)
+		assert.Contains(t, received[0], first.SpanContext().SpanID().String())
+		assert.Contains(t, received[1], second.SpanContext().SpanID().String())
+	})
+
+	t.Run("context에 span이 없으면 traceparent 헤더를 부모로 사용하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		recorder := tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithTracer(traceotel.New(provider)),
+		))
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		req.Header.Set(httpretry.HeaderTraceparent, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+		// when
+		resp, err := client.Do(req)
+
+		// then
+		assert.NoError(t, err)
+		resp.Body.Close()
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+	})
+}
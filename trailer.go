@@ -0,0 +1,94 @@
+package httpretry
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// grpcStatusUnavailable gRPC UNAVAILABLE 상태 코드. 일시적인 장애로 재시도할 수 있음
+	grpcStatusUnavailable = 14
+	// grpcWebPeekLimit gRPC-Web 응답의 상태를 확인하기 위해 읽는 최대 바이트 수
+	grpcWebPeekLimit = 64 << 10
+	// grpcWebTrailerFlag gRPC-Web 바디에서 trailer 프레임을 나타내는 플래그
+	grpcWebTrailerFlag = 0x80
+)
+
+// TrailerInspector 응답 trailer 값으로 재시도 여부를 판단하는 BodyInspector
+//
+// 바디를 끝까지 읽은 경우에만 trailer를 받을 수 있으므로, BodyPeekLimit은 응답 바디보다 커야 합니다.
+// 바디 없이 헤더만 보내는 trailers-only 응답을 위해 같은 이름의 헤더도 확인합니다.
+//
+//	httpretry.WithBodyPeek(64<<10, httpretry.TrailerInspector("X-Status", "retry"))
+//
+// Parameters:
+//   - name: (string) trailer 이름
+//   - values: (...string) 재시도할 trailer 값
+func TrailerInspector(name string, values ...string) BodyInspector {
+	return func(resp *http.Response, _ []byte) bool {
+		value, ok := trailerValue(resp, name)
+		return ok && slices.Contains(values, value)
+	}
+}
+
+// GRPCStatusInspector gRPC 상태 코드(grpc-status)로 재시도 여부를 판단하는 BodyInspector
+//
+// HTTP trailer, trailers-only 응답의 헤더, gRPC-Web 바디의 trailer 프레임 순서로 상태 코드를 찾습니다.
+// grpc-web-text(base64) 응답은 지원하지 않습니다.
+//
+// Parameters:
+//   - codes: (...int) 재시도할 gRPC 상태 코드. 비어 있으면 UNAVAILABLE(14)
+func GRPCStatusInspector(codes ...int) BodyInspector {
+	if len(codes) == 0 {
+		codes = []int{grpcStatusUnavailable}
+	}
+	return func(resp *http.Response, peek []byte) bool {
+		value, ok := trailerValue(resp, "Grpc-Status")
+		if !ok {
+			value = grpcWebTrailer(peek).Get("Grpc-Status")
+		}
+		code, err := strconv.Atoi(value)
+		return err == nil && slices.Contains(codes, code)
+	}
+}
+
+// trailerValue 응답 trailer 값을 반환. trailer가 없으면 trailers-only 응답의 헤더 값을 반환
+func trailerValue(resp *http.Response, name string) (string, bool) {
+	if values := resp.Trailer.Values(name); len(values) > 0 {
+		return values[0], true
+	}
+	if values := resp.Header.Values(name); len(values) > 0 {
+		return values[0], true
+	}
+	return "", false
+}
+
+// grpcWebTrailer gRPC-Web 바디에서 trailer 프레임을 찾아 헤더로 반환
+//
+// 프레임은 1바이트 플래그, 4바이트 길이, 데이터로 구성되며, trailer 프레임의 데이터는 "key: value\r\n" 목록입니다.
+func grpcWebTrailer(body []byte) http.Header {
+	trailer := http.Header{}
+	for len(body) >= 5 {
+		flag, size := body[0], binary.BigEndian.Uint32(body[1:5])
+		if uint64(len(body)-5) < uint64(size) {
+			break
+		}
+		frame := body[5 : 5+size]
+		body = body[5+size:]
+		if flag&grpcWebTrailerFlag == 0 {
+			continue
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(frame))
+		for scanner.Scan() {
+			if key, value, ok := strings.Cut(scanner.Text(), ":"); ok {
+				trailer.Add(strings.TrimSpace(key), strings.TrimSpace(value))
+			}
+		}
+	}
+	return trailer
+}
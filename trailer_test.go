@@ -0,0 +1,178 @@
+package httpretry_test
+
+import (
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+// trailerBody EOF에서 요청 trailer 값을 설정하는 바디
+type trailerBody struct {
+	io.Reader
+	req *http.Request
+}
+
+func (b *trailerBody) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+	if err == io.EOF {
+		b.req.Trailer.Set("X-Checksum", "abc")
+	}
+	return n, err
+}
+
+// grpcWebFrame gRPC-Web 바디 프레임
+func grpcWebFrame(flag byte, data string) []byte {
+	frame := make([]byte, 5, 5+len(data))
+	frame[0] = flag
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(data)))
+	return append(frame, data...)
+}
+
+func TestTrailers(t *testing.T) {
+	t.Run("요청 trailer를 모든 시도에 전송하고 마지막 응답의 trailer를 반환하는 테스트", func(t *testing.T) {
+		// given
+		var received atomic.Int32
+		var trailers []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.ReadAll(r.Body)
+			trailers = append(trailers, r.Trailer.Get("X-Checksum"))
+			if received.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("Trailer", "X-Result")
+			w.Write([]byte("done"))
+			w.Header().Set("X-Result", "ok")
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(httpretry.WithBackoffPolicy(noBackoff)))
+		req, _ := http.NewRequest(http.MethodPost, server.URL, nil)
+		req.Body = io.NopCloser(&trailerBody{Reader: strings.NewReader("payload"), req: req})
+		req.ContentLength = -1
+		req.Trailer = http.Header{"X-Checksum": nil}
+
+		// when
+		resp, err := client.Do(req)
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "done", string(body))
+		assert.Equal(t, "ok", resp.Trailer.Get("X-Result"))
+		assert.Equal(t, []string{"abc", "abc"}, trailers)
+	})
+
+	t.Run("trailer 값으로 재시도하는 테스트", func(t *testing.T) {
+		// given
+		var received atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Trailer", "X-Status")
+			w.Write([]byte("0123456789"))
+			if received.Add(1) == 1 {
+				w.Header().Set("X-Status", "retry")
+			}
+		}))
+		defer server.Close()
+		// 바디가 peek 크기와 같아도 trailer를 받아야 함
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithBodyPeek(10, httpretry.TrailerInspector("X-Status", "retry")),
+		))
+
+		// when
+		resp, err := client.Get(server.URL)
+
+		// then
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, int32(2), received.Load())
+	})
+}
+
+func TestWithGRPCWebRetry(t *testing.T) {
+	t.Run("HTTP trailer의 grpc-status로 재시도하는 테스트", func(t *testing.T) {
+		// given
+		var received atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Trailer", "Grpc-Status")
+			w.Write(grpcWebFrame(0, "message"))
+			if received.Add(1) == 1 {
+				w.Header().Set("Grpc-Status", "14")
+				return
+			}
+			w.Header().Set("Grpc-Status", "0")
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithGRPCWebRetry(),
+		))
+
+		// when
+		resp, err := client.Get(server.URL)
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		io.ReadAll(resp.Body)
+		assert.Equal(t, int32(2), received.Load())
+		assert.Equal(t, "0", resp.Trailer.Get("Grpc-Status"))
+	})
+
+	t.Run("gRPC-Web 바디의 trailer 프레임으로 재시도하는 테스트", func(t *testing.T) {
+		// given
+		var received atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/grpc-web+proto")
+			w.Write(grpcWebFrame(0, "message"))
+			if received.Add(1) < 3 {
+				w.Write(grpcWebFrame(0x80, "grpc-status: 14\r\ngrpc-message: unavailable\r\n"))
+				return
+			}
+			w.Write(grpcWebFrame(0x80, "grpc-status: 0\r\n"))
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithGRPCWebRetry(),
+		))
+
+		// when
+		resp, err := client.Get(server.URL)
+
+		// then
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, int32(3), received.Load())
+	})
+
+	t.Run("trailers-only 응답의 재시도하지 않는 상태 코드는 그대로 반환하는 테스트", func(t *testing.T) {
+		// given
+		var received atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received.Add(1)
+			w.Header().Set("Grpc-Status", "5")
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithGRPCWebRetry(),
+		))
+
+		// when
+		resp, err := client.Get(server.URL)
+
+		// then
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, int32(1), received.Load())
+	})
+}
@@ -0,0 +1,194 @@
+package httpretry_test
+
+import (
+	"compress/gzip"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTLSConfig(t *testing.T) {
+	t.Run("지정한 TLS 설정으로 연결하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+		roots := x509.NewCertPool()
+		roots.AddCert(server.Certificate())
+		var verified atomic.Int32
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithTLSConfig(&tls.Config{
+				RootCAs: roots,
+				VerifyPeerCertificate: func([][]byte, [][]*x509.Certificate) error {
+					verified.Add(1)
+					return nil
+				},
+			}),
+		))
+
+		// when
+		resp, err := client.Get(server.URL)
+
+		// then
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, int32(1), verified.Load())
+	})
+
+	t.Run("TLS 설정을 지정하면 Insecure를 적용하지 않는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(1),
+			httpretry.WithInsecure(true),
+			httpretry.WithTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12}),
+		))
+
+		// when
+		_, err := client.Get(server.URL)
+
+		// then
+		var certErr *tls.CertificateVerificationError
+		assert.ErrorAs(t, err, &certErr)
+	})
+}
+
+func TestWithTransportTweak(t *testing.T) {
+	t.Run("설정을 적용한 기본 Transport를 변경하는 테스트", func(t *testing.T) {
+		// given
+		var header http.Header
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header
+		}))
+		defer server.Close()
+		var timeout time.Duration
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithResponseHeaderTimeout(3*time.Second),
+			httpretry.WithTransportTweak(func(t *http.Transport) {
+				timeout = t.ResponseHeaderTimeout
+				t.DisableKeepAlives = true
+			}),
+		))
+
+		// when
+		resp, err := client.Get(server.URL)
+
+		// then
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, 3*time.Second, timeout)
+		assert.Equal(t, "close", header.Get("Connection"))
+	})
+
+	t.Run("다른 tweak으로 복제하면 에러를 반환하는 테스트", func(t *testing.T) {
+		// given
+		client := httpretry.NewClient(httpretry.NewHTTPSettings())
+
+		// when
+		_, err := httpretry.CloneWith(client, httpretry.WithTransportTweak(func(*http.Transport) {}))
+
+		// then
+		assert.ErrorContains(t, err, "TransportTweaks")
+	})
+}
+
+func TestWithDisableKeepAlives(t *testing.T) {
+	t.Run("재시도마다 새 커넥션으로 연결하는 테스트", func(t *testing.T) {
+		// given
+		remotes := map[string]struct{}{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			remotes[r.RemoteAddr] = struct{}{}
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(3),
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithDisableKeepAlives(true),
+		))
+
+		// when
+		_, err := client.Get(server.URL)
+
+		// then
+		assert.Error(t, err)
+		assert.Len(t, remotes, 3)
+	})
+}
+
+func TestWithDisableCompression(t *testing.T) {
+	t.Run("압축된 바디를 그대로 반환하는 테스트", func(t *testing.T) {
+		// given
+		var acceptEncoding string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			acceptEncoding = r.Header.Get("Accept-Encoding")
+			w.Header().Set("Content-Encoding", "gzip")
+			gz := gzip.NewWriter(w)
+			gz.Write([]byte("hello"))
+			gz.Close()
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(httpretry.WithDisableCompression(true)))
+
+		// when
+		resp, err := client.Get(server.URL)
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Empty(t, acceptEncoding)
+		assert.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+		reader, err := gzip.NewReader(resp.Body)
+		assert.NoError(t, err)
+		plain, _ := io.ReadAll(reader)
+		assert.Equal(t, "hello", string(plain))
+	})
+}
+
+func TestWithBufferSizes(t *testing.T) {
+	t.Run("기본 Transport에 버퍼 크기를 적용하는 테스트", func(t *testing.T) {
+		// given
+		var read, write int
+
+		// when
+		httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithBufferSizes(64<<10, 32<<10),
+			httpretry.WithTransportTweak(func(t *http.Transport) {
+				read, write = t.ReadBufferSize, t.WriteBufferSize
+			}),
+		))
+
+		// then
+		assert.Equal(t, 64<<10, read)
+		assert.Equal(t, 32<<10, write)
+	})
+}
+
+func TestWithMaxResponseHeaderBytes(t *testing.T) {
+	t.Run("제한을 넘는 응답 헤더를 거절하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Large", strings.Repeat("a", 8<<10))
+		}))
+		defer server.Close()
+		client := httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(1),
+			httpretry.WithMaxResponseHeaderBytes(1<<10),
+		))
+
+		// when
+		_, err := client.Get(server.URL)
+
+		// then
+		assert.ErrorContains(t, err, "server response headers exceeded 1024 bytes")
+	})
+}
@@ -0,0 +1,245 @@
+// Package upload httpretry 클라이언트로 tus 프로토콜(v1.0.0) 기반 이어 올리기(resumable upload)를 수행합니다
+//
+// 업로드는 청크 단위 PATCH 요청으로 전송되며, 각 요청은 재시도 클라이언트의 재시도 정책을 따릅니다.
+// 재시도로도 복구하지 못한 실패나 오프셋 충돌(409)이 발생하면 HEAD 요청으로 서버에 저장된 오프셋을 조회하여
+// 처음부터 다시 올리지 않고 그 위치부터 이어서 전송합니다.
+package upload
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dings-things/httpretry"
+)
+
+const (
+	// TusVersion 지원하는 tus 프로토콜 버전
+	TusVersion = "1.0.0"
+	// DefaultChunkSize 기본 청크 크기 (8MiB)
+	DefaultChunkSize = 8 << 20
+	// DefaultMaxResumes 업로드 1건에서 오프셋을 다시 조회하여 이어 올리는 최대 횟수
+	DefaultMaxResumes = 5
+)
+
+// ErrUploadExpired 서버에서 업로드가 만료되거나 삭제된 경우 (404, 410)
+var ErrUploadExpired = errors.New("upload: upload expired or not found")
+
+type (
+	// Option Uploader 설정 함수
+	Option func(*Uploader)
+
+	// Uploader tus 업로드를 수행
+	Uploader struct {
+		client     httpretry.Doer
+		chunkSize  int64
+		maxResumes int
+	}
+)
+
+// WithChunkSize PATCH 요청 1회에 전송할 크기를 설정하는 Option
+//
+// Parameters:
+//   - size: (int64) 청크 크기 (bytes)
+func WithChunkSize(size int64) Option {
+	return func(u *Uploader) {
+		u.chunkSize = size
+	}
+}
+
+// WithMaxResumes 오프셋을 다시 조회하여 이어 올리는 최대 횟수를 설정하는 Option
+//
+// Parameters:
+//   - resumes: (int) 최대 횟수
+func WithMaxResumes(resumes int) Option {
+	return func(u *Uploader) {
+		u.maxResumes = resumes
+	}
+}
+
+// New Uploader를 생성
+//
+// Parameters:
+//   - client: (httpretry.Doer) 요청에 사용할 클라이언트. 보통 httpretry.NewClient로 생성한 클라이언트
+//   - opts: (...Option) 업로드 설정
+func New(client httpretry.Doer, opts ...Option) *Uploader {
+	u := &Uploader{
+		client:     client,
+		chunkSize:  DefaultChunkSize,
+		maxResumes: DefaultMaxResumes,
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	if u.chunkSize <= 0 {
+		u.chunkSize = DefaultChunkSize
+	}
+	return u
+}
+
+// Create 업로드를 생성하고 업로드 URL을 반환
+//
+// 반환된 URL을 보관해 두면 프로세스가 재시작된 뒤에도 Upload로 이어서 올릴 수 있습니다.
+//
+// Parameters:
+//   - ctx: (context.Context) 요청 context
+//   - endpoint: (string) tus 업로드 생성 엔드포인트
+//   - size: (int64) 업로드할 전체 크기
+//   - metadata: (map[string]string) Upload-Metadata 헤더로 전송할 메타데이터 (filename 등)
+func (u *Uploader) Create(ctx context.Context, endpoint string, size int64, metadata map[string]string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("upload: creating request: %w", err)
+	}
+	req.Header.Set("Tus-Resumable", TusVersion)
+	req.Header.Set("Upload-Length", strconv.FormatInt(size, 10))
+	if len(metadata) > 0 {
+		req.Header.Set("Upload-Metadata", encodeMetadata(metadata))
+	}
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("upload: unexpected status %d creating upload", resp.StatusCode)
+	}
+
+	// 상대 경로 Location은 요청 URL 기준으로 변환
+	location, err := resp.Location()
+	if err != nil {
+		return "", fmt.Errorf("upload: reading upload location: %w", err)
+	}
+	return location.String(), nil
+}
+
+// Upload src를 업로드 URL로 전송
+//
+// 서버에 저장된 오프셋부터 전송을 시작하므로, 중단된 업로드를 같은 URL로 다시 호출하면 이어서 올립니다.
+//
+// Parameters:
+//   - ctx: (context.Context) 요청 context
+//   - location: (string) Create로 생성한 업로드 URL
+//   - src: (io.ReaderAt) 업로드할 데이터. 이어 올리기를 위해 임의 위치를 읽을 수 있어야 함 (*os.File 등)
+//   - size: (int64) 업로드할 전체 크기
+func (u *Uploader) Upload(ctx context.Context, location string, src io.ReaderAt, size int64) error {
+	offset, err := u.offset(ctx, location)
+	if err != nil {
+		return err
+	}
+
+	resumes := 0
+	for offset < size {
+		next, err := u.patch(ctx, location, src, offset, min(u.chunkSize, size-offset))
+		if err == nil {
+			offset = next
+			continue
+		}
+		if errors.Is(err, ErrUploadExpired) || ctx.Err() != nil {
+			return err
+		}
+
+		// 재시도로 복구하지 못한 실패는 서버에 저장된 오프셋을 다시 조회하여 이어서 전송
+		resumes++
+		if resumes > u.maxResumes {
+			return fmt.Errorf("upload: giving up after %d resumes: %w", u.maxResumes, err)
+		}
+		if offset, err = u.offset(ctx, location); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// offset HEAD 요청으로 서버에 저장된 업로드 오프셋을 조회
+func (u *Uploader) offset(ctx context.Context, location string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, location, nil)
+	if err != nil {
+		return 0, fmt.Errorf("upload: creating request: %w", err)
+	}
+	req.Header.Set("Tus-Resumable", TusVersion)
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	if err := checkStatus(resp, http.StatusOK, http.StatusNoContent); err != nil {
+		return 0, err
+	}
+	return parseOffset(resp)
+}
+
+// patch offset부터 length 바이트를 전송하고, 서버가 응답한 다음 오프셋을 반환
+//
+// 바디는 src에서 다시 생성할 수 있으므로 재시도 클라이언트가 같은 청크를 다시 전송합니다.
+func (u *Uploader) patch(ctx context.Context, location string, src io.ReaderAt, offset, length int64) (int64, error) {
+	newBody := func() (io.ReadCloser, error) {
+		return io.NopCloser(io.NewSectionReader(src, offset, length)), nil
+	}
+	body, _ := newBody()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, location, body)
+	if err != nil {
+		return 0, fmt.Errorf("upload: creating request: %w", err)
+	}
+	req.GetBody = newBody
+	req.ContentLength = length
+	req.Header.Set("Tus-Resumable", TusVersion)
+	req.Header.Set("Upload-Offset", strconv.FormatInt(offset, 10))
+	req.Header.Set("Content-Type", "application/offset+octet-stream")
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	if err := checkStatus(resp, http.StatusNoContent, http.StatusOK); err != nil {
+		return 0, err
+	}
+	next, err := parseOffset(resp)
+	if err != nil {
+		return 0, err
+	}
+	if next <= offset {
+		return 0, fmt.Errorf("upload: server did not advance offset %d", offset)
+	}
+	return next, nil
+}
+
+// checkStatus 응답 상태 코드를 확인. 만료된 업로드는 ErrUploadExpired를 반환
+func checkStatus(resp *http.Response, expected ...int) error {
+	switch {
+	case slices.Contains(expected, resp.StatusCode):
+		return nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrUploadExpired
+	default:
+		return fmt.Errorf("upload: unexpected status %d", resp.StatusCode)
+	}
+}
+
+// parseOffset 응답의 Upload-Offset 헤더를 읽음
+func parseOffset(resp *http.Response) (int64, error) {
+	offset, err := strconv.ParseInt(resp.Header.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("upload: invalid Upload-Offset %q", resp.Header.Get("Upload-Offset"))
+	}
+	return offset, nil
+}
+
+// encodeMetadata Upload-Metadata 헤더 형식(key base64(value), ...)으로 인코딩
+func encodeMetadata(metadata map[string]string) string {
+	pairs := make([]string, 0, len(metadata))
+	for key, value := range metadata {
+		pairs = append(pairs, key+" "+base64.StdEncoding.EncodeToString([]byte(value)))
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,120 @@
+package upload_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/dings-things/httpretry/upload"
+	"github.com/stretchr/testify/assert"
+)
+
+// tusServer 테스트용 tus 서버. failAt 번째 PATCH는 바디 절반만 저장하고 500 응답
+type tusServer struct {
+	mu       sync.Mutex
+	data     []byte
+	length   int64
+	metadata string
+	patches  int
+	heads    int
+	failAt   int
+}
+
+func (s *tusServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Tus-Resumable", upload.TusVersion)
+	switch r.Method {
+	case http.MethodPost:
+		s.length, _ = strconv.ParseInt(r.Header.Get("Upload-Length"), 10, 64)
+		s.metadata = r.Header.Get("Upload-Metadata")
+		w.Header().Set("Location", "/files/1")
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodHead:
+		s.heads++
+		w.Header().Set("Upload-Offset", strconv.Itoa(len(s.data)))
+		w.Header().Set("Upload-Length", strconv.FormatInt(s.length, 10))
+	case http.MethodPatch:
+		s.patches++
+		if r.Header.Get("Upload-Offset") != strconv.Itoa(len(s.data)) {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		if s.patches == s.failAt {
+			s.data = append(s.data, body[:len(body)/2]...)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		s.data = append(s.data, body...)
+		w.Header().Set("Upload-Offset", strconv.Itoa(len(s.data)))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestUploader(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+	ctx := context.Background()
+
+	t.Run("전송이 중간에 실패하면 서버의 오프셋을 조회하여 이어서 올리는 테스트", func(t *testing.T) {
+		// given
+		tus := &tusServer{failAt: 2}
+		server := httptest.NewServer(tus)
+		defer server.Close()
+		uploader := upload.New(
+			httpretry.NewClient(httpretry.NewHTTPSettings(
+				httpretry.WithBackoffPolicy(func(int) time.Duration { return 0 }),
+			)),
+			upload.WithChunkSize(4000),
+		)
+
+		// when
+		location, createErr := uploader.Create(ctx, server.URL+"/files", int64(len(content)), map[string]string{"filename": "a.txt"})
+		err := uploader.Upload(ctx, location, bytes.NewReader(content), int64(len(content)))
+
+		// then
+		assert.NoError(t, createErr)
+		assert.Equal(t, server.URL+"/files/1", location)
+		assert.Equal(t, "filename YS50eHQ=", tus.metadata)
+		assert.NoError(t, err)
+		assert.Equal(t, content, tus.data)
+		assert.Equal(t, 2, tus.heads, "시작할 때와 실패 후 이어 올릴 때 오프셋을 조회해야 합니다.")
+	})
+
+	t.Run("중단된 업로드를 다시 호출하면 저장된 오프셋부터 올리는 테스트", func(t *testing.T) {
+		// given
+		tus := &tusServer{data: content[:3000], length: int64(len(content))}
+		server := httptest.NewServer(tus)
+		defer server.Close()
+		uploader := upload.New(http.DefaultClient, upload.WithChunkSize(4000))
+
+		// when
+		err := uploader.Upload(ctx, server.URL+"/files/1", bytes.NewReader(content), int64(len(content)))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, content, tus.data)
+		assert.Equal(t, 2, tus.patches)
+	})
+
+	t.Run("만료된 업로드는 ErrUploadExpired를 반환하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+		uploader := upload.New(http.DefaultClient)
+
+		// when
+		err := uploader.Upload(ctx, server.URL+"/files/1", bytes.NewReader(content), int64(len(content)))
+
+		// then
+		assert.True(t, errors.Is(err, upload.ErrUploadExpired))
+	})
+}
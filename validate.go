@@ -0,0 +1,201 @@
+package httpretry
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// ErrInvalidSettings 설정이 유효하지 않음을 나타내는 에러
+//
+// Settings.Validate가 반환하는 모든 SettingsError는 errors.Is(err, ErrInvalidSettings)로 확인할 수 있습니다.
+var ErrInvalidSettings = errors.New("invalid settings")
+
+// SettingsError 유효하지 않은 설정 필드와 그 이유
+type SettingsError struct {
+	// Field 설정 필드 이름 (HostPolicies["api.example.com"].MaxRetry 등)
+	Field string
+	// Reason 유효하지 않은 이유와 올바른 값
+	Reason string
+}
+
+// Error 필드와 이유를 포함한 메시지를 반환
+func (e *SettingsError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", ErrInvalidSettings, e.Field, e.Reason)
+}
+
+// Is ErrInvalidSettings와 같은 에러로 취급
+func (e *SettingsError) Is(target error) bool {
+	return target == ErrInvalidSettings
+}
+
+// Validate 요청 시점에 알 수 없는 방식으로 실패하게 될 설정을 검사
+//
+// 음수 재시도 횟수, 0인 타임아웃, 최소값보다 작은 최대값, 비어 있는 엔드포인트 목록 등
+// 유효하지 않은 모든 필드를 SettingsError로 모아 반환합니다. 유효한 경우 nil을 반환합니다.
+//
+//	if err := settings.Validate(); err != nil {
+//		log.Fatal(err) // 필드마다 한 줄: invalid settings: MaxRetry: must be at least 1 (total attempts), got 0
+//	}
+func (s *Settings) Validate() error {
+	v := &validator{}
+
+	v.check(s.MaxRetry >= 1, "MaxRetry", "must be at least 1 (total attempts), got %d", s.MaxRetry)
+	v.check(s.AttemptTimeout >= 0, "AttemptTimeout", "must not be negative, got %s", s.AttemptTimeout)
+	if s.AttemptTimeout == 0 {
+		v.check(s.RequestTimeout > 0, "RequestTimeout", "must be positive when AttemptTimeout is not set, got %s", s.RequestTimeout)
+	}
+	v.nonNegative("TotalTimeout", s.TotalTimeout)
+	v.nonNegative("IdleConnTimeout", s.IdleConnTimeout)
+	v.nonNegative("TLSHandshakeTimeout", s.TLSHandshakeTimeout)
+	v.nonNegative("ExpectContinueTimeout", s.ExpectContinueTimeout)
+	v.nonNegative("ResponseHeaderTimeout", s.ResponseHeaderTimeout)
+	v.check(s.MaxIdleConns >= 0, "MaxIdleConns", "must not be negative, got %d", s.MaxIdleConns)
+	v.check(s.ReadBufferSize >= 0, "ReadBufferSize", "must not be negative (0 for 4KB), got %d", s.ReadBufferSize)
+	v.check(s.WriteBufferSize >= 0, "WriteBufferSize", "must not be negative (0 for 4KB), got %d", s.WriteBufferSize)
+	v.check(s.MaxResponseHeaderBytes >= 0, "MaxResponseHeaderBytes", "must not be negative (0 for 1MB), got %d", s.MaxResponseHeaderBytes)
+
+	v.check(s.CircuitBreakerThreshold >= 0, "CircuitBreakerThreshold", "must not be negative (0 disables the breaker), got %d", s.CircuitBreakerThreshold)
+	if s.CircuitBreakerThreshold > 0 {
+		v.check(s.CircuitBreakerCooldown > 0, "CircuitBreakerCooldown", "must be positive when the breaker is enabled, got %s", s.CircuitBreakerCooldown)
+	}
+	v.check(s.CircuitProbePolicy.MaxProbes >= 0, "CircuitProbePolicy.MaxProbes", "must not be negative, got %d", s.CircuitProbePolicy.MaxProbes)
+	v.check(s.CircuitProbePolicy.SuccessThreshold >= 0, "CircuitProbePolicy.SuccessThreshold", "must not be negative, got %d", s.CircuitProbePolicy.SuccessThreshold)
+
+	v.nonNegative("MaxRetryAfter", s.MaxRetryAfter)
+	v.nonNegative("RateLimitJitterMin", s.RateLimitJitterMin)
+	v.nonNegative("RateLimitJitterMax", s.RateLimitJitterMax)
+	if s.RateLimitJitterMax > 0 {
+		v.check(s.RateLimitJitterMax >= s.RateLimitJitterMin, "RateLimitJitterMax",
+			"must not be below RateLimitJitterMin (%s), got %s", s.RateLimitJitterMin, s.RateLimitJitterMax)
+	}
+
+	oneOf(v, "RetryAfterPolicy", s.RetryAfterPolicy, RetryAfterClamp, RetryAfterFailFast)
+	oneOf(v, "DeadlinePolicy", s.DeadlinePolicy, DeadlineFailFast, DeadlineTruncate)
+	oneOf(v, "RetryConnectionPolicy", s.RetryConnectionPolicy,
+		RetryConnectionReuse, RetryConnectionFresh, RetryConnectionFreshAfterNetworkError)
+	oneOf(v, "ErrorAggregation", s.ErrorAggregation, ErrorAggregationAll, ErrorAggregationLast, ErrorAggregationSummary)
+	oneOf(v, "Locale", s.Locale, LocaleEnglish, LocaleKorean)
+	oneOf(v, "IPPreference", s.IPPreference, DualStack, IPv4Only, IPv6Only)
+
+	for host, policy := range s.HostPolicies {
+		v.check(policy.MaxRetry >= 1, fmt.Sprintf("HostPolicies[%q].MaxRetry", host), "must be at least 1 (total attempts), got %d", policy.MaxRetry)
+	}
+	for i, route := range s.RoutePolicies {
+		field := fmt.Sprintf("RoutePolicies[%d]", i)
+		v.check(route.Policy.MaxRetry >= 1, field+".Policy.MaxRetry", "must be at least 1 (total attempts), got %d", route.Policy.MaxRetry)
+		if err := validPattern(route.Pattern); err != nil {
+			v.add(field+".Pattern", "must be a valid http.ServeMux pattern: %v", err)
+		}
+	}
+	for name, bulkhead := range s.Bulkheads {
+		v.check(bulkhead.MaxConcurrent >= 1, fmt.Sprintf("Bulkheads[%q].MaxConcurrent", name), "must be at least 1, got %d", bulkhead.MaxConcurrent)
+		v.nonNegative(fmt.Sprintf("Bulkheads[%q].MaxWait", name), bulkhead.MaxWait)
+	}
+
+	for i, proxy := range s.Proxies {
+		if _, err := url.Parse(proxy); err != nil || proxy == "" {
+			v.add(fmt.Sprintf("Proxies[%d]", i), "must be a proxy URL, got %q", proxy)
+		}
+	}
+	v.check(s.WarmupConnections >= 0, "WarmupConnections", "must not be negative, got %d", s.WarmupConnections)
+	v.check(s.ProxyFailureThreshold >= 0, "ProxyFailureThreshold", "must not be negative, got %d", s.ProxyFailureThreshold)
+
+	v.percent("MirrorPercent", s.MirrorPercent)
+	if s.MirrorPercent > 0 {
+		v.check(s.MirrorURL != "", "MirrorURL", "must be set when MirrorPercent is %g", s.MirrorPercent)
+	}
+	v.percent("CanaryPercent", s.CanaryPercent)
+	if s.CanaryPercent > 0 {
+		v.check(s.CanaryStableURL != "", "CanaryStableURL", "must be set when CanaryPercent is %g", s.CanaryPercent)
+		v.check(s.CanaryURL != "", "CanaryURL", "must be set when CanaryPercent is %g", s.CanaryPercent)
+	}
+	if s.EndpointService != "" {
+		v.check(len(s.Endpoints) > 0, "Endpoints", "must list at least one endpoint for EndpointService %q", s.EndpointService)
+	} else {
+		v.check(len(s.Endpoints) == 0, "EndpointService", "must be set when Endpoints are listed")
+	}
+	for i, endpoint := range s.Endpoints {
+		v.check(endpoint != "", fmt.Sprintf("Endpoints[%d]", i), "must not be empty")
+	}
+
+	v.check(s.BodyPeekLimit >= 0, "BodyPeekLimit", "must not be negative, got %d", s.BodyPeekLimit)
+	v.check(s.MaxReplayableBodySize >= 0, "MaxReplayableBodySize", "must not be negative (0 for no limit), got %d", s.MaxReplayableBodySize)
+	v.check(s.BodySpillThreshold >= 0, "BodySpillThreshold", "must not be negative (0 buffers in memory), got %d", s.BodySpillThreshold)
+	v.check(s.CaptureAttempts >= 0, "CaptureAttempts", "must not be negative (0 disables capturing), got %d", s.CaptureAttempts)
+	v.nonNegative("CacheDefaultTTL", s.CacheDefaultTTL)
+	v.check(s.CacheMaxEntrySize >= 0, "CacheMaxEntrySize", "must not be negative (0 for no limit), got %d", s.CacheMaxEntrySize)
+	v.check(s.DebugSampleRate >= 0, "DebugSampleRate", "must not be negative, got %d", s.DebugSampleRate)
+	v.check(s.EventBufferSize >= 0, "EventBufferSize", "must not be negative, got %d", s.EventBufferSize)
+	if s.Guardrails != nil {
+		oneOf(v, "Guardrails.Mode", s.Guardrails.Mode, GuardrailClamp, GuardrailReject)
+		s.Guardrails.check(s, v)
+	}
+
+	return errors.Join(v.errs...)
+}
+
+// NewValidated 설정을 검사한 뒤 종료 기능을 제공하는 재시도 클라이언트를 생성
+//
+// 설정이 유효하지 않으면 클라이언트를 생성하지 않고 Settings.Validate의 에러를 반환합니다.
+//
+// Parameters:
+//   - settings: (*Settings) 재시도 설정. nil인 경우 기본 설정을 사용
+//   - retryStatusCodes: (...int) 기본 재시도 상태 코드에 추가할 상태 코드
+func NewValidated(settings *Settings, retryStatusCodes ...int) (*Client, error) {
+	if settings == nil {
+		settings = NewHTTPSettings()
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	return New(settings, retryStatusCodes...), nil
+}
+
+// validator 유효하지 않은 필드를 모으는 검사기
+type validator struct {
+	errs []error
+}
+
+// add 유효하지 않은 필드를 추가
+func (v *validator) add(field, format string, args ...any) {
+	v.errs = append(v.errs, &SettingsError{Field: field, Reason: fmt.Sprintf(format, args...)})
+}
+
+// check 조건을 만족하지 않으면 유효하지 않은 필드를 추가
+func (v *validator) check(ok bool, field, format string, args ...any) {
+	if !ok {
+		v.add(field, format, args...)
+	}
+}
+
+// nonNegative 음수 시간을 검사
+func (v *validator) nonNegative(field string, d time.Duration) {
+	v.check(d >= 0, field, "must not be negative, got %s", d)
+}
+
+// percent 0~100 범위의 비율을 검사
+func (v *validator) percent(field string, p float64) {
+	v.check(p >= 0 && p <= 100, field, "must be between 0 and 100, got %g", p)
+}
+
+// oneOf 허용된 값인지 검사. 빈 값은 기본값으로 취급
+func oneOf[T ~string](v *validator, field string, value T, allowed ...T) {
+	if value != "" && !slices.Contains(allowed, value) {
+		v.add(field, "must be one of %q, got %q", allowed, value)
+	}
+}
+
+// validPattern http.ServeMux에 등록할 수 있는 패턴인지 검사
+func validPattern(pattern string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	http.NewServeMux().Handle(pattern, http.NotFoundHandler())
+	return nil
+}
@@ -0,0 +1,86 @@
+package httpretry_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettings_Validate(t *testing.T) {
+	t.Run("기본 설정은 유효한 테스트", func(t *testing.T) {
+		// given
+		settings := httpretry.NewHTTPSettings()
+
+		// when
+		err := settings.Validate()
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("유효하지 않은 모든 필드를 모아 반환하는 테스트", func(t *testing.T) {
+		// given
+		settings := httpretry.NewHTTPSettings(
+			httpretry.WithMaxRetry(-1),
+			httpretry.WithRequestTimeout(0),
+			httpretry.WithRateLimitJitter(2*time.Second, time.Second),
+			httpretry.WithEndpoints("http://orders"),
+			httpretry.WithMirror("", 150),
+		)
+
+		// when
+		err := settings.Validate()
+
+		// then
+		assert.True(t, errors.Is(err, httpretry.ErrInvalidSettings))
+		var fields []string
+		for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+			var settingsErr *httpretry.SettingsError
+			assert.True(t, errors.As(err, &settingsErr))
+			fields = append(fields, settingsErr.Field)
+		}
+		assert.Equal(t, []string{"MaxRetry", "RequestTimeout", "RateLimitJitterMax", "MirrorPercent", "MirrorURL", "Endpoints"}, fields)
+		assert.Contains(t, err.Error(), "invalid settings: MaxRetry: must be at least 1 (total attempts), got -1")
+	})
+
+	t.Run("정책별 재시도 횟수와 패턴을 검사하는 테스트", func(t *testing.T) {
+		// given
+		settings := httpretry.NewHTTPSettings(
+			httpretry.WithRoutePolicy("GET /v1/{id", httpretry.Policy{MaxRetry: 0}),
+		)
+
+		// when
+		err := settings.Validate()
+
+		// then
+		assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 2)
+		assert.ErrorContains(t, err, "RoutePolicies[0].Policy.MaxRetry")
+		assert.ErrorContains(t, err, "RoutePolicies[0].Pattern: must be a valid http.ServeMux pattern")
+	})
+}
+
+func TestNewValidated(t *testing.T) {
+	t.Run("유효하지 않은 설정으로는 클라이언트를 생성하지 않는 테스트", func(t *testing.T) {
+		// given
+		settings := httpretry.NewHTTPSettings(httpretry.WithMaxRetry(0))
+
+		// when
+		client, err := httpretry.NewValidated(settings)
+
+		// then
+		assert.Nil(t, client)
+		assert.ErrorIs(t, err, httpretry.ErrInvalidSettings)
+	})
+
+	t.Run("유효한 설정으로 클라이언트를 생성하는 테스트", func(t *testing.T) {
+		// when
+		client, err := httpretry.NewValidated(nil)
+
+		// then
+		assert.NoError(t, err)
+		assert.NotNil(t, client)
+	})
+}
@@ -0,0 +1,66 @@
+package httpretry
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// startWarmup 설정된 호스트에 미리 커넥션을 생성
+//
+// 클라이언트 생성을 지연시키지 않도록 백그라운드에서 수행하며, 실패는 무시합니다.
+func startWarmup(transport http.RoundTripper, settings *Settings) {
+	if len(settings.WarmupHosts) == 0 {
+		return
+	}
+	connections := max(settings.WarmupConnections, 1)
+	timeout := settings.attemptTimeout()
+	logger := newLogger(settings)
+	hosts := append([]string(nil), settings.WarmupHosts...)
+
+	go func() {
+		var wg sync.WaitGroup
+		for _, host := range hosts {
+			target := warmupURL(host)
+			// 동시에 요청해야 커넥션이 재사용되지 않고 새로 생성됨
+			for range connections {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if err := warmup(transport, target, timeout); err != nil {
+						logger.Debug("warmup failed", "host", host, "error", err)
+					}
+				}()
+			}
+		}
+		wg.Wait()
+	}()
+}
+
+// warmup HEAD 요청으로 커넥션을 생성(TLS 핸드셰이크 포함)하고 커넥션 풀에 반환
+func warmup(transport http.RoundTripper, target string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, target, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
+
+// warmupURL 호스트를 요청 URL로 변환. scheme이 없으면 https를 사용
+func warmupURL(host string) string {
+	if strings.Contains(host, "://") {
+		return host
+	}
+	return "https://" + host + "/"
+}
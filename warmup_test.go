@@ -0,0 +1,60 @@
+package httpretry_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithWarmup(t *testing.T) {
+	t.Run("클라이언트 생성 시 커넥션을 미리 생성하고, 이후 요청에서 재사용하는 테스트", func(t *testing.T) {
+		// given
+		var connCount atomic.Int32
+		testServer := httptest.NewUnstartedServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(10 * time.Millisecond)
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+		testServer.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+			if state == http.StateNew {
+				connCount.Add(1)
+			}
+		}
+		testServer.Start()
+		defer testServer.Close()
+
+		// when
+		client := httpretry.NewClient(
+			httpretry.NewHTTPSettings(
+				httpretry.WithWarmup(testServer.URL),
+				httpretry.WithWarmupConnections(3),
+			),
+		)
+
+		// then
+		assert.Eventually(t, func() bool { return connCount.Load() == 3 }, time.Second, 5*time.Millisecond)
+		time.Sleep(50 * time.Millisecond) // 커넥션이 풀에 반환될 때까지 대기
+
+		var wg sync.WaitGroup
+		for range 3 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				resp, err := client.Get(testServer.URL)
+				if assert.NoError(t, err) {
+					resp.Body.Close()
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(3), connCount.Load(), "미리 생성한 커넥션을 재사용해야 합니다.")
+	})
+}
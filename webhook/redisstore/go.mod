@@ -0,0 +1,22 @@
+module github.com/dings-things/httpretry/webhook/redisstore
+
+go 1.24
+
+replace github.com/dings-things/httpretry => ../..
+
+require (
+	github.com/alicebob/miniredis/v2 v2.34.0
+	github.com/dings-things/httpretry v0.0.0-00010101000000-000000000000
+	github.com/redis/go-redis/v9 v9.7.3
+	github.com/stretchr/testify v1.9.0
+)
+
+require (
+	github.com/alicebob/gopher-json v0.0.0-20230218143504-906a9b012302 // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/yuin/gopher-lua v1.1.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,121 @@
+// Package redisstore webhook 전송 대기열을 Redis에 보관하는 webhook.Storage 구현을 제공합니다
+//
+// 같은 Redis와 prefix를 사용하는 replica들은 재전송 상태를 공유하며, 한 전송은 lease 동안 한 replica만 가져갑니다.
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dings-things/httpretry/webhook"
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultPrefix 기본 key prefix
+const DefaultPrefix = "httpretry:webhook"
+
+// leaseScript 전송 예정 시각이 지난 항목을 가져오고, score를 lease 만료 시각으로 변경하여 점유
+//
+// Ack/Nack 없이 lease가 만료되면 score가 지나 다시 가져갈 수 있습니다.
+var leaseScript = redis.NewScript(`
+local ids = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+local result = {}
+for _, id in ipairs(ids) do
+	local data = redis.call('HGET', KEYS[2], id)
+	if data then
+		redis.call('ZADD', KEYS[1], ARGV[3], id)
+		table.insert(result, data)
+	else
+		redis.call('ZREM', KEYS[1], id)
+	end
+end
+return result
+`)
+
+// Storage Redis 기반 webhook.Storage
+//
+// 전송 예정 시각은 sorted set에, 전송 내용은 hash에 보관합니다.
+type Storage struct {
+	client  redis.UniversalClient
+	dueKey  string
+	dataKey string
+}
+
+var _ webhook.Storage = (*Storage)(nil)
+
+// New Redis 저장소를 생성
+//
+// Parameters:
+//   - client: (redis.UniversalClient) Redis 클라이언트
+//   - prefix: (string) key prefix. 비어 있으면 DefaultPrefix를 사용
+func New(client redis.UniversalClient, prefix string) *Storage {
+	if prefix == "" {
+		prefix = DefaultPrefix
+	}
+	// cluster에서도 두 key가 같은 slot에 위치하도록 hash tag 사용
+	return &Storage{
+		client:  client,
+		dueKey:  fmt.Sprintf("{%s}:due", prefix),
+		dataKey: fmt.Sprintf("{%s}:data", prefix),
+	}
+}
+
+// Put 전송을 대기열에 추가
+func (s *Storage) Put(ctx context.Context, delivery webhook.Delivery) error {
+	data, err := json.Marshal(delivery)
+	if err != nil {
+		return fmt.Errorf("marshalling delivery: %w", err)
+	}
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, s.dataKey, delivery.Event.ID, data)
+		pipe.ZAdd(ctx, s.dueKey, redis.Z{
+			Score:  float64(delivery.DueAt.UnixMilli()),
+			Member: delivery.Event.ID,
+		})
+		return nil
+	})
+	return err
+}
+
+// Lease DueAt이 지난 전송을 DueAt 순으로 최대 limit개 가져오고, lease 동안 점유
+func (s *Storage) Lease(ctx context.Context, limit int, lease time.Duration) ([]webhook.Delivery, error) {
+	now := time.Now()
+	values, err := leaseScript.Run(
+		ctx,
+		s.client,
+		[]string{s.dueKey, s.dataKey},
+		now.UnixMilli(),
+		limit,
+		now.Add(lease).UnixMilli(),
+	).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]webhook.Delivery, 0, len(values))
+	for _, value := range values {
+		var delivery webhook.Delivery
+		if err := json.Unmarshal([]byte(value), &delivery); err != nil {
+			return nil, fmt.Errorf("unmarshalling delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
+
+// Ack 전송을 대기열에서 삭제
+func (s *Storage) Ack(ctx context.Context, eventID string) error {
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, s.dueKey, eventID)
+		pipe.HDel(ctx, s.dataKey, eventID)
+		return nil
+	})
+	return err
+}
+
+// Nack 점유를 해제하고, 변경된 Attempt/DueAt으로 다시 대기열에 넣음
+func (s *Storage) Nack(ctx context.Context, delivery webhook.Delivery) error {
+	return s.Put(ctx, delivery)
+}
@@ -0,0 +1,65 @@
+package redisstore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/dings-things/httpretry/webhook"
+	"github.com/dings-things/httpretry/webhook/redisstore"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorage(t *testing.T) {
+	newStorage := func(t *testing.T) *redisstore.Storage {
+		server := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		t.Cleanup(func() { client.Close() })
+		return redisstore.New(client, "")
+	}
+	ctx := context.Background()
+
+	t.Run("전송 예정 시각이 지난 항목만 순서대로 가져오고, lease 동안 다시 가져가지 않는 테스트", func(t *testing.T) {
+		// given
+		storage := newStorage(t)
+		now := time.Now()
+		assert.NoError(t, storage.Put(ctx, webhook.Delivery{Event: webhook.Event{ID: "late"}, Attempt: 1, DueAt: now.Add(-time.Second)}))
+		assert.NoError(t, storage.Put(ctx, webhook.Delivery{Event: webhook.Event{ID: "early"}, Attempt: 2, DueAt: now.Add(-time.Minute)}))
+		assert.NoError(t, storage.Put(ctx, webhook.Delivery{Event: webhook.Event{ID: "future"}, Attempt: 1, DueAt: now.Add(time.Hour)}))
+
+		// when
+		leased, err := storage.Lease(ctx, 10, time.Minute)
+		leasedAgain, againErr := storage.Lease(ctx, 10, time.Minute)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, leased, 2)
+		assert.Equal(t, "early", leased[0].Event.ID)
+		assert.Equal(t, 2, leased[0].Attempt)
+		assert.Equal(t, "late", leased[1].Event.ID)
+		assert.NoError(t, againErr)
+		assert.Empty(t, leasedAgain, "lease 중인 항목은 다시 가져가지 않아야 합니다.")
+	})
+
+	t.Run("Nack 한 항목은 변경된 일정으로 다시 가져가고, Ack 한 항목은 삭제되는 테스트", func(t *testing.T) {
+		// given
+		storage := newStorage(t)
+		assert.NoError(t, storage.Put(ctx, webhook.Delivery{Event: webhook.Event{ID: "evt"}, Attempt: 1, DueAt: time.Now()}))
+		leased, _ := storage.Lease(ctx, 10, time.Minute)
+
+		// when
+		leased[0].Attempt = 2
+		leased[0].DueAt = time.Now().Add(-time.Millisecond)
+		assert.NoError(t, storage.Nack(ctx, leased[0]))
+		retried, _ := storage.Lease(ctx, 10, time.Minute)
+		assert.NoError(t, storage.Ack(ctx, "evt"))
+		acked, _ := storage.Lease(ctx, 10, 0)
+
+		// then
+		assert.Len(t, retried, 1)
+		assert.Equal(t, 2, retried[0].Attempt)
+		assert.Empty(t, acked)
+	})
+}
@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Standard Webhooks(https://www.standardwebhooks.com) 형식의 헤더
+const (
+	HeaderID        = "webhook-id"
+	HeaderTimestamp = "webhook-timestamp"
+	HeaderSignature = "webhook-signature"
+)
+
+// ErrInvalidSignature 서명 검증에 실패했음을 나타내는 에러
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// Sign webhook 서명을 생성
+//
+// "{id}.{timestamp}.{payload}"를 HMAC-SHA256으로 서명하고 "v1,{base64}" 형식으로 반환합니다.
+func Sign(secret []byte, id string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%s.%d.", id, timestamp)
+	mac.Write(payload)
+	return "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Verify 수신한 webhook의 서명을 검증
+//
+// 수신 서버에서 사용하며, timestamp가 tolerance 이상 차이 나는 경우 재전송 공격으로 보고 거부합니다.
+//
+// Parameters:
+//   - secret: ([]byte) 서명 키
+//   - header: (http.Header) 수신한 요청 헤더
+//   - payload: ([]byte) 수신한 바디
+//   - tolerance: (time.Duration) 허용하는 timestamp 오차
+func Verify(secret []byte, header http.Header, payload []byte, tolerance time.Duration) error {
+	timestamp, err := strconv.ParseInt(header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		return fmt.Errorf("parsing timestamp: %w", ErrInvalidSignature)
+	}
+	if diff := time.Since(time.Unix(timestamp, 0)); diff > tolerance || diff < -tolerance {
+		return fmt.Errorf("timestamp out of tolerance: %w", ErrInvalidSignature)
+	}
+
+	expected := Sign(secret, header.Get(HeaderID), timestamp, payload)
+	for _, signature := range strings.Fields(header.Get(HeaderSignature)) {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+type (
+	// Delivery 저장소에 보관되는 전송 대기 webhook
+	Delivery struct {
+		Event Event `json:"event"`
+		// Attempt 다음 전송 시도 번호 (1부터 시작)
+		Attempt int `json:"attempt"`
+		// DueAt 전송 예정 시각
+		DueAt time.Time `json:"due_at"`
+	}
+
+	// Storage 전송 대기열 저장소
+	//
+	// 여러 replica가 같은 저장소를 공유하면 재전송 상태도 공유됩니다.
+	// Lease로 가져간 전송은 Ack 또는 Nack 하기 전까지 다른 replica가 가져가지 않으며,
+	// lease 시간이 지나도록 Ack/Nack 되지 않으면(replica 장애 등) 다시 가져갈 수 있습니다.
+	Storage interface {
+		// Put 전송을 대기열에 추가
+		Put(ctx context.Context, delivery Delivery) error
+		// Lease DueAt이 지난 전송을 DueAt 순으로 최대 limit개 가져오고, lease 동안 점유
+		Lease(ctx context.Context, limit int, lease time.Duration) ([]Delivery, error)
+		// Ack 전송이 끝난(성공 또는 포기) 전송을 대기열에서 삭제
+		Ack(ctx context.Context, eventID string) error
+		// Nack 점유를 해제하고, 변경된 Attempt/DueAt으로 다시 대기열에 넣음
+		Nack(ctx context.Context, delivery Delivery) error
+	}
+
+	// memoryEntry 메모리 저장소의 항목
+	memoryEntry struct {
+		delivery    Delivery
+		leasedUntil time.Time
+	}
+
+	// MemoryStorage 프로세스 메모리에 대기열을 보관하는 저장소
+	//
+	// 프로세스가 종료되면 대기 중인 전송은 사라지며, replica 간에 공유되지 않습니다.
+	MemoryStorage struct {
+		mu      sync.Mutex
+		entries map[string]*memoryEntry
+	}
+)
+
+// NewMemoryStorage 메모리 저장소를 생성
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{entries: make(map[string]*memoryEntry)}
+}
+
+// Put 전송을 대기열에 추가
+func (s *MemoryStorage) Put(_ context.Context, delivery Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[delivery.Event.ID] = &memoryEntry{delivery: delivery}
+	return nil
+}
+
+// Lease DueAt이 지난 전송을 DueAt 순으로 최대 limit개 가져오고, lease 동안 점유
+func (s *MemoryStorage) Lease(_ context.Context, limit int, lease time.Duration) ([]Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var due []*memoryEntry
+	for _, entry := range s.entries {
+		if !entry.delivery.DueAt.After(now) && !entry.leasedUntil.After(now) {
+			due = append(due, entry)
+		}
+	}
+	slices.SortFunc(due, func(a, b *memoryEntry) int {
+		return a.delivery.DueAt.Compare(b.delivery.DueAt)
+	})
+
+	deliveries := make([]Delivery, 0, min(limit, len(due)))
+	for _, entry := range due[:min(limit, len(due))] {
+		entry.leasedUntil = now.Add(lease)
+		deliveries = append(deliveries, entry.delivery)
+	}
+	return deliveries, nil
+}
+
+// Ack 전송을 대기열에서 삭제
+func (s *MemoryStorage) Ack(_ context.Context, eventID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, eventID)
+	return nil
+}
+
+// Nack 점유를 해제하고 다시 대기열에 넣음
+func (s *MemoryStorage) Nack(_ context.Context, delivery Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[delivery.Event.ID] = &memoryEntry{delivery: delivery}
+	return nil
+}
@@ -0,0 +1,389 @@
+// Package webhook httpretry 클라이언트 위에서 서명된 webhook을 전송합니다
+//
+// 전송에 실패한 webhook은 재시도 일정(기본 1m, 5m, 30m, 2h)에 따라 다시 전송하며,
+// 수신 서버별로 서킷 브레이커를 두어 장애가 난 수신 서버로의 전송을 잠시 멈춥니다.
+// 전송 대기열은 Storage에 보관되며, 공유 저장소를 사용하면 여러 replica가 재전송 상태를 공유합니다.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/dings-things/httpretry"
+)
+
+// ErrDispatcherClosed Dispatcher가 종료되어 전송할 수 없음을 나타내는 에러
+var ErrDispatcherClosed = errors.New("webhook dispatcher is closed")
+
+// leaseBatchSize 한 번의 polling에서 가져오는 최대 전송 수
+const leaseBatchSize = 100
+
+// DefaultSchedule 기본 재전송 일정. 첫 전송 실패 이후 각 간격만큼 기다렸다가 재전송
+var DefaultSchedule = []time.Duration{
+	1 * time.Minute,
+	5 * time.Minute,
+	30 * time.Minute,
+	2 * time.Hour,
+}
+
+type (
+	// Event 전송할 webhook
+	Event struct {
+		// ID 수신 서버가 중복 수신을 판단하는 식별자. 비어 있으면 생성
+		ID string `json:"id"`
+		// URL 수신 서버 URL
+		URL string `json:"url"`
+		// Payload 전송할 바디
+		Payload []byte `json:"payload"`
+		// Header 추가로 전송할 헤더
+		Header http.Header `json:"header,omitempty"`
+	}
+
+	// Attempt 전송 시도 이력
+	Attempt struct {
+		EventID    string
+		URL        string
+		Number     int
+		StatusCode int
+		Err        error
+		StartedAt  time.Time
+		Duration   time.Duration
+		// NextAttemptAt 다음 재전송 예정 시각. 더 이상 재전송하지 않는 경우 zero value
+		NextAttemptAt time.Time
+		// Delivered 전송 성공 여부
+		Delivered bool
+	}
+
+	// Option Dispatcher 설정
+	Option func(*Dispatcher)
+
+	// Dispatcher 서명된 webhook을 재전송 일정에 따라 전송
+	Dispatcher struct {
+		client           httpretry.Doer
+		secret           []byte
+		schedule         []time.Duration
+		onAttempt        func(Attempt)
+		onError          func(error)
+		breakerThreshold int
+		breakerCooldown  time.Duration
+		storage          Storage
+		pollInterval     time.Duration
+		lease            time.Duration
+
+		mu       sync.Mutex
+		closed   bool
+		breakers map[string]*httpretry.CircuitBreaker
+		wake     chan struct{}
+		stop     chan struct{}
+		loopDone chan struct{}
+		ctx      context.Context // Close가 기다림을 포기하면 취소되어 진행 중인 전송을 중단
+		cancel   context.CancelFunc
+		inFlight sync.WaitGroup
+	}
+)
+
+// WithSchedule 재전송 일정을 변경하는 Option
+//
+// Parameters:
+//   - schedule: (...time.Duration) 실패 이후 재전송까지의 대기 시간 목록. 목록의 길이가 최대 재전송 횟수
+func WithSchedule(schedule ...time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.schedule = schedule
+	}
+}
+
+// WithAttemptListener 전송 시도마다 이력을 전달받는 Option
+//
+// 리스너는 전송 goroutine에서 호출되므로 오래 걸리는 작업을 하지 않아야 합니다.
+//
+// Parameters:
+//   - listener: (func(Attempt)) 전송 시도 이력 콜백
+func WithAttemptListener(listener func(Attempt)) Option {
+	return func(d *Dispatcher) {
+		d.onAttempt = listener
+	}
+}
+
+// WithErrorListener 저장소 에러를 전달받는 Option
+//
+// Lease 에러는 다음 polling에서 다시 시도하며, Ack/Nack 에러가 발생한 전송은 lease가 끝난 뒤 다시 전송될 수 있습니다.
+//
+// Parameters:
+//   - listener: (func(error)) 저장소 에러 콜백
+func WithErrorListener(listener func(error)) Option {
+	return func(d *Dispatcher) {
+		d.onError = listener
+	}
+}
+
+// WithStorage 전송 대기열 저장소를 변경하는 Option
+//
+// 기본으로 MemoryStorage가 적용되며, 여러 replica가 재전송 상태를 공유하려면 redisstore 등 공유 저장소를 사용합니다.
+//
+// Parameters:
+//   - storage: (Storage) 전송 대기열 저장소
+func WithStorage(storage Storage) Option {
+	return func(d *Dispatcher) {
+		d.storage = storage
+	}
+}
+
+// WithPollInterval 저장소에서 전송 예정 webhook을 가져오는 주기를 변경하는 Option
+//
+// 기본값은 1초입니다. Send로 추가한 webhook은 주기와 관계없이 즉시 전송됩니다.
+//
+// Parameters:
+//   - interval: (time.Duration) polling 주기
+func WithPollInterval(interval time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.pollInterval = interval
+	}
+}
+
+// WithLease 저장소에서 가져간 전송을 점유하는 시간을 변경하는 Option
+//
+// 기본값은 5분이며, 클라이언트의 전체 재시도 시간보다 길어야 중복 전송되지 않습니다.
+//
+// Parameters:
+//   - lease: (time.Duration) 점유 시간
+func WithLease(lease time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.lease = lease
+	}
+}
+
+// WithCircuitBreaker 수신 서버별 서킷 브레이커 설정을 변경하는 Option
+//
+// 기본값은 5회 연속 실패 시 10분간 전송을 멈추는 것입니다. 서킷이 열린 동안의 전송은 실패로 보고 다음 일정으로 넘깁니다.
+//
+// Parameters:
+//   - threshold: (int) 서킷을 여는 연속 실패 횟수
+//   - cooldown: (time.Duration) 서킷이 열린 뒤 다시 전송을 시도하기까지의 시간
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.breakerThreshold = threshold
+		d.breakerCooldown = cooldown
+	}
+}
+
+// NewDispatcher webhook Dispatcher를 생성하고 전송을 시작
+//
+// 저장소에 남아 있는 전송도 일정에 따라 전송하며, 사용이 끝나면 Close를 호출해야 합니다.
+//
+// Parameters:
+//   - client: (httpretry.Doer) 전송에 사용할 클라이언트. 보통 httpretry.NewClient로 생성한 재시도 클라이언트
+//   - secret: ([]byte) 서명 키
+//   - opts: (...Option) 기본 설정에서 변경이 필요한 경우, 추가되는 옵션 값
+func NewDispatcher(client httpretry.Doer, secret []byte, opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		client:           client,
+		secret:           secret,
+		schedule:         DefaultSchedule,
+		onAttempt:        func(Attempt) {},
+		onError:          func(error) {},
+		breakerThreshold: 5,
+		breakerCooldown:  10 * time.Minute,
+		storage:          NewMemoryStorage(),
+		pollInterval:     1 * time.Second,
+		lease:            5 * time.Minute,
+		breakers:         make(map[string]*httpretry.CircuitBreaker),
+		wake:             make(chan struct{}, 1),
+		stop:             make(chan struct{}),
+		loopDone:         make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+	go d.run()
+	return d
+}
+
+// Send webhook을 대기열에 추가하고 즉시 전송을 시작
+//
+// 전송은 백그라운드에서 수행되며, 결과는 WithAttemptListener로 전달됩니다.
+func (d *Dispatcher) Send(ctx context.Context, event Event) error {
+	if _, err := url.Parse(event.URL); err != nil {
+		return fmt.Errorf("parsing webhook url: %w", err)
+	}
+	if event.ID == "" {
+		event.ID = newEventID()
+	}
+
+	d.mu.Lock()
+	closed := d.closed
+	d.mu.Unlock()
+	if closed {
+		return ErrDispatcherClosed
+	}
+
+	if err := d.storage.Put(ctx, Delivery{Event: event, Attempt: 1, DueAt: time.Now()}); err != nil {
+		return fmt.Errorf("storing webhook: %w", err)
+	}
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Close 새 전송을 멈추고, 진행 중인 전송이 끝나기를 ctx가 만료될 때까지 기다림
+//
+// ctx가 만료되면 진행 중인 전송을 취소합니다. 취소된 webhook과 재전송 예정인 webhook은 저장소에 남아 있으며,
+// 같은 저장소로 생성한 Dispatcher가 이어서 전송합니다.
+func (d *Dispatcher) Close(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.stop)
+	}
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		<-d.loopDone
+		d.inFlight.Wait()
+		close(done)
+	}()
+	defer d.cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run 종료될 때까지 주기적으로 전송 예정 webhook을 가져와 전송
+func (d *Dispatcher) run() {
+	defer close(d.loopDone)
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.poll()
+		select {
+		case <-d.stop:
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// poll 저장소에서 전송 예정 webhook을 가져와 전송을 시작
+func (d *Dispatcher) poll() {
+	deliveries, err := d.storage.Lease(context.Background(), leaseBatchSize, d.lease)
+	if err != nil {
+		d.onError(fmt.Errorf("leasing webhooks: %w", err))
+		return
+	}
+	for _, delivery := range deliveries {
+		d.inFlight.Add(1)
+		go d.deliver(delivery)
+	}
+}
+
+// deliver webhook을 한 번 전송하고, 실패하면 다음 일정으로 저장소에 반환
+func (d *Dispatcher) deliver(delivery Delivery) {
+	defer d.inFlight.Done()
+
+	event := delivery.Event
+	attempt := Attempt{
+		EventID:   event.ID,
+		URL:       event.URL,
+		Number:    delivery.Attempt,
+		StartedAt: time.Now(),
+	}
+	var host string
+	if target, err := url.Parse(event.URL); err == nil {
+		host = target.Host
+	}
+	breaker := d.breaker(host)
+	if attempt.Err = breaker.Allow(); attempt.Err == nil {
+		attempt.StatusCode, attempt.Err = d.post(event, attempt.StartedAt)
+		attempt.Delivered = attempt.Err == nil
+		if attempt.Delivered || d.ctx.Err() == nil {
+			breaker.Record(attempt.Delivered)
+		}
+	}
+	attempt.Duration = time.Since(attempt.StartedAt)
+
+	ctx := context.Background()
+	if !attempt.Delivered && d.ctx.Err() != nil {
+		// 종료로 취소된 전송은 재전송 횟수를 소모하지 않고 바로 다시 전송되도록 반환
+		delivery.DueAt = time.Now()
+		if err := d.storage.Nack(ctx, delivery); err != nil {
+			d.onError(fmt.Errorf("returning cancelled webhook: %w", err))
+		}
+	} else if !attempt.Delivered && delivery.Attempt <= len(d.schedule) {
+		delivery.DueAt = time.Now().Add(d.schedule[delivery.Attempt-1])
+		delivery.Attempt++
+		if err := d.storage.Nack(ctx, delivery); err != nil {
+			d.onError(fmt.Errorf("rescheduling webhook: %w", err))
+		} else {
+			attempt.NextAttemptAt = delivery.DueAt
+		}
+	} else if err := d.storage.Ack(ctx, event.ID); err != nil {
+		d.onError(fmt.Errorf("acknowledging webhook: %w", err))
+	}
+	d.onAttempt(attempt)
+}
+
+// post 서명 헤더와 함께 webhook을 전송하고, 2xx가 아니면 에러를 반환
+func (d *Dispatcher) post(event Event, now time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, event.URL, bytes.NewReader(event.Payload))
+	if err != nil {
+		return 0, err
+	}
+	for key, values := range event.Header {
+		req.Header[key] = values
+	}
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	timestamp := now.Unix()
+	req.Header.Set(HeaderID, event.ID)
+	req.Header.Set(HeaderTimestamp, fmt.Sprint(timestamp))
+	req.Header.Set(HeaderSignature, Sign(d.secret, event.ID, timestamp, event.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// breaker 수신 서버의 서킷 브레이커를 반환
+func (d *Dispatcher) breaker(host string) *httpretry.CircuitBreaker {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	breaker, ok := d.breakers[host]
+	if !ok {
+		breaker = httpretry.NewCircuitBreaker(d.breakerThreshold, d.breakerCooldown)
+		d.breakers[host] = breaker
+	}
+	return breaker
+}
+
+// newEventID 임의의 webhook ID를 생성
+func newEventID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return "msg_" + hex.EncodeToString(id)
+}
@@ -0,0 +1,185 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dings-things/httpretry"
+	"github.com/dings-things/httpretry/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDispatcher(t *testing.T) {
+	secret := []byte("whsec_test")
+
+	t.Run("실패한 webhook을 일정에 따라 서명과 함께 재전송하는 테스트", func(t *testing.T) {
+		// given
+		var (
+			mu         sync.Mutex
+			reqCount   int
+			verifyErrs []error
+		)
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				payload, _ := io.ReadAll(r.Body)
+				mu.Lock()
+				defer mu.Unlock()
+				reqCount++
+				verifyErrs = append(verifyErrs, webhook.Verify(secret, r.Header, payload, time.Minute))
+				if reqCount < 3 {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}),
+		)
+		defer testServer.Close()
+
+		attempts := make(chan webhook.Attempt, 10)
+		dispatcher := webhook.NewDispatcher(
+			httpretry.NewClient(httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(1),
+				httpretry.WithBackoffPolicy(func(int) time.Duration { return 0 }),
+			)),
+			secret,
+			webhook.WithSchedule(10*time.Millisecond, 10*time.Millisecond, 10*time.Millisecond),
+			webhook.WithPollInterval(5*time.Millisecond),
+			webhook.WithAttemptListener(func(attempt webhook.Attempt) { attempts <- attempt }),
+		)
+
+		// when
+		err := dispatcher.Send(context.Background(), webhook.Event{ID: "evt_1", URL: testServer.URL, Payload: []byte(`{"type":"ping"}`)})
+
+		// then
+		assert.NoError(t, err)
+		var history []webhook.Attempt
+		for attempt := range attempts {
+			history = append(history, attempt)
+			if attempt.Delivered || attempt.NextAttemptAt.IsZero() {
+				break
+			}
+		}
+		assert.Len(t, history, 3)
+		assert.Error(t, history[0].Err)
+		assert.False(t, history[0].NextAttemptAt.IsZero())
+		assert.True(t, history[2].Delivered)
+		assert.Equal(t, http.StatusNoContent, history[2].StatusCode)
+		assert.Equal(t, 3, history[2].Number)
+		for _, verifyErr := range verifyErrs {
+			assert.NoError(t, verifyErr)
+		}
+		assert.NoError(t, dispatcher.Close(context.Background()))
+	})
+
+	t.Run("수신 서버 서킷이 열린 경우, 전송하지 않고 다음 일정으로 넘기는 테스트", func(t *testing.T) {
+		// given
+		var reqCount int
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reqCount++
+				w.WriteHeader(http.StatusInternalServerError)
+			}),
+		)
+		defer testServer.Close()
+
+		attempts := make(chan webhook.Attempt, 10)
+		dispatcher := webhook.NewDispatcher(
+			httpretry.NewClient(httpretry.NewHTTPSettings(
+				httpretry.WithMaxRetry(1),
+				httpretry.WithBackoffPolicy(func(int) time.Duration { return 0 }),
+			)),
+			secret,
+			webhook.WithSchedule(10*time.Millisecond, 10*time.Millisecond),
+			webhook.WithPollInterval(5*time.Millisecond),
+			webhook.WithCircuitBreaker(1, time.Hour),
+			webhook.WithAttemptListener(func(attempt webhook.Attempt) { attempts <- attempt }),
+		)
+
+		// when
+		err := dispatcher.Send(context.Background(), webhook.Event{URL: testServer.URL, Payload: []byte(`{}`)})
+
+		// then
+		assert.NoError(t, err)
+		var history []webhook.Attempt
+		for attempt := range attempts {
+			history = append(history, attempt)
+			if attempt.NextAttemptAt.IsZero() {
+				break
+			}
+		}
+		assert.Len(t, history, 3)
+		assert.ErrorIs(t, history[1].Err, httpretry.ErrCircuitOpen)
+		assert.ErrorIs(t, history[2].Err, httpretry.ErrCircuitOpen)
+		assert.Equal(t, 1, reqCount)
+		assert.NoError(t, dispatcher.Close(context.Background()))
+	})
+
+	t.Run("종료된 Dispatcher는 전송을 거부하는 테스트", func(t *testing.T) {
+		// given
+		dispatcher := webhook.NewDispatcher(http.DefaultClient, secret)
+		assert.NoError(t, dispatcher.Close(context.Background()))
+
+		// when
+		err := dispatcher.Send(context.Background(), webhook.Event{URL: "http://example.com"})
+
+		// then
+		assert.ErrorIs(t, err, webhook.ErrDispatcherClosed)
+	})
+
+	t.Run("종료 대기 시간이 지나면, 진행 중인 전송을 취소하고 저장소에 남기는 테스트", func(t *testing.T) {
+		// given
+		received := make(chan struct{}, 1)
+		release := make(chan struct{})
+		testServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received <- struct{}{}
+				select {
+				case <-r.Context().Done():
+				case <-release:
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}),
+		)
+		defer testServer.Close()
+		defer close(release)
+
+		storage := webhook.NewMemoryStorage()
+		attempts := make(chan webhook.Attempt, 1)
+		dispatcher := webhook.NewDispatcher(
+			http.DefaultClient,
+			secret,
+			webhook.WithStorage(storage),
+			webhook.WithPollInterval(5*time.Millisecond),
+			webhook.WithAttemptListener(func(attempt webhook.Attempt) { attempts <- attempt }),
+		)
+		assert.NoError(t, dispatcher.Send(context.Background(), webhook.Event{ID: "evt_slow", URL: testServer.URL, Payload: []byte(`{}`)}))
+		<-received
+
+		// when
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := dispatcher.Close(ctx)
+
+		// then
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		select {
+		case attempt := <-attempts:
+			assert.False(t, attempt.Delivered)
+			assert.ErrorIs(t, attempt.Err, context.Canceled)
+			assert.True(t, attempt.NextAttemptAt.IsZero())
+		case <-time.After(time.Second):
+			t.Fatal("in-flight delivery was not cancelled")
+		}
+		deliveries, leaseErr := storage.Lease(context.Background(), 10, time.Minute)
+		assert.NoError(t, leaseErr)
+		if assert.Len(t, deliveries, 1) {
+			assert.Equal(t, "evt_slow", deliveries[0].Event.ID)
+			assert.Equal(t, 1, deliveries[0].Attempt)
+		}
+	})
+}
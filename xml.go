@@ -0,0 +1,71 @@
+package httpretry
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// contentTypeXML XML 요청 바디의 Content-Type
+const contentTypeXML = "application/xml; charset=utf-8"
+
+// GetXML GET 요청의 XML 응답을 out에 디코딩
+//
+// 재시도는 client의 재시도 정책을 따르며, 2xx가 아닌 최종 응답은 에러로 반환합니다.
+//
+//	var rates ExchangeRates
+//	err := httpretry.GetXML(ctx, client, "https://legacy.example.com/rates.xml", &rates)
+//
+// Parameters:
+//   - ctx: (context.Context) 요청 context
+//   - client: (Doer) 요청에 사용할 클라이언트. 보통 NewClient로 생성한 재시도 클라이언트
+//   - url: (string) 요청 URL
+//   - out: (any) 응답을 디코딩할 값의 포인터. nil인 경우 응답 바디를 버림
+func GetXML(ctx context.Context, client Doer, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	return doXML(client, req, out)
+}
+
+// PostXML in을 XML로 인코딩하여 POST 요청하고, XML 응답을 out에 디코딩
+//
+// 요청 바디는 XML 선언(<?xml ...?>)을 포함하며, 재시도할 때마다 같은 바디를 다시 전송합니다.
+// SOAP API처럼 추가 헤더(SOAPAction 등)가 필요하면 요청을 직접 만들어 client.Do를 사용합니다.
+//
+//	var result CreateOrderResponse
+//	err := httpretry.PostXML(ctx, client, "https://legacy.example.com/orders", CreateOrder{ID: 1}, &result)
+//
+// Parameters:
+//   - ctx: (context.Context) 요청 context
+//   - client: (Doer) 요청에 사용할 클라이언트. 보통 NewClient로 생성한 재시도 클라이언트
+//   - url: (string) 요청 URL
+//   - in: (any) XML로 인코딩할 요청 바디
+//   - out: (any) 응답을 디코딩할 값의 포인터. nil인 경우 응답 바디를 버림
+func PostXML(ctx context.Context, client Doer, url string, in, out any) error {
+	body, err := xml.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("encoding xml request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(append([]byte(xml.Header), body...)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentTypeXML)
+	return doXML(client, req, out)
+}
+
+// doXML 요청을 전송하고 2xx 응답의 XML 바디를 out에 디코딩
+func doXML(client Doer, req *http.Request, out any) error {
+	req.Header.Set("Accept", "application/xml, text/xml")
+	return doDecode(client, req, out, func(r io.Reader) error {
+		if err := xml.NewDecoder(r).Decode(out); err != nil {
+			return fmt.Errorf("decoding xml response: %w", err)
+		}
+		return nil
+	})
+}
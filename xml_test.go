@@ -0,0 +1,110 @@
+package httpretry_test
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dings-things/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+type xmlOrder struct {
+	XMLName xml.Name `xml:"order"`
+	ID      int      `xml:"id,attr"`
+	Item    string   `xml:"item"`
+}
+
+func TestXML(t *testing.T) {
+	newClient := func() *http.Client {
+		return httpretry.NewClient(httpretry.NewHTTPSettings(
+			httpretry.WithBackoffPolicy(noBackoff),
+			httpretry.WithRetryMethods(http.MethodGet, http.MethodPost),
+		))
+	}
+
+	t.Run("GetXML로 재시도한 응답을 디코딩하는 테스트", func(t *testing.T) {
+		// given
+		var received atomic.Int32
+		var accept string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			accept = r.Header.Get("Accept")
+			if received.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("Content-Type", "text/xml")
+			w.Write([]byte(`<?xml version="1.0"?><order id="7"><item>book</item></order>`))
+		}))
+		defer server.Close()
+		var order xmlOrder
+
+		// when
+		err := httpretry.GetXML(context.Background(), newClient(), server.URL, &order)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, 7, order.ID)
+		assert.Equal(t, "book", order.Item)
+		assert.Equal(t, int32(2), received.Load())
+		assert.Contains(t, accept, "application/xml")
+	})
+
+	t.Run("PostXML이 재시도마다 같은 XML 바디를 전송하는 테스트", func(t *testing.T) {
+		// given
+		var received atomic.Int32
+		var bodies []string
+		var contentType string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			contentType = r.Header.Get("Content-Type")
+			if received.Add(1) == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.Write([]byte(`<order id="8"><item>pen</item></order>`))
+		}))
+		defer server.Close()
+		var created xmlOrder
+
+		// when
+		err := httpretry.PostXML(context.Background(), newClient(), server.URL, xmlOrder{ID: 8, Item: "pen"}, &created)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, xmlOrder{XMLName: xml.Name{Local: "order"}, ID: 8, Item: "pen"}, created)
+		assert.Equal(t, "application/xml; charset=utf-8", contentType)
+		assert.Len(t, bodies, 2)
+		assert.Equal(t, bodies[0], bodies[1])
+		assert.Equal(t, xml.Header+`<order id="8"><item>pen</item></order>`, bodies[0])
+	})
+
+	t.Run("2xx가 아닌 응답과 잘못된 XML은 에러를 반환하는 테스트", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/missing" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(`<order id="x">`))
+		}))
+		defer server.Close()
+		var order xmlOrder
+
+		// when
+		statusErr := httpretry.GetXML(context.Background(), newClient(), server.URL+"/missing?token=secret", &order)
+		decodeErr := httpretry.GetXML(context.Background(), newClient(), server.URL, &order)
+		discardErr := httpretry.PostXML(context.Background(), newClient(), server.URL, xmlOrder{ID: 1}, nil)
+
+		// then
+		assert.ErrorContains(t, statusErr, "unexpected status code 404 from GET")
+		assert.NotContains(t, statusErr.Error(), "secret")
+		assert.ErrorContains(t, decodeErr, "decoding xml response")
+		assert.NoError(t, discardErr)
+	})
+}